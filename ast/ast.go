@@ -0,0 +1,106 @@
+// Package ast represents the syntax tree of the parsed JSONPath.
+package ast
+
+// Node represents any node of the syntax tree.
+type Node interface {
+	node()
+}
+
+// Identifier represents the node identifier that starts the query.
+type Identifier int
+
+const (
+	// RootIdentifier represents the root node identifier '$'.
+	RootIdentifier Identifier = iota
+	// CurrentIdentifier represents the current node identifier '@'.
+	CurrentIdentifier
+)
+
+func (i Identifier) String() string {
+	if i == CurrentIdentifier {
+		return `@`
+	}
+	return `$`
+}
+
+// Query represents the query that consists of the identifier and the segments.
+// The filter expression and the function arguments also use it for the embedded query.
+type Query struct {
+	Identifier Identifier
+	Segments   []Segment
+}
+
+// Segment represents the segment of the query.
+type Segment interface {
+	Node
+	segment()
+}
+
+// ChildSegment represents the child segment such as `.a`, `['a','b']`, `[0]` and `[?(@.a)]`.
+type ChildSegment struct {
+	Selectors []Selector
+}
+
+// DescendantSegment represents the descendant segment such as `..a` and `..[0]`.
+type DescendantSegment struct {
+	Selectors []Selector
+}
+
+// FunctionSegment represents the function appended to the end of the query such as `.max()`.
+// Aggregate is true if the function receives all the values retrieved by the preceding segments.
+type FunctionSegment struct {
+	Function  *Function
+	Aggregate bool
+}
+
+// Selector represents the selector of the segment.
+type Selector interface {
+	Node
+	selector()
+}
+
+// NameSelector represents the member name such as `a` and `['a']`.
+type NameSelector struct {
+	Name string
+}
+
+// WildcardSelector represents the wildcard `*`.
+type WildcardSelector struct{}
+
+// IndexSelector represents the array index such as `[0]` and `[-1]`.
+type IndexSelector struct {
+	Index int
+}
+
+// SliceSelector represents the array slice such as `[1:3]` and `[::-1]`.
+// The omitted parameters are nil.
+type SliceSelector struct {
+	Start *int
+	End   *int
+	Step  *int
+}
+
+// FilterSelector represents the filter such as `[?(@.a > 1)]`.
+type FilterSelector struct {
+	Expression Expression
+}
+
+func (*Query) node()             {}
+func (*ChildSegment) node()      {}
+func (*DescendantSegment) node() {}
+func (*FunctionSegment) node()   {}
+func (*NameSelector) node()      {}
+func (*WildcardSelector) node()  {}
+func (*IndexSelector) node()     {}
+func (*SliceSelector) node()     {}
+func (*FilterSelector) node()    {}
+
+func (*ChildSegment) segment()      {}
+func (*DescendantSegment) segment() {}
+func (*FunctionSegment) segment()   {}
+
+func (*NameSelector) selector()     {}
+func (*WildcardSelector) selector() {}
+func (*IndexSelector) selector()    {}
+func (*SliceSelector) selector()    {}
+func (*FilterSelector) selector()   {}
@@ -0,0 +1,119 @@
+package ast
+
+// Expression represents the logical expression of the filter.
+type Expression interface {
+	Node
+	expression()
+}
+
+// LogicalOr represents the logical OR `||`.
+type LogicalOr struct {
+	Left  Expression
+	Right Expression
+}
+
+// LogicalAnd represents the logical AND `&&`.
+type LogicalAnd struct {
+	Left  Expression
+	Right Expression
+}
+
+// LogicalNot represents the logical NOT `!`.
+type LogicalNot struct {
+	Expression Expression
+}
+
+// Comparison represents the comparison such as `@.a > 1`.
+// The literal is always placed on the right side.
+type Comparison struct {
+	Left     Comparable
+	Operator Operator
+	Right    Comparable
+}
+
+// RegexMatch represents the regular expression match such as `@.a =~ /^a/`.
+// The pattern does not include the delimiters and the escape of the slashes.
+type RegexMatch struct {
+	Left    Comparable
+	Pattern string
+}
+
+// ExistenceTest represents the test that the query selects at least one node such as `@.a`.
+type ExistenceTest struct {
+	Query *Query
+}
+
+// FunctionTest represents the test by the function result such as `match(@.a, 'a.*')`.
+type FunctionTest struct {
+	Function *Function
+}
+
+// Comparable represents the operand of the comparison.
+// It is one of *Literal, *Query and *Function.
+type Comparable interface {
+	Node
+	comparableNode()
+}
+
+// Literal represents the literal value.
+// The value is one of float64, string, bool and nil.
+type Literal struct {
+	Value any
+}
+
+// Operator represents the comparison operator.
+type Operator int
+
+const (
+	// EQ represents the operator `==`.
+	EQ Operator = iota
+	// NE represents the operator `!=`.
+	NE
+	// LT represents the operator `<`.
+	LT
+	// LE represents the operator `<=`.
+	LE
+	// GT represents the operator `>`.
+	GT
+	// GE represents the operator `>=`.
+	GE
+)
+
+func (o Operator) String() string {
+	switch o {
+	case EQ:
+		return `==`
+	case NE:
+		return `!=`
+	case LT:
+		return `<`
+	case LE:
+		return `<=`
+	case GT:
+		return `>`
+	case GE:
+		return `>=`
+	}
+	return `unknown`
+}
+
+func (*LogicalOr) node()     {}
+func (*LogicalAnd) node()    {}
+func (*LogicalNot) node()    {}
+func (*Comparison) node()    {}
+func (*RegexMatch) node()    {}
+func (*ExistenceTest) node() {}
+func (*FunctionTest) node()  {}
+func (*Literal) node()       {}
+
+func (*LogicalOr) expression()     {}
+func (*LogicalAnd) expression()    {}
+func (*LogicalNot) expression()    {}
+func (*Comparison) expression()    {}
+func (*RegexMatch) expression()    {}
+func (*ExistenceTest) expression() {}
+func (*FunctionTest) expression()  {}
+
+func (*Literal) comparableNode()  {}
+func (*Query) comparableNode()    {}
+func (*Function) comparableNode() {}
@@ -0,0 +1,27 @@
+package ast
+
+// Function represents the function call.
+type Function struct {
+	Name      string
+	Arguments []Argument
+}
+
+// Argument represents the argument of the function.
+// It is one of *Literal, *Query, *Function and the Expression.
+type Argument interface {
+	Node
+	argument()
+}
+
+func (*Function) node() {}
+
+func (*Literal) argument()       {}
+func (*Query) argument()         {}
+func (*Function) argument()      {}
+func (*LogicalOr) argument()     {}
+func (*LogicalAnd) argument()    {}
+func (*LogicalNot) argument()    {}
+func (*Comparison) argument()    {}
+func (*RegexMatch) argument()    {}
+func (*ExistenceTest) argument() {}
+func (*FunctionTest) argument()  {}
@@ -0,0 +1,49 @@
+package ast
+
+// Inspect traverses the syntax tree in depth-first order.
+// It calls f for each node and skips the children of the node if f returns false.
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+
+	switch typedNode := node.(type) {
+	case *Query:
+		for _, segment := range typedNode.Segments {
+			Inspect(segment, f)
+		}
+	case *ChildSegment:
+		for _, selector := range typedNode.Selectors {
+			Inspect(selector, f)
+		}
+	case *DescendantSegment:
+		for _, selector := range typedNode.Selectors {
+			Inspect(selector, f)
+		}
+	case *FunctionSegment:
+		Inspect(typedNode.Function, f)
+	case *FilterSelector:
+		Inspect(typedNode.Expression, f)
+	case *LogicalOr:
+		Inspect(typedNode.Left, f)
+		Inspect(typedNode.Right, f)
+	case *LogicalAnd:
+		Inspect(typedNode.Left, f)
+		Inspect(typedNode.Right, f)
+	case *LogicalNot:
+		Inspect(typedNode.Expression, f)
+	case *Comparison:
+		Inspect(typedNode.Left, f)
+		Inspect(typedNode.Right, f)
+	case *RegexMatch:
+		Inspect(typedNode.Left, f)
+	case *ExistenceTest:
+		Inspect(typedNode.Query, f)
+	case *FunctionTest:
+		Inspect(typedNode.Function, f)
+	case *Function:
+		for _, argument := range typedNode.Arguments {
+			Inspect(argument, f)
+		}
+	}
+}
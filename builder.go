@@ -0,0 +1,382 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// Builder represents the JSONPath under construction.
+// Each method returns a new Builder, so a Builder can be shared as the common prefix.
+type Builder struct {
+	identifier ast.Identifier
+	segments   []ast.Segment
+	err        error
+}
+
+// Root returns the Builder that starts with the root node identifier '$'.
+func Root() *Builder {
+	return &Builder{identifier: ast.RootIdentifier}
+}
+
+// Cur returns the Builder that starts with the current node identifier '@'.
+// It is used for the queries in the filter.
+func Cur() *Builder {
+	return &Builder{identifier: ast.CurrentIdentifier}
+}
+
+func (b *Builder) appendSegment(segment ast.Segment, err error) *Builder {
+	if err == nil {
+		err = b.err
+	}
+	return &Builder{
+		identifier: b.identifier,
+		segments:   append(slices.Clip(b.segments), segment),
+		err:        err,
+	}
+}
+
+// Child appends the child segment that selects the members of the given names.
+func (b *Builder) Child(names ...string) *Builder {
+	return b.appendSegment(&ast.ChildSegment{Selectors: nameSelectors(names)}, nil)
+}
+
+// Descendant appends the descendant segment that selects the members of the given names.
+func (b *Builder) Descendant(names ...string) *Builder {
+	return b.appendSegment(&ast.DescendantSegment{Selectors: nameSelectors(names)}, nil)
+}
+
+// Wildcard appends the child segment that selects all the children.
+func (b *Builder) Wildcard() *Builder {
+	return b.appendSegment(&ast.ChildSegment{Selectors: []ast.Selector{&ast.WildcardSelector{}}}, nil)
+}
+
+// DescendantWildcard appends the descendant segment that selects all the descendants.
+func (b *Builder) DescendantWildcard() *Builder {
+	return b.appendSegment(&ast.DescendantSegment{Selectors: []ast.Selector{&ast.WildcardSelector{}}}, nil)
+}
+
+// Index appends the child segment that selects the array elements of the given indexes.
+func (b *Builder) Index(indexes ...int) *Builder {
+	selectors := make([]ast.Selector, len(indexes))
+	for index := range indexes {
+		selectors[index] = &ast.IndexSelector{Index: indexes[index]}
+	}
+	return b.appendSegment(&ast.ChildSegment{Selectors: selectors}, nil)
+}
+
+// Slice appends the child segment that selects the array elements from start to end.
+// Use Select with ast.SliceSelector to omit the parameters or to specify the step.
+func (b *Builder) Slice(start, end int) *Builder {
+	return b.appendSegment(&ast.ChildSegment{Selectors: []ast.Selector{
+		&ast.SliceSelector{Start: &start, End: &end},
+	}}, nil)
+}
+
+// Select appends the child segment that consists of the given selectors.
+func (b *Builder) Select(selectors ...ast.Selector) *Builder {
+	return b.appendSegment(&ast.ChildSegment{Selectors: selectors}, nil)
+}
+
+// DescendantSelect appends the descendant segment that consists of the given selectors.
+func (b *Builder) DescendantSelect(selectors ...ast.Selector) *Builder {
+	return b.appendSegment(&ast.DescendantSegment{Selectors: selectors}, nil)
+}
+
+// Filter appends the child segment that selects the children matching the condition.
+func (b *Builder) Filter(condition *Condition) *Builder {
+	return b.appendSegment(&ast.ChildSegment{Selectors: []ast.Selector{
+		&ast.FilterSelector{Expression: condition.expression},
+	}}, condition.err)
+}
+
+// Function appends the function such as `.max()` to the end of the query.
+// The arguments are the literals, the *Builder, the *Call and the *Condition.
+func (b *Builder) Function(name string, arguments ...any) *Builder {
+	function, err := newFunction(name, arguments)
+	return b.appendSegment(&ast.FunctionSegment{Function: function}, err)
+}
+
+// AST returns the syntax tree of the query under construction.
+func (b *Builder) AST() *ast.Query {
+	return &ast.Query{
+		Identifier: b.identifier,
+		Segments:   slices.Clone(b.segments),
+	}
+}
+
+// String returns the JSONPath in the canonical form.
+func (b *Builder) String() string {
+	return formatQuery(b.AST(), defaultFormatStyle)
+}
+
+// Err returns the first error found while building the query.
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// Compile returns the compiled JSONPath.
+func (b *Builder) Compile(config ...config.Config) (*Query, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return Compile(b.String(), config...)
+}
+
+// Exists returns the condition that the query selects at least one node.
+func (b *Builder) Exists() *Condition {
+	return &Condition{expression: &ast.ExistenceTest{Query: b.AST()}, err: b.err}
+}
+
+// Eq returns the condition that the query is equal to the value.
+func (b *Builder) Eq(value any) *Condition {
+	return newComparison(b.AST(), b.err, ast.EQ, value)
+}
+
+// Ne returns the condition that the query is not equal to the value.
+func (b *Builder) Ne(value any) *Condition {
+	return newComparison(b.AST(), b.err, ast.NE, value)
+}
+
+// Lt returns the condition that the query is less than the value.
+func (b *Builder) Lt(value any) *Condition {
+	return newComparison(b.AST(), b.err, ast.LT, value)
+}
+
+// Le returns the condition that the query is less than or equal to the value.
+func (b *Builder) Le(value any) *Condition {
+	return newComparison(b.AST(), b.err, ast.LE, value)
+}
+
+// Gt returns the condition that the query is greater than the value.
+func (b *Builder) Gt(value any) *Condition {
+	return newComparison(b.AST(), b.err, ast.GT, value)
+}
+
+// Ge returns the condition that the query is greater than or equal to the value.
+func (b *Builder) Ge(value any) *Condition {
+	return newComparison(b.AST(), b.err, ast.GE, value)
+}
+
+// Match returns the condition that the query matches the regular expression.
+func (b *Builder) Match(pattern string) *Condition {
+	return &Condition{expression: &ast.RegexMatch{Left: b.AST(), Pattern: pattern}, err: b.err}
+}
+
+// Call represents the function call in the filter.
+type Call struct {
+	function *ast.Function
+	err      error
+}
+
+// NewCall returns the function call in the filter such as `length(@.a)`.
+// The arguments are the literals, the *Builder, the *Call and the *Condition.
+func NewCall(name string, arguments ...any) *Call {
+	function, err := newFunction(name, arguments)
+	return &Call{function: function, err: err}
+}
+
+// Test returns the condition that the function result is true or not empty.
+func (c *Call) Test() *Condition {
+	return &Condition{expression: &ast.FunctionTest{Function: c.function}, err: c.err}
+}
+
+// Eq returns the condition that the function result is equal to the value.
+func (c *Call) Eq(value any) *Condition {
+	return newComparison(c.function, c.err, ast.EQ, value)
+}
+
+// Ne returns the condition that the function result is not equal to the value.
+func (c *Call) Ne(value any) *Condition {
+	return newComparison(c.function, c.err, ast.NE, value)
+}
+
+// Lt returns the condition that the function result is less than the value.
+func (c *Call) Lt(value any) *Condition {
+	return newComparison(c.function, c.err, ast.LT, value)
+}
+
+// Le returns the condition that the function result is less than or equal to the value.
+func (c *Call) Le(value any) *Condition {
+	return newComparison(c.function, c.err, ast.LE, value)
+}
+
+// Gt returns the condition that the function result is greater than the value.
+func (c *Call) Gt(value any) *Condition {
+	return newComparison(c.function, c.err, ast.GT, value)
+}
+
+// Ge returns the condition that the function result is greater than or equal to the value.
+func (c *Call) Ge(value any) *Condition {
+	return newComparison(c.function, c.err, ast.GE, value)
+}
+
+// Match returns the condition that the function result matches the regular expression.
+func (c *Call) Match(pattern string) *Condition {
+	return &Condition{expression: &ast.RegexMatch{Left: c.function, Pattern: pattern}, err: c.err}
+}
+
+// Condition represents the logical expression of the filter.
+type Condition struct {
+	expression ast.Expression
+	err        error
+}
+
+// And returns the condition that all the conditions are satisfied.
+func (c *Condition) And(conditions ...*Condition) *Condition {
+	result := c
+	for _, condition := range conditions {
+		result = &Condition{
+			expression: &ast.LogicalAnd{Left: result.expression, Right: condition.expression},
+			err:        firstError(result.err, condition.err),
+		}
+	}
+	return result
+}
+
+// Or returns the condition that any of the conditions is satisfied.
+func (c *Condition) Or(conditions ...*Condition) *Condition {
+	result := c
+	for _, condition := range conditions {
+		result = &Condition{
+			expression: &ast.LogicalOr{Left: result.expression, Right: condition.expression},
+			err:        firstError(result.err, condition.err),
+		}
+	}
+	return result
+}
+
+// Not returns the negation of the condition.
+// The JSONPath syntax cannot negate the comparisons other than `==` and `!=` and the regular expressions.
+func Not(condition *Condition) *Condition {
+	expression, err := negateExpression(condition.expression)
+	return &Condition{expression: expression, err: firstError(condition.err, err)}
+}
+
+func negateExpression(expression ast.Expression) (ast.Expression, error) {
+	switch typedExpression := expression.(type) {
+	case *ast.LogicalOr:
+		left, leftErr := negateExpression(typedExpression.Left)
+		right, rightErr := negateExpression(typedExpression.Right)
+		return &ast.LogicalAnd{Left: left, Right: right}, firstError(leftErr, rightErr)
+	case *ast.LogicalAnd:
+		left, leftErr := negateExpression(typedExpression.Left)
+		right, rightErr := negateExpression(typedExpression.Right)
+		return &ast.LogicalOr{Left: left, Right: right}, firstError(leftErr, rightErr)
+	case *ast.LogicalNot:
+		return typedExpression.Expression, nil
+	case *ast.Comparison:
+		switch typedExpression.Operator {
+		case ast.EQ:
+			return &ast.Comparison{Left: typedExpression.Left, Operator: ast.NE, Right: typedExpression.Right}, nil
+		case ast.NE:
+			return &ast.Comparison{Left: typedExpression.Left, Operator: ast.EQ, Right: typedExpression.Right}, nil
+		}
+		return expression, errors.NewErrorInvalidArgument(
+			typedExpression.Operator.String(), fmt.Errorf(`negation is not supported`))
+	case *ast.RegexMatch:
+		return expression, errors.NewErrorInvalidArgument(
+			`=~`, fmt.Errorf(`negation is not supported`))
+	}
+	return &ast.LogicalNot{Expression: expression}, nil
+}
+
+func nameSelectors(names []string) []ast.Selector {
+	selectors := make([]ast.Selector, len(names))
+	for index := range names {
+		selectors[index] = &ast.NameSelector{Name: names[index]}
+	}
+	return selectors
+}
+
+func newComparison(left ast.Comparable, leftErr error, operator ast.Operator, value any) *Condition {
+	right, rightErr := newComparable(value)
+	return &Condition{
+		expression: &ast.Comparison{Left: left, Operator: operator, Right: right},
+		err:        firstError(leftErr, rightErr),
+	}
+}
+
+func newComparable(value any) (ast.Comparable, error) {
+	switch typedValue := value.(type) {
+	case *Builder:
+		return typedValue.AST(), typedValue.err
+	case *Call:
+		return typedValue.function, typedValue.err
+	}
+	return newLiteral(value)
+}
+
+func newFunction(name string, arguments []any) (*ast.Function, error) {
+	function := &ast.Function{
+		Name:      name,
+		Arguments: make([]ast.Argument, len(arguments)),
+	}
+	var err error
+	for index := range arguments {
+		var argumentErr error
+		if condition, ok := arguments[index].(*Condition); ok {
+			function.Arguments[index], argumentErr = condition.expression.(ast.Argument), condition.err
+		} else {
+			var argument ast.Comparable
+			argument, argumentErr = newComparable(arguments[index])
+			function.Arguments[index] = argument.(ast.Argument)
+		}
+		err = firstError(err, argumentErr)
+	}
+	return function, err
+}
+
+func newLiteral(value any) (*ast.Literal, error) {
+	switch typedValue := value.(type) {
+	case nil, bool, string:
+		return &ast.Literal{Value: typedValue}, nil
+	case float64:
+		if math.IsNaN(typedValue) || math.IsInf(typedValue, 0) {
+			break
+		}
+		return &ast.Literal{Value: typedValue}, nil
+	case float32:
+		return newLiteral(float64(typedValue))
+	case int:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case int8:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case int16:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case int32:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case int64:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case uint:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case uint8:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case uint16:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case uint32:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case uint64:
+		return &ast.Literal{Value: float64(typedValue)}, nil
+	case json.Number:
+		if number, err := typedValue.Float64(); err == nil {
+			return newLiteral(number)
+		}
+	}
+	return &ast.Literal{}, errors.NewErrorInvalidArgument(
+		fmt.Sprint(value), fmt.Errorf(`unsupported literal type %T`, value))
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
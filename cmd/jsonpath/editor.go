@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+)
+
+// deletedValue marks the list elements to be removed,
+// so that the indexes of the other paths remain valid until all of them are deleted.
+var deletedValue = &struct{}{}
+
+// editAll replaces or deletes the values selected by each query in order, and returns the edited document.
+func editAll(queries []*jsonpath.Query, src any, opts *options) (any, error) {
+	for _, query := range queries {
+		_, paths, err := query.RetrievePaths(src)
+		if err != nil {
+			return nil, err
+		}
+		if opts.first && len(paths) > 0 {
+			paths = paths[:1]
+		}
+
+		for _, path := range paths {
+			elements, err := parsePath(path)
+			if err != nil {
+				return nil, err
+			}
+			if opts.delete {
+				if len(elements) == 0 {
+					return nil, fmt.Errorf(`the root cannot be deleted`)
+				}
+				replaceValue(src, elements, deletedValue)
+				continue
+			}
+
+			// Each location receives its own copy, so that the later edits do not affect the others.
+			value, err := decodeJSON([]byte(*opts.setValue))
+			if err != nil {
+				return nil, err
+			}
+			if len(elements) == 0 {
+				src = value
+				continue
+			}
+			replaceValue(src, elements, value)
+		}
+
+		if opts.delete {
+			src = removeDeleted(src)
+		}
+	}
+	return src, nil
+}
+
+// parsePath returns the member names and the indexes of the normalized path.
+func parsePath(path string) ([]any, error) {
+	query, err := jsonpath.Compile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var elements []any
+	for _, segment := range query.AST().Segments {
+		childSegment, ok := segment.(*ast.ChildSegment)
+		if !ok || len(childSegment.Selectors) != 1 {
+			return nil, fmt.Errorf(`not a normalized path: %s`, path)
+		}
+		switch selector := childSegment.Selectors[0].(type) {
+		case *ast.NameSelector:
+			elements = append(elements, selector.Name)
+		case *ast.IndexSelector:
+			elements = append(elements, selector.Index)
+		default:
+			return nil, fmt.Errorf(`not a normalized path: %s`, path)
+		}
+	}
+	return elements, nil
+}
+
+// replaceValue replaces the value at the path elements.
+// The path is ignored if its parent has been replaced or deleted by the previous path.
+func replaceValue(src any, elements []any, value any) {
+	parent := src
+	for _, element := range elements[:len(elements)-1] {
+		child, ok := childValue(parent, element)
+		if !ok {
+			return
+		}
+		parent = child
+	}
+
+	last := elements[len(elements)-1]
+	if _, ok := childValue(parent, last); !ok {
+		return
+	}
+	switch typedParent := parent.(type) {
+	case map[string]any:
+		if value == deletedValue {
+			delete(typedParent, last.(string))
+			return
+		}
+		typedParent[last.(string)] = value
+	case []any:
+		typedParent[last.(int)] = value
+	}
+}
+
+// childValue returns the member or the element of the parent, and whether it exists.
+func childValue(parent any, element any) (any, bool) {
+	switch typedParent := parent.(type) {
+	case map[string]any:
+		key, ok := element.(string)
+		if !ok {
+			return nil, false
+		}
+		child, ok := typedParent[key]
+		return child, ok
+	case []any:
+		index, ok := element.(int)
+		if !ok || index < 0 || index >= len(typedParent) {
+			return nil, false
+		}
+		return typedParent[index], true
+	}
+	return nil, false
+}
+
+// removeDeleted removes the list elements marked as deleted.
+func removeDeleted(src any) any {
+	switch typedSrc := src.(type) {
+	case map[string]any:
+		for key, value := range typedSrc {
+			typedSrc[key] = removeDeleted(value)
+		}
+	case []any:
+		list := typedSrc[:0]
+		for _, value := range typedSrc {
+			if value != deletedValue {
+				list = append(list, removeDeleted(value))
+			}
+		}
+		return list
+	}
+	return src
+}
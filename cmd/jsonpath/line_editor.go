@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	keyCtrlA     = 0x01
+	keyCtrlB     = 0x02
+	keyCtrlC     = 0x03
+	keyCtrlD     = 0x04
+	keyCtrlE     = 0x05
+	keyCtrlF     = 0x06
+	keyBackspace = 0x08
+	keyTab       = 0x09
+	keyLineFeed  = 0x0a
+	keyCtrlK     = 0x0b
+	keyEnter     = 0x0d
+	keyCtrlN     = 0x0e
+	keyCtrlP     = 0x10
+	keyCtrlU     = 0x15
+	keyCtrlW     = 0x17
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+// errInterrupted is returned by readLine when the line is discarded by Ctrl-C.
+var errInterrupted = errors.New(`interrupted`)
+
+// lineEditor reads the lines from the terminal in the raw mode,
+// with the cursor movement, the history and the completion.
+type lineEditor struct {
+	reader *bufio.Reader
+	writer io.Writer
+	prompt string
+
+	// complete returns the position where the completed word starts and the candidates of the word.
+	complete func(line []rune, cursor int) (int, []string)
+
+	history      []string
+	historyIndex int
+	editingLine  []rune
+
+	line   []rune
+	cursor int
+}
+
+func newLineEditor(reader io.Reader, writer io.Writer, prompt string) *lineEditor {
+	return &lineEditor{reader: bufio.NewReader(reader), writer: writer, prompt: prompt}
+}
+
+// readLine returns the line entered, and adds it to the history.
+// It returns io.EOF on Ctrl-D at the empty line, and errInterrupted on Ctrl-C.
+func (e *lineEditor) readLine() (string, error) {
+	e.line, e.cursor = e.line[:0], 0
+	e.historyIndex = len(e.history)
+	e.refresh()
+
+	for {
+		key, _, err := e.reader.ReadRune()
+		if err != nil {
+			return ``, err
+		}
+
+		switch key {
+		case keyEnter, keyLineFeed:
+			fmt.Fprint(e.writer, "\n")
+			line := string(e.line)
+			if strings.TrimSpace(line) != `` && (len(e.history) == 0 || e.history[len(e.history)-1] != line) {
+				e.history = append(e.history, line)
+			}
+			return line, nil
+
+		case keyCtrlC:
+			fmt.Fprint(e.writer, "^C\n")
+			return ``, errInterrupted
+
+		case keyCtrlD:
+			if len(e.line) == 0 {
+				fmt.Fprint(e.writer, "\n")
+				return ``, io.EOF
+			}
+			e.deleteRunes(e.cursor, e.cursor+1)
+
+		case keyBackspace, keyDelete:
+			e.deleteRunes(e.cursor-1, e.cursor)
+
+		case keyCtrlA:
+			e.moveCursor(0)
+		case keyCtrlE:
+			e.moveCursor(len(e.line))
+		case keyCtrlB:
+			e.moveCursor(e.cursor - 1)
+		case keyCtrlF:
+			e.moveCursor(e.cursor + 1)
+		case keyCtrlK:
+			e.deleteRunes(e.cursor, len(e.line))
+		case keyCtrlU:
+			e.deleteRunes(0, e.cursor)
+		case keyCtrlW:
+			e.deleteRunes(e.getWordStart(), e.cursor)
+		case keyCtrlP:
+			e.moveHistory(-1)
+		case keyCtrlN:
+			e.moveHistory(1)
+		case keyTab:
+			e.completeWord()
+		case keyEscape:
+			e.readEscapeSequence()
+
+		default:
+			if key < 0x20 {
+				continue
+			}
+			e.insertRunes([]rune{key})
+		}
+	}
+}
+
+// readEscapeSequence handles the keys sent as the escape sequences, such as the arrow keys.
+func (e *lineEditor) readEscapeSequence() {
+	introducer, _, err := e.reader.ReadRune()
+	if err != nil || introducer != '[' && introducer != 'O' {
+		return
+	}
+
+	var parameter []rune
+	for {
+		key, _, err := e.reader.ReadRune()
+		if err != nil {
+			return
+		}
+		if key >= '0' && key <= '9' || key == ';' {
+			parameter = append(parameter, key)
+			continue
+		}
+
+		switch key {
+		case 'A':
+			e.moveHistory(-1)
+		case 'B':
+			e.moveHistory(1)
+		case 'C':
+			e.moveCursor(e.cursor + 1)
+		case 'D':
+			e.moveCursor(e.cursor - 1)
+		case 'H':
+			e.moveCursor(0)
+		case 'F':
+			e.moveCursor(len(e.line))
+		case '~':
+			switch string(parameter) {
+			case `1`, `7`:
+				e.moveCursor(0)
+			case `4`, `8`:
+				e.moveCursor(len(e.line))
+			case `3`:
+				e.deleteRunes(e.cursor, e.cursor+1)
+			}
+		}
+		return
+	}
+}
+
+func (e *lineEditor) insertRunes(runes []rune) {
+	e.line = append(e.line[:e.cursor], append(runes, e.line[e.cursor:]...)...)
+	e.cursor += len(runes)
+	e.refresh()
+}
+
+func (e *lineEditor) deleteRunes(from, to int) {
+	from, to = max(from, 0), min(to, len(e.line))
+	if from >= to {
+		return
+	}
+	e.line = append(e.line[:from], e.line[to:]...)
+	if e.cursor > to {
+		e.cursor -= to - from
+	} else if e.cursor > from {
+		e.cursor = from
+	}
+	e.refresh()
+}
+
+func (e *lineEditor) moveCursor(cursor int) {
+	e.cursor = min(max(cursor, 0), len(e.line))
+	e.refresh()
+}
+
+func (e *lineEditor) getWordStart() int {
+	start := e.cursor
+	for start > 0 && e.line[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && e.line[start-1] != ' ' {
+		start--
+	}
+	return start
+}
+
+// moveHistory replaces the line with the previous or the next entry of the history.
+// The line being edited is kept while moving through the history.
+func (e *lineEditor) moveHistory(step int) {
+	index := e.historyIndex + step
+	if index < 0 || index > len(e.history) {
+		return
+	}
+	if e.historyIndex == len(e.history) {
+		e.editingLine = append(e.editingLine[:0], e.line...)
+	}
+	e.historyIndex = index
+	if index == len(e.history) {
+		e.line = append(e.line[:0], e.editingLine...)
+	} else {
+		e.line = append(e.line[:0], []rune(e.history[index])...)
+	}
+	e.cursor = len(e.line)
+	e.refresh()
+}
+
+// completeWord completes the word before the cursor up to the common prefix of the candidates,
+// and lists the candidates if the word cannot be completed any further.
+func (e *lineEditor) completeWord() {
+	if e.complete == nil {
+		return
+	}
+	start, candidates := e.complete(e.line, e.cursor)
+	if len(candidates) == 0 {
+		fmt.Fprint(e.writer, "\a")
+		return
+	}
+
+	word := []rune(getCommonPrefix(candidates))
+	if len(word) > e.cursor-start {
+		e.line = append(e.line[:start], append(word, e.line[e.cursor:]...)...)
+		e.cursor = start + len(word)
+		e.refresh()
+		return
+	}
+	if len(candidates) > 1 {
+		fmt.Fprintf(e.writer, "\n%s\n", strings.Join(candidates, `  `))
+		e.refresh()
+	}
+}
+
+// refresh redraws the prompt and the line, and moves the cursor to its position.
+func (e *lineEditor) refresh() {
+	fmt.Fprintf(e.writer, "\r%s%s\x1b[K", e.prompt, string(e.line))
+	if back := len(e.line) - e.cursor; back > 0 {
+		fmt.Fprintf(e.writer, "\x1b[%dD", back)
+	}
+}
+
+func getCommonPrefix(words []string) string {
+	prefix := []rune(words[0])
+	for _, word := range words[1:] {
+		runes := []rune(word)
+		length := 0
+		for length < len(prefix) && length < len(runes) && prefix[length] == runes[length] {
+			length++
+		}
+		prefix = prefix[:length]
+	}
+	return string(prefix)
+}
@@ -0,0 +1,349 @@
+// Command jsonpath retrieves the values from the JSON using the JSONPath.
+//
+// Usage:
+//
+//	jsonpath [flags] [query] [file ...]
+//	jsonpath repl file
+//
+// The JSON is read from the files, or from the standard input if no file is given.
+// The query is given by -q, which can be repeated, or by the first argument.
+// The standard functions such as length() and sum() are available in the queries.
+//
+// The flags are:
+//
+//	-q query
+//		the JSONPath to retrieve (repeatable)
+//	-o format
+//		the output format: json (default), ndjson, raw or path
+//	-first
+//		output only the first value of each query
+//	-exists
+//		output nothing, and exit with 0 if every query selects a value, or with 1 otherwise
+//	-set json
+//		replace the selected values with the JSON and output the document
+//	-delete
+//		delete the selected values and output the document
+//	-i
+//		write the document edited by -set or -delete back to the files
+//
+// The output formats are:
+//
+//	json    the values of each query as a JSON array on one line
+//	ndjson  each value as JSON on its own line
+//	raw     each value on its own line, the strings without the quotes
+//	path    each value on its own line as the normalized path, a tab and the JSON
+//
+// The repl command loads the JSON from the file and evaluates the queries entered interactively.
+// On the terminal, the lines can be edited with the history, and the member names are completed by Tab.
+// The :paths and :explain commands output the normalized paths of the values and the plan of the query.
+//
+// The exit status is 0 on success, 2 if a query is invalid, 3 if a query fails on the JSON
+// (member not found, type unmatched or function failed) and 1 on the other errors.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+const (
+	exitOK           = 0
+	exitFailure      = 1
+	exitSyntaxError  = 2
+	exitRuntimeError = 3
+)
+
+const (
+	formatJSON   = `json`
+	formatNDJSON = `ndjson`
+	formatRaw    = `raw`
+	formatPath   = `path`
+)
+
+const stdinName = `-`
+
+type options struct {
+	queries  []string
+	format   string
+	first    bool
+	exists   bool
+	setValue *string
+	delete   bool
+	inPlace  bool
+	files    []string
+}
+
+func (o *options) isEdit() bool {
+	return o.setValue != nil || o.delete
+}
+
+type queryFlags []string
+
+func (q *queryFlags) String() string {
+	return strings.Join(*q, `, `)
+}
+
+func (q *queryFlags) Set(value string) error {
+	*q = append(*q, value)
+	return nil
+}
+
+type setFlag struct {
+	value **string
+}
+
+func (s setFlag) String() string {
+	if s.value == nil || *s.value == nil {
+		return ``
+	}
+	return **s.value
+}
+
+func (s setFlag) Set(value string) error {
+	*s.value = &value
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == `repl` {
+		return runREPL(args[1:], stdin, stdout, stderr)
+	}
+
+	opts, err := parseOptions(args, stderr)
+	if err != nil {
+		if goerrors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		fmt.Fprintf(stderr, "jsonpath: %v\n", err)
+		return exitFailure
+	}
+
+	queries, err := compileQueries(opts.queries)
+	if err != nil {
+		return reportError(stderr, ``, err)
+	}
+
+	writer := bufio.NewWriter(stdout)
+	defer writer.Flush()
+	printer := newPrinter(writer, opts.format)
+
+	isAllFound := true
+	for _, file := range opts.files {
+		src, err := readJSON(file, stdin)
+		if err != nil {
+			return reportError(stderr, file, err)
+		}
+
+		switch {
+		case opts.exists:
+			isFound, err := existsAll(queries, src)
+			if err != nil {
+				return reportError(stderr, file, err)
+			}
+			isAllFound = isAllFound && isFound
+
+		case opts.isEdit():
+			edited, err := editAll(queries, src, opts)
+			if err != nil {
+				return reportError(stderr, file, err)
+			}
+			if opts.inPlace {
+				err = writeJSONFile(file, edited)
+			} else {
+				err = printer.printDocument(edited)
+			}
+			if err != nil {
+				return reportError(stderr, file, err)
+			}
+
+		default:
+			for _, query := range queries {
+				values, paths, err := query.RetrievePaths(src)
+				if err != nil {
+					writer.Flush()
+					return reportError(stderr, file, err)
+				}
+				if opts.first && len(values) > 0 {
+					values, paths = values[:1], paths[:1]
+				}
+				if err := printer.print(values, paths); err != nil {
+					return reportError(stderr, file, err)
+				}
+			}
+		}
+	}
+
+	if !isAllFound {
+		return exitFailure
+	}
+	return exitOK
+}
+
+func parseOptions(args []string, stderr io.Writer) (*options, error) {
+	opts := &options{}
+	var queries queryFlags
+
+	flags := flag.NewFlagSet(`jsonpath`, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, `usage: jsonpath [flags] [query] [file ...]`)
+		flags.PrintDefaults()
+	}
+	flags.Var(&queries, `q`, `the JSONPath to retrieve (repeatable)`)
+	flags.StringVar(&opts.format, `o`, formatJSON, `the output format: json, ndjson, raw or path`)
+	flags.BoolVar(&opts.first, `first`, false, `output only the first value of each query`)
+	flags.BoolVar(&opts.exists, `exists`, false, `exit with 0 if every query selects a value, or with 1 otherwise`)
+	flags.Var(setFlag{value: &opts.setValue}, `set`, `replace the selected values with the JSON`)
+	flags.BoolVar(&opts.delete, `delete`, false, `delete the selected values`)
+	flags.BoolVar(&opts.inPlace, `i`, false, `write the edited document back to the files`)
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	opts.queries = queries
+	opts.files = flags.Args()
+	if len(opts.queries) == 0 {
+		if len(opts.files) == 0 {
+			flags.Usage()
+			return nil, fmt.Errorf(`no query is given`)
+		}
+		opts.queries, opts.files = opts.files[:1], opts.files[1:]
+	}
+	if len(opts.files) == 0 {
+		opts.files = []string{stdinName}
+	}
+
+	switch opts.format {
+	case formatJSON, formatNDJSON, formatRaw, formatPath:
+	default:
+		return nil, fmt.Errorf(`unknown output format %q`, opts.format)
+	}
+	if opts.setValue != nil && opts.delete {
+		return nil, fmt.Errorf(`-set and -delete cannot be used together`)
+	}
+	if opts.exists && opts.isEdit() {
+		return nil, fmt.Errorf(`-exists cannot be used with -set or -delete`)
+	}
+	if opts.inPlace && !opts.isEdit() {
+		return nil, fmt.Errorf(`-i requires -set or -delete`)
+	}
+	if opts.inPlace && len(opts.files) == 1 && opts.files[0] == stdinName {
+		return nil, fmt.Errorf(`-i requires the files`)
+	}
+	if opts.setValue != nil && !json.Valid([]byte(*opts.setValue)) {
+		return nil, fmt.Errorf(`-set value is not valid JSON: %s`, *opts.setValue)
+	}
+	return opts, nil
+}
+
+func compileQueries(jsonPaths []string) ([]*jsonpath.Query, error) {
+	cfg := config.Config{}
+	cfg.SetStandardFunctions()
+
+	queries := make([]*jsonpath.Query, len(jsonPaths))
+	for index, jsonPath := range jsonPaths {
+		query, err := jsonpath.Compile(jsonPath, cfg)
+		if err != nil {
+			return nil, err
+		}
+		queries[index] = query
+	}
+	return queries, nil
+}
+
+// existsAll reports whether every query selects at least one value.
+func existsAll(queries []*jsonpath.Query, src any) (bool, error) {
+	for _, query := range queries {
+		_, err := query.Retrieve(src)
+		if goerrors.Is(err, errors.ErrNotFound) || goerrors.Is(err, errors.ErrTypeUnmatched) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func readJSON(file string, stdin io.Reader) (any, error) {
+	var data []byte
+	var err error
+	if file == stdinName {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(data)
+}
+
+// decodeJSON decodes a single JSON value, keeping the numbers as json.Number
+// so that they are written back without the loss of precision.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var src any
+	if err := decoder.Decode(&src); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf(`invalid data after the top-level value`)
+	}
+	return src, nil
+}
+
+func writeJSONFile(file string, src any) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := newPrinter(&buffer, formatJSON).printDocument(src); err != nil {
+		return err
+	}
+	return os.WriteFile(file, buffer.Bytes(), info.Mode().Perm())
+}
+
+// reportError writes the error to the standard error and returns the exit status for it.
+// The syntax errors are written with the location in the JSONPath.
+func reportError(stderr io.Writer, file string, err error) int {
+	var syntaxErr errors.ErrorInvalidSyntax
+	switch {
+	case goerrors.As(err, &syntaxErr):
+		fmt.Fprintf(stderr, "jsonpath: %s\n", syntaxErr.Pretty())
+	case file == `` || file == stdinName:
+		fmt.Fprintf(stderr, "jsonpath: %v\n", err)
+	default:
+		fmt.Fprintf(stderr, "jsonpath: %s: %v\n", file, err)
+	}
+	return getExitCode(err)
+}
+
+func getExitCode(err error) int {
+	switch {
+	case goerrors.Is(err, errors.ErrSyntax):
+		return exitSyntaxError
+	case goerrors.Is(err, errors.ErrNotFound),
+		goerrors.Is(err, errors.ErrTypeUnmatched),
+		goerrors.Is(err, errors.ErrFunctionFailed):
+		return exitRuntimeError
+	}
+	return exitFailure
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// printer writes the retrieved values in the output format.
+type printer struct {
+	writer  io.Writer
+	encoder *json.Encoder
+	format  string
+}
+
+func newPrinter(writer io.Writer, format string) *printer {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &printer{writer: writer, encoder: encoder, format: format}
+}
+
+// print writes the values of a query together with their normalized paths.
+func (p *printer) print(values []any, paths []string) error {
+	switch p.format {
+	case formatJSON:
+		return p.encoder.Encode(values)
+
+	case formatNDJSON:
+		for _, value := range values {
+			if err := p.encoder.Encode(value); err != nil {
+				return err
+			}
+		}
+
+	case formatRaw:
+		for _, value := range values {
+			if text, ok := value.(string); ok {
+				if _, err := fmt.Fprintln(p.writer, text); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := p.encoder.Encode(value); err != nil {
+				return err
+			}
+		}
+
+	case formatPath:
+		for index, value := range values {
+			if _, err := fmt.Fprintf(p.writer, "%s\t", paths[index]); err != nil {
+				return err
+			}
+			if err := p.encoder.Encode(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// printDocument writes the whole document edited by -set or -delete.
+func (p *printer) printDocument(src any) error {
+	return p.encoder.Encode(src)
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+const replPrompt = `jsonpath> `
+
+const replHelp = `Enter a JSONPath to retrieve the values from the document.
+Commands:
+  :paths <query>    output the normalized path of each value
+  :explain <query>  output the plan of the query
+  :help             output this help
+  :quit             exit
+Keys:
+  Tab               complete the member name
+  Up, Down          move through the history
+  Ctrl-A, Ctrl-E    move to the start or the end of the line
+  Ctrl-U, Ctrl-K    delete before or after the cursor
+  Ctrl-C            discard the line
+  Ctrl-D            exit at the empty line
+`
+
+// repl evaluates the queries entered against the loaded document.
+type repl struct {
+	src         any
+	memberNames []string
+	config      config.Config
+	stdout      io.Writer
+	stderr      io.Writer
+}
+
+func newREPL(src any, stdout, stderr io.Writer) *repl {
+	cfg := config.Config{}
+	cfg.SetStandardFunctions()
+	cfg.SetOptimization()
+	return &repl{src: src, memberNames: getMemberNames(src), config: cfg, stdout: stdout, stderr: stderr}
+}
+
+// runREPL loads the document from the file and evaluates the queries entered until :quit or the end of the input.
+// The lines are edited in the raw mode if the input is a terminal, or read as they are otherwise.
+func runREPL(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) != 1 || args[0] == stdinName {
+		fmt.Fprintln(stderr, `usage: jsonpath repl file`)
+		return exitFailure
+	}
+	src, err := readJSON(args[0], stdin)
+	if err != nil {
+		return reportError(stderr, args[0], err)
+	}
+	r := newREPL(src, stdout, stderr)
+
+	readLine := newPlainLineReader(stdin)
+	if file, ok := stdin.(*os.File); ok && isTerminal(int(file.Fd())) {
+		fmt.Fprintf(stdout, "Loaded %s. Enter :help for the commands.\n", args[0])
+		readLine = newTerminalLineReader(file, stdout, r.complete)
+	}
+
+	for {
+		line, err := readLine()
+		if err == errInterrupted {
+			continue
+		}
+		if err == io.EOF {
+			return exitOK
+		}
+		if err != nil {
+			return reportError(stderr, ``, err)
+		}
+		if !r.execute(line) {
+			return exitOK
+		}
+	}
+}
+
+func newPlainLineReader(stdin io.Reader) func() (string, error) {
+	reader := bufio.NewReader(stdin)
+	return func() (string, error) {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF && line != `` {
+			err = nil
+		}
+		return strings.TrimRight(line, "\r\n"), err
+	}
+}
+
+// newTerminalLineReader returns the reader that puts the terminal into the raw mode only while a line is edited,
+// so that Ctrl-C interrupts the evaluation as usual.
+func newTerminalLineReader(
+	terminal *os.File, stdout io.Writer, complete func([]rune, int) (int, []string)) func() (string, error) {
+
+	editor := newLineEditor(terminal, stdout, replPrompt)
+	editor.complete = complete
+	fd := int(terminal.Fd())
+	return func() (string, error) {
+		state, err := makeRaw(fd)
+		if err != nil {
+			return ``, err
+		}
+		defer restoreTerminal(fd, state)
+		return editor.readLine()
+	}
+}
+
+// execute evaluates the line, and returns false if the REPL is to exit.
+func (r *repl) execute(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == `` {
+		return true
+	}
+	if !strings.HasPrefix(line, `:`) {
+		r.retrieve(line)
+		return true
+	}
+
+	command, argument, _ := strings.Cut(line, ` `)
+	argument = strings.TrimSpace(argument)
+	switch command {
+	case `:quit`, `:q`, `:exit`:
+		return false
+	case `:help`:
+		fmt.Fprint(r.stdout, replHelp)
+	case `:paths`:
+		r.retrievePaths(argument)
+	case `:explain`:
+		r.explain(argument)
+	default:
+		fmt.Fprintf(r.stderr, "unknown command %s (enter :help for the commands)\n", command)
+	}
+	return true
+}
+
+func (r *repl) retrieve(jsonPath string) {
+	query, ok := r.compile(jsonPath)
+	if !ok {
+		return
+	}
+	values, err := query.Retrieve(r.src)
+	if err != nil {
+		r.printError(err)
+		return
+	}
+	output, err := json.MarshalIndent(values, ``, `  `)
+	if err != nil {
+		r.printError(err)
+		return
+	}
+	fmt.Fprintln(r.stdout, string(output))
+}
+
+func (r *repl) retrievePaths(jsonPath string) {
+	query, ok := r.compile(jsonPath)
+	if !ok {
+		return
+	}
+	values, paths, err := query.RetrievePaths(r.src)
+	if err != nil {
+		r.printError(err)
+		return
+	}
+	if err := newPrinter(r.stdout, formatPath).print(values, paths); err != nil {
+		r.printError(err)
+	}
+}
+
+func (r *repl) explain(jsonPath string) {
+	if query, ok := r.compile(jsonPath); ok {
+		fmt.Fprint(r.stdout, query.Plan())
+	}
+}
+
+func (r *repl) compile(jsonPath string) (*jsonpath.Query, bool) {
+	if jsonPath == `` {
+		fmt.Fprintln(r.stderr, `no query is given`)
+		return nil, false
+	}
+	query, err := jsonpath.Compile(jsonPath, r.config)
+	if err != nil {
+		r.printError(err)
+		return nil, false
+	}
+	return query, true
+}
+
+// printError writes the error, with the location in the JSONPath if it is a syntax error.
+func (r *repl) printError(err error) {
+	var syntaxErr errors.ErrorInvalidSyntax
+	if goerrors.As(err, &syntaxErr) {
+		fmt.Fprintln(r.stderr, syntaxErr.Pretty())
+		return
+	}
+	fmt.Fprintln(r.stderr, err)
+}
+
+// complete returns the member names that complete the word before the cursor.
+// The names are taken from the values selected by the JSONPath before the word,
+// or from the whole document if the JSONPath cannot be retrieved alone, such as in the filters.
+func (r *repl) complete(line []rune, cursor int) (int, []string) {
+	start := cursor
+	for start > 0 && isMemberNameRune(line[start-1]) {
+		start--
+	}
+
+	var parent string
+	var isDotted bool
+	switch {
+	case start > 0 && line[start-1] == '.':
+		parent, isDotted = string(line[:start-1]), true
+	case start > 1 && (line[start-1] == '\'' || line[start-1] == '"') && line[start-2] == '[':
+		parent = string(line[:start-2])
+	default:
+		return start, nil
+	}
+	if strings.HasPrefix(parent, `:`) {
+		_, parent, _ = strings.Cut(parent, ` `)
+		parent = strings.TrimSpace(parent)
+	}
+
+	word := string(line[start:cursor])
+	var candidates []string
+	for _, name := range r.getParentMemberNames(parent) {
+		if !strings.HasPrefix(name, word) {
+			continue
+		}
+		if isDotted && strings.IndexFunc(name, func(r rune) bool { return !isMemberNameRune(r) }) >= 0 {
+			continue
+		}
+		candidates = append(candidates, name)
+	}
+	return start, candidates
+}
+
+func (r *repl) getParentMemberNames(parent string) []string {
+	if strings.HasSuffix(parent, `.`) {
+		return r.memberNames
+	}
+	query, err := jsonpath.Compile(parent, r.config)
+	if err != nil {
+		return r.memberNames
+	}
+	values, err := query.Retrieve(r.src)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, value := range values {
+		if object, ok := value.(map[string]any); ok {
+			for name := range object {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// getMemberNames returns the names of all the members in the document.
+func getMemberNames(src any) []string {
+	var names []string
+	var walk func(node any)
+	walk = func(node any) {
+		switch typedNode := node.(type) {
+		case map[string]any:
+			for name, value := range typedNode {
+				names = append(names, name)
+				walk(value)
+			}
+		case []any:
+			for _, value := range typedNode {
+				walk(value)
+			}
+		}
+	}
+	walk(src)
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func isMemberNameRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,21 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package main
+
+import "errors"
+
+// terminalState is not used where the raw mode is not supported,
+// and the REPL reads the lines without the editing.
+type terminalState struct{}
+
+func isTerminal(int) bool {
+	return false
+}
+
+func makeRaw(int) (*terminalState, error) {
+	return nil, errors.New(`the raw mode is not supported`)
+}
+
+func restoreTerminal(int, *terminalState) error {
+	return nil
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type cliTestCase struct {
+	args           []string
+	stdin          string
+	expectedOutput string
+	expectedStatus int
+	expectedStderr string
+}
+
+func runCLITestCases(t *testing.T, testCases []cliTestCase) {
+	for _, testCase := range testCases {
+		t.Run(strings.Join(testCase.args, ` `), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(testCase.args, strings.NewReader(testCase.stdin), &stdout, &stderr)
+			if status != testCase.expectedStatus {
+				t.Errorf("expected status<%d> != actual status<%d> (stderr<%s>)\n",
+					testCase.expectedStatus, status, stderr.String())
+			}
+			if actual := stdout.String(); actual != testCase.expectedOutput {
+				t.Errorf("expected output<%s> != actual output<%s>\n", testCase.expectedOutput, actual)
+			}
+			if !strings.Contains(stderr.String(), testCase.expectedStderr) {
+				t.Errorf("expected stderr<%s> is not in actual stderr<%s>\n", testCase.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestCLI_Retrieve(t *testing.T) {
+	src := `{"a":[1,"x<y",{"b":true}],"c":12345678901234567890}`
+	runCLITestCases(t, []cliTestCase{
+		{
+			args:           []string{`$.a[*]`},
+			stdin:          src,
+			expectedOutput: "[1,\"x<y\",{\"b\":true}]\n",
+		},
+		{
+			args:           []string{`-q`, `$.a[0]`, `-q`, `$.c`},
+			stdin:          src,
+			expectedOutput: "[1]\n[12345678901234567890]\n",
+		},
+		{
+			args:           []string{`-o`, `ndjson`, `$.a[*]`},
+			stdin:          src,
+			expectedOutput: "1\n\"x<y\"\n{\"b\":true}\n",
+		},
+		{
+			args:           []string{`-o`, `raw`, `$.a[*]`},
+			stdin:          src,
+			expectedOutput: "1\nx<y\n{\"b\":true}\n",
+		},
+		{
+			args:           []string{`-o`, `path`, `$..b`},
+			stdin:          src,
+			expectedOutput: "$['a'][2]['b']\ttrue\n",
+		},
+		{
+			args:           []string{`-first`, `-o`, `ndjson`, `$.a[*]`},
+			stdin:          src,
+			expectedOutput: "1\n",
+		},
+		{
+			args:           []string{`$.a[?(length(@) > 2)]`},
+			stdin:          src,
+			expectedOutput: "[\"x<y\"]\n",
+		},
+		{
+			args:           []string{`-q`, `$.a[0]`, `-q`, `$.none`},
+			stdin:          src,
+			expectedOutput: "[1]\n",
+			expectedStatus: exitRuntimeError,
+			expectedStderr: `member did not exist (path=.none)`,
+		},
+		{
+			args:           []string{`$.c.d`},
+			stdin:          src,
+			expectedStatus: exitRuntimeError,
+			expectedStderr: `type unmatched`,
+		},
+		{
+			args:           []string{`$.a[?(@.b = 1)]`},
+			stdin:          src,
+			expectedStatus: exitSyntaxError,
+			expectedStderr: "column 11: unrecognized input\n  $.a[?(@.b = 1)]\n            ^",
+		},
+		{
+			args:           []string{`$.unknown()`},
+			stdin:          src,
+			expectedStatus: exitSyntaxError,
+			expectedStderr: `function not found`,
+		},
+		{
+			args:           []string{`$.a`},
+			stdin:          `{"a":`,
+			expectedStatus: exitFailure,
+			expectedStderr: `unexpected EOF`,
+		},
+		{
+			args:           []string{`$.a`},
+			stdin:          `{"a":1} {}`,
+			expectedStatus: exitFailure,
+			expectedStderr: `invalid data after the top-level value`,
+		},
+		{
+			args:           []string{},
+			expectedStatus: exitFailure,
+			expectedStderr: `no query is given`,
+		},
+		{
+			args:           []string{`-o`, `xml`, `$`},
+			expectedStatus: exitFailure,
+			expectedStderr: `unknown output format "xml"`,
+		},
+	})
+}
+
+func TestCLI_Exists(t *testing.T) {
+	src := `{"a":[1,2],"b":"c"}`
+	runCLITestCases(t, []cliTestCase{
+		{
+			args:  []string{`-exists`, `-q`, `$.a[1]`, `-q`, `$.b`},
+			stdin: src,
+		},
+		{
+			args:           []string{`-exists`, `-q`, `$.a[1]`, `-q`, `$.none`},
+			stdin:          src,
+			expectedStatus: exitFailure,
+		},
+		{
+			args:           []string{`-exists`, `$.b.c`},
+			stdin:          src,
+			expectedStatus: exitFailure,
+		},
+		{
+			args:           []string{`-exists`, `$[`},
+			stdin:          src,
+			expectedStatus: exitSyntaxError,
+			expectedStderr: `invalid syntax`,
+		},
+	})
+}
+
+func TestCLI_Edit(t *testing.T) {
+	src := `{"a":[1,2,3,{"b":1}],"c":{"d":1,"e":2}}`
+	runCLITestCases(t, []cliTestCase{
+		{
+			args:           []string{`-set`, `{"x":0}`, `$.a[0,2]`},
+			stdin:          src,
+			expectedOutput: "{\"a\":[{\"x\":0},2,{\"x\":0},{\"b\":1}],\"c\":{\"d\":1,\"e\":2}}\n",
+		},
+		{
+			args:           []string{`-set`, `null`, `-first`, `$..*`},
+			stdin:          src,
+			expectedOutput: "{\"a\":null,\"c\":{\"d\":1,\"e\":2}}\n",
+		},
+		{
+			args:           []string{`-set`, `[]`, `$..*`},
+			stdin:          `{"a":[1,2]}`,
+			expectedOutput: "{\"a\":[]}\n",
+		},
+		{
+			args:           []string{`-set`, `{}`, `$..*`},
+			stdin:          src,
+			expectedOutput: "{\"a\":{},\"c\":{}}\n",
+		},
+		{
+			args:           []string{`-set`, `0`, `$`},
+			stdin:          src,
+			expectedOutput: "0\n",
+		},
+		{
+			args:           []string{`-delete`, `$.a[2,0,1]`},
+			stdin:          src,
+			expectedOutput: "{\"a\":[{\"b\":1}],\"c\":{\"d\":1,\"e\":2}}\n",
+		},
+		{
+			args:           []string{`-delete`, `-q`, `$..b`, `-q`, `$.c.d`},
+			stdin:          src,
+			expectedOutput: "{\"a\":[1,2,3,{}],\"c\":{\"e\":2}}\n",
+		},
+		{
+			args:           []string{`-delete`, `$..*`},
+			stdin:          src,
+			expectedOutput: "{}\n",
+		},
+		{
+			args:           []string{`-delete`, `$`},
+			stdin:          src,
+			expectedStatus: exitFailure,
+			expectedStderr: `the root cannot be deleted`,
+		},
+		{
+			args:           []string{`-delete`, `$.none`},
+			stdin:          src,
+			expectedStatus: exitRuntimeError,
+			expectedStderr: `member did not exist`,
+		},
+		{
+			args:           []string{`-set`, `{`, `$.a`},
+			stdin:          src,
+			expectedStatus: exitFailure,
+			expectedStderr: `-set value is not valid JSON`,
+		},
+		{
+			args:           []string{`-set`, `1`, `-delete`, `$.a`},
+			stdin:          src,
+			expectedStatus: exitFailure,
+			expectedStderr: `-set and -delete cannot be used together`,
+		},
+		{
+			args:           []string{`-i`, `-delete`, `$.a`},
+			stdin:          src,
+			expectedStatus: exitFailure,
+			expectedStderr: `-i requires the files`,
+		},
+	})
+}
+
+func TestCLI_Files(t *testing.T) {
+	dir := t.TempDir()
+	file1 := filepath.Join(dir, `1.json`)
+	file2 := filepath.Join(dir, `2.json`)
+	os.WriteFile(file1, []byte(`{"a":1,"b":2}`), 0o600)
+	os.WriteFile(file2, []byte(`{"a":3}`), 0o600)
+
+	runCLITestCases(t, []cliTestCase{
+		{
+			args:           []string{`$.a`, file1, file2},
+			expectedOutput: "[1]\n[3]\n",
+		},
+		{
+			args:           []string{`-q`, `$.b`, file1, file2},
+			expectedOutput: "[2]\n",
+			expectedStatus: exitRuntimeError,
+			expectedStderr: file2 + `: member did not exist`,
+		},
+		{
+			args:           []string{`$.a`, filepath.Join(dir, `none.json`)},
+			expectedStatus: exitFailure,
+			expectedStderr: `none.json`,
+		},
+		{
+			args: []string{`-i`, `-delete`, `$.a`, file1, file2},
+		},
+	})
+
+	for file, expected := range map[string]string{file1: "{\"b\":2}\n", file2: "{}\n"} {
+		if actual, _ := os.ReadFile(file); string(actual) != expected {
+			t.Errorf("expected file<%s> != actual file<%s>\n", expected, actual)
+		}
+	}
+}
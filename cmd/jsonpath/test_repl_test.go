@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const replTestJSON = `{"store":{"book":[{"title":"A","price":8},{"title":"B","price":12,"isbn":"x"}],"bicycle":{"color":"red"}},"my key":1}`
+
+func TestREPL_Execute(t *testing.T) {
+	file := filepath.Join(t.TempDir(), `store.json`)
+	os.WriteFile(file, []byte(replTestJSON), 0o600)
+
+	input := strings.Join([]string{
+		`$.store.book[?(@.price < 10)].title`,
+		``,
+		`:paths $..color`,
+		`:explain $.store.book[0]`,
+		`$.store.book[?(@.price = 1)]`,
+		`$.none`,
+		`:paths`,
+		`:unknown`,
+		`:quit`,
+		`$.store`,
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	status := run([]string{`repl`, file}, strings.NewReader(input), &stdout, &stderr)
+	if status != exitOK {
+		t.Errorf("expected status<%d> != actual status<%d>\n", exitOK, status)
+	}
+
+	expectedOutput := "[\n  \"A\"\n]\n" +
+		"$['store']['bicycle']['color']\t\"red\"\n" +
+		"child ['store']\nchild ['book']\nunion [0]\n"
+	if actual := stdout.String(); actual != expectedOutput {
+		t.Errorf("expected output<%s> != actual output<%s>\n", expectedOutput, actual)
+	}
+
+	expectedStderr := "invalid syntax at line 1, column 24: unrecognized input\n" +
+		"  $.store.book[?(@.price = 1)]\n" +
+		"                         ^\n" +
+		"expected: ')', '==', '!=', '<', '<=', '>', '>=', '=~', '&&', '||'\n" +
+		"hint: use '==' to compare the values\n" +
+		"member did not exist (path=.none)\n" +
+		"no query is given\n" +
+		"unknown command :unknown (enter :help for the commands)\n"
+	if actual := stderr.String(); actual != expectedStderr {
+		t.Errorf("expected stderr<%s> != actual stderr<%s>\n", expectedStderr, actual)
+	}
+}
+
+func TestREPL_Arguments(t *testing.T) {
+	runCLITestCases(t, []cliTestCase{
+		{
+			args:           []string{`repl`},
+			expectedStatus: exitFailure,
+			expectedStderr: `usage: jsonpath repl file`,
+		},
+		{
+			args:           []string{`repl`, `-`},
+			expectedStatus: exitFailure,
+			expectedStderr: `usage: jsonpath repl file`,
+		},
+		{
+			args:           []string{`repl`, filepath.Join(t.TempDir(), `none.json`)},
+			expectedStatus: exitFailure,
+			expectedStderr: `none.json`,
+		},
+	})
+}
+
+func TestREPL_Complete(t *testing.T) {
+	var src any
+	src, _ = decodeJSON([]byte(replTestJSON))
+	r := newREPL(src, io.Discard, io.Discard)
+
+	testCases := []struct {
+		line               string
+		expectedStart      int
+		expectedCandidates string
+	}{
+		{line: `$.`, expectedStart: 2, expectedCandidates: `store`},
+		{line: `$.st`, expectedStart: 2, expectedCandidates: `store`},
+		{line: `$.store.b`, expectedStart: 8, expectedCandidates: `bicycle book`},
+		{line: `$.store.book[*].`, expectedStart: 16, expectedCandidates: `isbn price title`},
+		{line: `$.store.book[0].`, expectedStart: 16, expectedCandidates: `price title`},
+		{line: `$['`, expectedStart: 3, expectedCandidates: `my key store`},
+		{line: `$..c`, expectedStart: 3, expectedCandidates: `color`},
+		{line: `$.store.book[?(@.p`, expectedStart: 17, expectedCandidates: `price`},
+		{line: `:paths $.store.bi`, expectedStart: 15, expectedCandidates: `bicycle`},
+		{line: `$.none.`, expectedStart: 7, expectedCandidates: ``},
+		{line: `$.store.book[0`, expectedStart: 13, expectedCandidates: ``},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.line, func(t *testing.T) {
+			line := []rune(testCase.line)
+			start, candidates := r.complete(line, len(line))
+			if start != testCase.expectedStart {
+				t.Errorf("expected start<%d> != actual start<%d>\n", testCase.expectedStart, start)
+			}
+			if actual := strings.Join(candidates, ` `); actual != testCase.expectedCandidates {
+				t.Errorf("expected candidates<%s> != actual candidates<%s>\n", testCase.expectedCandidates, actual)
+			}
+		})
+	}
+}
+
+func TestLineEditor_ReadLine(t *testing.T) {
+	testCases := []struct {
+		name          string
+		keys          string
+		expectedLines []string
+	}{
+		{name: `enter`, keys: "$.a\r", expectedLines: []string{`$.a`}},
+		{name: `backspace`, keys: "$.ab\x7f\r", expectedLines: []string{`$.a`}},
+		{name: `arrow keys`, keys: "$.b\x1b[D\x1b[Da\x1b[C\x1b[C.c\r", expectedLines: []string{`$a.b.c`}},
+		{name: `home and end`, keys: "a\x01$.\x05b\r", expectedLines: []string{`$.ab`}},
+		{name: `home and end sequences`, keys: "a\x1b[H$.\x1b[Fb\x1b[1~\x1b[3~\r", expectedLines: []string{`.ab`}},
+		{name: `kill`, keys: "$.a.b\x1b[D\x1b[D\x0b\r$.x.y\x1b[D\x15\r", expectedLines: []string{`$.a`, `y`}},
+		{name: `delete word`, keys: "$.a $.b\x17\r", expectedLines: []string{`$.a `}},
+		{name: `ctrl-d deletes`, keys: "ab\x01\x04\r", expectedLines: []string{`b`}},
+		{
+			name:          `history`,
+			keys:          "$.a\r$.b\r$.b\r\x1b[A\x1b[A\r\x10\x10\x0e\r$.c\x1b[A\x1b[B\r",
+			expectedLines: []string{`$.a`, `$.b`, `$.b`, `$.a`, `$.a`, `$.c`},
+		},
+		{name: `completion`, keys: "$.b\t.t\t\r", expectedLines: []string{`$.book.title`}},
+		{name: `control keys ignored`, keys: "$\x07.a\r", expectedLines: []string{`$.a`}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			editor := newLineEditor(strings.NewReader(testCase.keys), io.Discard, replPrompt)
+			editor.complete = func(line []rune, cursor int) (int, []string) {
+				start := cursor
+				for start > 0 && line[start-1] != '.' {
+					start--
+				}
+				for _, name := range []string{`book`, `title`} {
+					if strings.HasPrefix(name, string(line[start:cursor])) {
+						return start, []string{name}
+					}
+				}
+				return start, nil
+			}
+
+			var lines []string
+			for {
+				line, err := editor.readLine()
+				if err != nil {
+					break
+				}
+				lines = append(lines, line)
+			}
+			if actual, expected := strings.Join(lines, "\n"), strings.Join(testCase.expectedLines, "\n"); actual != expected {
+				t.Errorf("expected lines<%s> != actual lines<%s>\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestLineEditor_Interrupt(t *testing.T) {
+	editor := newLineEditor(strings.NewReader("$.a\x03\x04"), io.Discard, replPrompt)
+	if _, err := editor.readLine(); err != errInterrupted {
+		t.Errorf("expected error<%v> != actual error<%v>\n", errInterrupted, err)
+	}
+	if _, err := editor.readLine(); err != io.EOF {
+		t.Errorf("expected error<%v> != actual error<%v>\n", io.EOF, err)
+	}
+}
+
+func TestLineEditor_CompletionList(t *testing.T) {
+	var output bytes.Buffer
+	editor := newLineEditor(strings.NewReader("$.\t\t\r"), &output, replPrompt)
+	editor.complete = func(line []rune, cursor int) (int, []string) {
+		return strings.LastIndex(string(line), `.`) + 1, []string{`price`, `prize`}
+	}
+	line, _ := editor.readLine()
+	if line != `$.pri` {
+		t.Errorf("expected line<$.pri> != actual line<%s>\n", line)
+	}
+	if !strings.Contains(output.String(), "\nprice  prize\n") {
+		t.Errorf("candidates are not listed in output<%q>\n", output.String())
+	}
+}
@@ -2,6 +2,13 @@ package config
 
 // Accessor represents the accessor to the result nodes of JSONPath.
 type Accessor struct {
+	// Get returns the node. It panics with the error that TryGet returns.
 	Get func() any
+	// TryGet returns the node, or the error if the node cannot be read,
+	// such as errors.ErrorFunctionFailed of the reversible function applied to the source node.
+	TryGet func() (any, error)
+	// Set updates the node. It panics with the error that TrySet returns.
 	Set func(any)
+	// TrySet updates the node and returns errors.ErrorReadOnly if the node cannot be updated.
+	TrySet func(any) error
 }
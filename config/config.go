@@ -1,14 +1,27 @@
 package config
 
+import "github.com/AsaiYusuke/jsonpath/v2/internal/functions"
+
 // Config represents the configuration parameters.
+// Each setter of the functions replaces the function of the same name set before by any of them.
 type Config struct {
-	FilterFunctions    map[string]func(any) (any, error)
-	AggregateFunctions map[string]func([]any) (any, error)
-	AccessorMode       bool
+	FilterFunctions         map[string]func(any) (any, error)
+	AggregateFunctions      map[string]func([]any) (any, error)
+	Functions               map[string]Function
+	UserData                any
+	FormatStyle             FormatStyle
+	AccessorMode            bool
+	Optimization            bool
+	Observer                Observer
+	CollectAllErrors        bool
+	ContinueOnFunctionError bool
+	DocumentOrder           bool
+	Unordered               bool
 }
 
 // SetFilterFunction sets the custom function.
 func (c *Config) SetFilterFunction(id string, function func(any) (any, error)) {
+	c.deleteFunction(id)
 	if c.FilterFunctions == nil {
 		c.FilterFunctions = map[string]func(any) (any, error){}
 	}
@@ -17,13 +30,148 @@ func (c *Config) SetFilterFunction(id string, function func(any) (any, error)) {
 
 // SetAggregateFunction sets the custom function.
 func (c *Config) SetAggregateFunction(id string, function func([]any) (any, error)) {
+	c.deleteFunction(id)
 	if c.AggregateFunctions == nil {
 		c.AggregateFunctions = map[string]func([]any) (any, error){}
 	}
 	c.AggregateFunctions[id] = function
 }
 
+// SetFunction sets the custom function of any kind.
+func (c *Config) SetFunction(id string, function Function) {
+	c.deleteFunction(id)
+	if c.Functions == nil {
+		c.Functions = map[string]Function{}
+	}
+	c.Functions[id] = function
+}
+
+// SetFilterFunctionWithArgs sets the custom function that takes the given number of arguments.
+func (c *Config) SetFilterFunctionWithArgs(id string, arity int, function func(any, []any) (any, error)) {
+	c.SetFunction(id, Function{
+		Kind:  FilterKind,
+		Arity: arity,
+		Filter: func(_ FunctionContext, value any, arguments []any) (any, error) {
+			return function(value, arguments)
+		},
+	})
+}
+
+// SetAggregateFunctionWithArgs sets the custom function that takes the given number of arguments.
+func (c *Config) SetAggregateFunctionWithArgs(id string, arity int, function func([]any, []any) (any, error)) {
+	c.SetFunction(id, Function{
+		Kind:  AggregateKind,
+		Arity: arity,
+		Aggregate: func(_ FunctionContext, values []any, arguments []any) (any, error) {
+			return function(values, arguments)
+		},
+	})
+}
+
+// SetExpressionFunction sets the custom function that can be called inside filter expressions.
+func (c *Config) SetExpressionFunction(
+	id string, paramTypes []FunctionType, resultType FunctionType, function func([]any) (any, error)) {
+	c.SetFunction(id, Function{
+		Kind:       ExpressionKind,
+		ParamTypes: paramTypes,
+		ResultType: resultType,
+		Expression: function,
+	})
+}
+
+// SetReversibleFilterFunction sets the custom function with its inverse function.
+// The accessors of the results write back to the source node through the inverse function.
+func (c *Config) SetReversibleFilterFunction(id string, function, inverse func(any) (any, error)) {
+	c.SetFunction(id, Function{
+		Kind: FilterKind,
+		Filter: func(_ FunctionContext, value any, _ []any) (any, error) {
+			return function(value)
+		},
+		Inverse: inverse,
+	})
+}
+
+// SetFilterFunctionWithContext sets the custom function that receives the call context.
+func (c *Config) SetFilterFunctionWithContext(
+	id string, arity int, function func(FunctionContext, any, []any) (any, error)) {
+	c.SetFunction(id, Function{Kind: FilterKind, Arity: arity, WithContext: true, Filter: function})
+}
+
+// SetAggregateFunctionWithContext sets the custom function that receives the call context.
+func (c *Config) SetAggregateFunctionWithContext(
+	id string, arity int, function func(FunctionContext, []any, []any) (any, error)) {
+	c.SetFunction(id, Function{Kind: AggregateKind, Arity: arity, WithContext: true, Aggregate: function})
+}
+
+// deleteFunction deletes the function of the name, so that the function set next is the only one with the name.
+// FilterFunctions and AggregateFunctions are kept apart from Functions for compatibility.
+func (c *Config) deleteFunction(id string) {
+	delete(c.FilterFunctions, id)
+	delete(c.AggregateFunctions, id)
+	delete(c.Functions, id)
+}
+
+// SetUserData sets the value passed to the context-aware functions.
+func (c *Config) SetUserData(data any) {
+	c.UserData = data
+}
+
+// SetStandardFunctions sets the standard filter and aggregate functions.
+// A function set afterwards with the same name replaces the standard one.
+func (c *Config) SetStandardFunctions() {
+	for id, function := range functions.StandardFilterFunctions {
+		c.SetFilterFunction(id, function)
+	}
+	for id, function := range functions.StandardAggregateFunctions {
+		c.SetAggregateFunction(id, function)
+	}
+}
+
+// SetFormatStyle sets the style of the JSONPath formatted by Format.
+func (c *Config) SetFormatStyle(style FormatStyle) {
+	c.FormatStyle = style
+}
+
 // SetAccessorMode sets a collection of accessors to the result.
 func (c *Config) SetAccessorMode() {
 	c.AccessorMode = true
 }
+
+// SetOptimization enables the optimization of the compiled JSONPath.
+// The results are the same, but the functions in the filters may be called in a different order.
+func (c *Config) SetOptimization() {
+	c.Optimization = true
+}
+
+// SetObserver sets the observer that receives the events of the retrieval.
+// The query compiled without the observer does not pay any cost for it.
+func (c *Config) SetObserver(observer Observer) {
+	c.Observer = observer
+}
+
+// SetCollectAllErrors makes the retrieval return the partial results together with errors.ErrorMultiple,
+// which lists the error of every failed branch instead of the most resolved one.
+func (c *Config) SetCollectAllErrors() {
+	c.CollectAllErrors = true
+}
+
+// SetContinueOnFunctionError makes the retrieval continue when the functions fail on some of the values,
+// and return the successful results together with errors.ErrorMultiple, which lists the failures of the functions.
+// The functions in the filters are not affected.
+func (c *Config) SetContinueOnFunctionError() {
+	c.ContinueOnFunctionError = true
+}
+
+// SetDocumentOrder makes the wildcards, the filters and the recursive descents retrieve the members of ordered.Object
+// in order of the document. The members of map[string]any are retrieved in sorted order of their keys regardless.
+func (c *Config) SetDocumentOrder() {
+	c.DocumentOrder = true
+}
+
+// SetUnordered makes the wildcards, the filters and the recursive descents retrieve the members of the objects
+// in the order of ranging over the map, skipping the sort of their keys.
+// The order of the results is unspecified, so that it suits the queries whose results are aggregated, such as sum().
+// It takes priority over SetDocumentOrder.
+func (c *Config) SetUnordered() {
+	c.Unordered = true
+}
@@ -0,0 +1,30 @@
+package config
+
+// Notation represents the notation of the member names in the formatted JSONPath.
+type Notation int
+
+const (
+	// BracketNotation renders the member names such as `['a']`.
+	BracketNotation Notation = iota
+	// DotNotation renders the member names such as `.a` if they consist of the letters, the digits, '-' and '_'.
+	DotNotation
+)
+
+// QuoteStyle represents the quotation marks of the strings in the formatted JSONPath.
+type QuoteStyle int
+
+const (
+	// SingleQuote renders the strings such as `'a'`.
+	SingleQuote QuoteStyle = iota
+	// DoubleQuote renders the strings such as `"a"`.
+	DoubleQuote
+)
+
+// FormatStyle represents the style of the formatted JSONPath.
+// The zero value is the canonical style such as `$['a'][?(@['b']>1)]`.
+type FormatStyle struct {
+	Notation Notation
+	Quote    QuoteStyle
+	// Spacing puts the spaces around the operators and after the commas.
+	Spacing bool
+}
@@ -0,0 +1,47 @@
+package config
+
+import "context"
+
+// FunctionKind represents the kind of the custom function.
+type FunctionKind int
+
+const (
+	// FilterKind represents the function applied to each of the values.
+	FilterKind FunctionKind = iota
+	// AggregateKind represents the function applied to all of the values together.
+	AggregateKind
+	// ExpressionKind represents the function that can be called only inside filter expressions.
+	ExpressionKind
+)
+
+// Function represents the custom function registered by name.
+type Function struct {
+	Kind FunctionKind
+	// Arity is the number of the arguments of the filter and aggregate functions.
+	Arity int
+	// ParamTypes and ResultType are the declared types of the expression function.
+	ParamTypes []FunctionType
+	ResultType FunctionType
+	// WithContext makes the filter and aggregate functions receive the FunctionContext.
+	// The other functions receive the zero FunctionContext, so that the retrieval does not keep track of it.
+	WithContext bool
+	Filter      func(FunctionContext, any, []any) (any, error)
+	Aggregate   func(FunctionContext, []any, []any) (any, error)
+	Expression  func([]any) (any, error)
+	// Inverse is the inverse of the filter function that takes neither the arguments nor the context.
+	// The accessors of the results write back to the source node through it.
+	Inverse func(any) (any, error)
+}
+
+// FunctionContext represents the information passed to the context-aware function.
+type FunctionContext struct {
+	// Context is the context given to the retrieval by the methods and the functions whose names end with Context.
+	// It is context.Background() if none is given.
+	Context context.Context
+	// Root is the root of the source JSON.
+	Root any
+	// Path is the normalized path of the current node (e.g. $['store']['book'][0]).
+	Path string
+	// UserData is the value set by Config.SetUserData.
+	UserData any
+}
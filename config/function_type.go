@@ -0,0 +1,35 @@
+package config
+
+// FunctionType represents the declared type of the parameters and the result of an expression function.
+type FunctionType int
+
+const (
+	// ValueType represents a single JSON value.
+	ValueType FunctionType = iota
+	// LogicalType represents a boolean value.
+	LogicalType
+	// NodesType represents a list of JSON values selected by a JSONPath.
+	NodesType
+	// NumberType represents a JSON number, either float64 or json.Number.
+	// It is a ValueType that the comparators compare as a number.
+	NumberType
+	// StringType represents a JSON string.
+	// It is a ValueType that the comparators compare as a string.
+	StringType
+)
+
+func (t FunctionType) String() string {
+	switch t {
+	case ValueType:
+		return `value`
+	case LogicalType:
+		return `logical`
+	case NodesType:
+		return `nodes`
+	case NumberType:
+		return `number`
+	case StringType:
+		return `string`
+	}
+	return `unknown`
+}
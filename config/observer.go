@@ -0,0 +1,19 @@
+package config
+
+import "context"
+
+// Observer receives the events of the retrieval, such as for the metrics and the tracing.
+// The methods are called synchronously and may be called concurrently by the concurrent retrievals.
+type Observer interface {
+	// OnQueryStart is called before the retrieval starts.
+	// The returned context is passed to the other methods and to the context-aware functions,
+	// so that it can carry the values such as the span of the tracing.
+	OnQueryStart(ctx context.Context, jsonPath string) context.Context
+	// OnNodeVisit is called each time a node of the JSONPath is retrieved, including the nodes in the filters.
+	// The segment is the part of the JSONPath that the node represents (e.g. `.store`, `[?(@.price)]`).
+	OnNodeVisit(ctx context.Context, segment string)
+	// OnFunctionCall is called after a function is called, with the error it returned.
+	OnFunctionCall(ctx context.Context, name string, err error)
+	// OnQueryEnd is called after the retrieval ends, with the number of results and the error.
+	OnQueryEnd(ctx context.Context, jsonPath string, resultCount int, err error)
+}
@@ -40,3 +40,37 @@ func ExampleAccessor() {
 	// Set -> Get : 3
 	// Src -> Get : 4
 }
+
+func ExampleConfig_SetReversibleFilterFunction() {
+	cfg := config.Config{}
+	cfg.SetAccessorMode()
+	cfg.SetReversibleFilterFunction(`toCents`,
+		func(param any) (any, error) {
+			return param.(float64) * 100, nil
+		},
+		func(param any) (any, error) {
+			return param.(float64) / 100, nil
+		})
+	jsonPath, srcJSON := `$.price.toCents()`, `{"price":12.5}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	accessor := output[0].(config.Accessor)
+	srcMap := src.(map[string]any)
+
+	fmt.Printf("Get : %v\n", accessor.Get())
+
+	if err := accessor.TrySet(2000.0); err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	fmt.Printf("Set -> Src : %v\n", srcMap[`price`])
+
+	// Output:
+	// Get : 1250
+	// Set -> Src : 20
+}
@@ -1,13 +1,16 @@
 package config_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/AsaiYusuke/jsonpath/v2"
 	"github.com/AsaiYusuke/jsonpath/v2/config"
 	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
 )
 
 func ExampleConfig_SetFilterFunction() {
@@ -62,6 +65,158 @@ func ExampleConfig_SetAggregateFunction() {
 	// [3]
 }
 
+func ExampleConfig_SetFilterFunctionWithArgs() {
+	config := config.Config{}
+	config.SetFilterFunctionWithArgs(`multiply`, 1, func(param any, args []any) (any, error) {
+		floatParam, ok := param.(float64)
+		if !ok {
+			return nil, fmt.Errorf(`type error`)
+		}
+		floatArg, ok := args[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf(`argument type error`)
+		}
+		return floatParam * floatArg, nil
+	})
+	jsonPath, srcJSON := `$.values[*].multiply($.factor)`, `{"values":[1,3],"factor":10}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, config)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// [10,30]
+}
+
+func ExampleConfig_SetAggregateFunctionWithArgs() {
+	config := config.Config{}
+	config.SetAggregateFunctionWithArgs(`join`, 1, func(params []any, args []any) (any, error) {
+		separator, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf(`argument type error`)
+		}
+		texts := make([]string, len(params))
+		for index, param := range params {
+			texts[index] = fmt.Sprint(param)
+		}
+		return strings.Join(texts, separator), nil
+	})
+	jsonPath, srcJSON := `$[*].join(', ')`, `["a","b","c"]`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, config)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// ["a, b, c"]
+}
+
+func ExampleConfig_SetExpressionFunction() {
+	cfg := config.Config{}
+	cfg.SetExpressionFunction(`lower`,
+		[]config.FunctionType{config.ValueType}, config.ValueType,
+		func(params []any) (any, error) {
+			if stringParam, ok := params[0].(string); ok {
+				return strings.ToLower(stringParam), nil
+			}
+			return nil, fmt.Errorf(`type error`)
+		})
+	cfg.SetExpressionFunction(`isEmail`,
+		[]config.FunctionType{config.ValueType}, config.LogicalType,
+		func(params []any) (any, error) {
+			stringParam, ok := params[0].(string)
+			return ok && strings.Contains(stringParam, `@`), nil
+		})
+	jsonPath := `$[?(isEmail(@.email) && lower(@.name) == 'bob')].id`
+	srcJSON := `[{"id":1,"name":"Bob","email":"bob@example.com"},{"id":2,"name":"BOB"},{"id":3,"name":"Alice"}]`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// [1]
+}
+
+func ExampleConfig_SetFilterFunctionWithContext() {
+	cfg := config.Config{}
+	cfg.SetFilterFunctionWithContext(`ref`, 0,
+		func(ctx config.FunctionContext, param any, _ []any) (any, error) {
+			definitions := ctx.Root.(map[string]any)[`definitions`].(map[string]any)
+			return fmt.Sprintf(`%s=%v`, ctx.Path, definitions[param.(string)]), nil
+		})
+	jsonPath := `$.items[*].type.ref()`
+	srcJSON := `{"definitions":{"s":1,"l":3},"items":[{"type":"s"},{"type":"l"}]}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.RetrieveContext(context.Background(), jsonPath, src, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// ["$['items'][0]['type']=1","$['items'][1]['type']=3"]
+}
+
+func ExampleConfig_SetFunction() {
+	cfg := config.Config{}
+	cfg.SetFunction(`scale`, config.Function{
+		Kind:  config.FilterKind,
+		Arity: 1,
+		Filter: func(_ config.FunctionContext, param any, args []any) (any, error) {
+			floatParam, ok := param.(float64)
+			if !ok {
+				return nil, fmt.Errorf(`type error`)
+			}
+			return floatParam * args[0].(float64), nil
+		},
+	})
+	jsonPath, srcJSON := `$[?(scale(@.price, 2) > 10)].id`, `[{"id":1,"price":4},{"id":2,"price":6}]`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// [2]
+}
+
+func ExampleConfig_SetStandardFunctions() {
+	cfg := config.Config{}
+	cfg.SetStandardFunctions()
+	jsonPath := `$[?(length(@.tags) > 1)].price.sum()`
+	srcJSON := `[{"price":10,"tags":["a","b"]},{"price":20,"tags":["a"]},{"price":5,"tags":["b","c"]}]`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// [15]
+}
+
 func ExampleConfig_SetAccessorMode() {
 	cfg := config.Config{}
 	cfg.SetAccessorMode()
@@ -93,3 +248,75 @@ func ExampleConfig_SetAccessorMode() {
 	// Set -> Get : 3
 	// Src -> Get : 4
 }
+
+func ExampleConfig_SetCollectAllErrors() {
+	cfg := config.Config{}
+	cfg.SetCollectAllErrors()
+	jsonPath, srcJSON := `$.items[*].price`, `{"items":[{"price":1},{"name":"a"},{"price":2},3]}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, cfg)
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	fmt.Println(err)
+	// Output:
+	// [1,2]
+	// branch failed (location=$['items'][1], error=member did not exist (path=.price))
+	// branch failed (location=$['items'][3], error=type unmatched (path=.price, expected=object, found=float64))
+}
+
+func ExampleConfig_SetContinueOnFunctionError() {
+	cfg := config.Config{}
+	cfg.SetFilterFunction(`twice`, func(param any) (any, error) {
+		if input, ok := param.(float64); ok {
+			return input * 2, nil
+		}
+		return nil, fmt.Errorf(`not a number`)
+	})
+	cfg.SetContinueOnFunctionError()
+	jsonPath, srcJSON := `$[*].twice()`, `[1,"a",2]`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, cfg)
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	fmt.Println(err)
+	// Output:
+	// [2,4]
+	// branch failed (location=$[1], error=function failed (path=.twice(), error=not a number))
+}
+
+func ExampleConfig_SetDocumentOrder() {
+	cfg := config.Config{}
+	cfg.SetDocumentOrder()
+	jsonPath, srcJSON := `$.*`, `{"name":"web","image":"nginx","ports":[80]}`
+	var src any
+	ordered.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// ["web","nginx",[80]]
+}
+
+func ExampleConfig_SetUnordered() {
+	cfg := config.Config{}
+	cfg.SetStandardFunctions()
+	cfg.SetUnordered()
+	jsonPath, srcJSON := `$..price.sum()`, `{"book":{"price":8},"pen":{"price":2},"bag":{"price":30}}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := jsonpath.Retrieve(jsonPath, src, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// [40]
+}
@@ -0,0 +1,74 @@
+package config_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+// slogObserver is the adapter that writes the events of the retrieval to log/slog.
+type slogObserver struct {
+	logger *slog.Logger
+}
+
+func (o slogObserver) OnQueryStart(ctx context.Context, jsonPath string) context.Context {
+	o.logger.DebugContext(ctx, `query start`, `jsonpath`, jsonPath)
+	return ctx
+}
+
+func (o slogObserver) OnNodeVisit(ctx context.Context, segment string) {
+	o.logger.DebugContext(ctx, `node visit`, `segment`, segment)
+}
+
+func (o slogObserver) OnFunctionCall(ctx context.Context, name string, err error) {
+	o.logger.DebugContext(ctx, `function call`, `name`, name, `error`, err)
+}
+
+func (o slogObserver) OnQueryEnd(ctx context.Context, jsonPath string, resultCount int, err error) {
+	o.logger.InfoContext(ctx, `query end`, `jsonpath`, jsonPath, `results`, resultCount, `error`, err)
+}
+
+func ExampleConfig_SetObserver() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+
+	config := config.Config{}
+	config.SetStandardFunctions()
+	config.SetObserver(slogObserver{logger: logger})
+	query, err := jsonpath.Compile(`$.a[*].max()`, config)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	srcJSON := `{"a":[1,3]}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := query.Retrieve(src)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// level=DEBUG msg="query start" jsonpath=$.a[*].max()
+	// level=DEBUG msg="node visit" segment=.max()
+	// level=DEBUG msg="node visit" segment=.a
+	// level=DEBUG msg="node visit" segment=[*]
+	// level=DEBUG msg="function call" name=max error=<nil>
+	// level=INFO msg="query end" jsonpath=$.a[*].max() results=1 error=<nil>
+	// [3]
+}
@@ -0,0 +1,38 @@
+package errors
+
+// The sentinel errors that the errors of this package match with errors.Is.
+// Each error type matches one of them, regardless of its fields.
+var (
+	// ErrSyntax is matched by the errors of compiling the JSONPath:
+	// ErrorInvalidSyntax, ErrorInvalidArgument, ErrorFunctionNotFound and ErrorNotSupported.
+	ErrSyntax error = sentinelError(`invalid JSONPath`)
+	// ErrNotFound is matched by ErrorMemberNotExist.
+	ErrNotFound error = sentinelError(`member did not exist`)
+	// ErrTypeUnmatched is matched by ErrorTypeUnmatched.
+	ErrTypeUnmatched error = sentinelError(`type unmatched`)
+	// ErrFunctionFailed is matched by ErrorFunctionFailed.
+	ErrFunctionFailed error = sentinelError(`function failed`)
+	// ErrReadOnly is matched by ErrorReadOnly.
+	ErrReadOnly error = sentinelError(`read-only`)
+	// ErrTypeConversion is matched by ErrorTypeConversion.
+	ErrTypeConversion error = sentinelError(`type conversion failed`)
+)
+
+type sentinelError string
+
+func (e sentinelError) Error() string {
+	return string(e)
+}
+
+// asError sets the error to the target of errors.As, whether the target points to the value or the pointer.
+func asError[T error](err T, target any) bool {
+	switch typedTarget := target.(type) {
+	case *T:
+		*typedTarget = err
+		return true
+	case **T:
+		*typedTarget = &err
+		return true
+	}
+	return false
+}
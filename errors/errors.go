@@ -1,18 +1,87 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ErrorInvalidSyntax represents the error that have syntax error in the JSONPath.
+// The fields after Near are set when the error is returned by the parser.
 type ErrorInvalidSyntax struct {
 	Position int
 	Reason   string
 	Near     string
+
+	// JSONPath is the whole JSONPath that has the error.
+	JSONPath string
+	// Line and Column locate, in runes from 1, where the parser stopped recognizing the JSONPath.
+	// It may be after Position, which is the start of the part that is not recognized.
+	Line   int
+	Column int
+	// Expected lists, separated by ", ", the quoted tokens and the kinds of the tokens, such as number,
+	// that are acceptable at Line and Column. It is empty in the string literal that is not closed.
+	// It is a string so that the error stays comparable with ==.
+	Expected string
+	// Hint suggests how to fix the common mistake found in the JSONPath.
+	Hint string
+	// Field is the name of the struct field that the JSONPath is decoded into, set by jsonpath.PrepareQueries.
+	Field string
 }
 
 func (e ErrorInvalidSyntax) Error() string {
+	if len(e.Field) > 0 {
+		return fmt.Sprintf(`invalid syntax (field=%s, position=%d, reason=%s, near=%s)`, e.Field, e.Position, e.Reason, e.Near)
+	}
 	return fmt.Sprintf(`invalid syntax (position=%d, reason=%s, near=%s)`, e.Position, e.Reason, e.Near)
 }
 
+// Pretty returns the multi-line message that points at the error in the JSONPath with a caret,
+// followed by the expected tokens and the hint.
+// It returns the same message as Error if the error has no location.
+func (e ErrorInvalidSyntax) Pretty() string {
+	if e.Line < 1 || e.Column < 1 {
+		return e.Error()
+	}
+
+	lines := strings.Split(e.JSONPath, "\n")
+	if e.Line > len(lines) {
+		return e.Error()
+	}
+	line := []rune(lines[e.Line-1])
+
+	var caret strings.Builder
+	for index := 0; index < e.Column-1 && index < len(line); index++ {
+		// The tab is kept so that the caret stays under the same column.
+		if line[index] == '\t' {
+			caret.WriteByte('\t')
+		} else {
+			caret.WriteByte(' ')
+		}
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "invalid syntax at line %d, column %d: %s\n", e.Line, e.Column, e.Reason)
+	fmt.Fprintf(&message, "  %s\n", string(line))
+	fmt.Fprintf(&message, "  %s^", caret.String())
+	if len(e.Expected) > 0 {
+		fmt.Fprintf(&message, "\nexpected: %s", e.Expected)
+	}
+	if len(e.Hint) > 0 {
+		fmt.Fprintf(&message, "\nhint: %s", e.Hint)
+	}
+	return message.String()
+}
+
+// Is reports whether the target is ErrSyntax.
+func (e ErrorInvalidSyntax) Is(target error) bool {
+	return target == ErrSyntax
+}
+
+// As sets the error to the target that is either *ErrorInvalidSyntax or **ErrorInvalidSyntax.
+func (e ErrorInvalidSyntax) As(target any) bool {
+	return asError(e, target)
+}
+
 func NewErrorInvalidSyntax(position int, reason string, near string) ErrorInvalidSyntax {
 	return ErrorInvalidSyntax{
 		Position: position,
@@ -31,6 +100,21 @@ func (e ErrorInvalidArgument) Error() string {
 	return fmt.Sprintf(`invalid argument (argument=%s, error=%s)`, e.ArgumentName, e.Err)
 }
 
+// Is reports whether the target is ErrSyntax.
+func (e ErrorInvalidArgument) Is(target error) bool {
+	return target == ErrSyntax
+}
+
+// As sets the error to the target that is either *ErrorInvalidArgument or **ErrorInvalidArgument.
+func (e ErrorInvalidArgument) As(target any) bool {
+	return asError(e, target)
+}
+
+// Unwrap returns the error that caused this error.
+func (e ErrorInvalidArgument) Unwrap() error {
+	return e.Err
+}
+
 func NewErrorInvalidArgument(argument string, err error) ErrorInvalidArgument {
 	return ErrorInvalidArgument{
 		ArgumentName: argument,
@@ -48,6 +132,16 @@ func (e ErrorNotSupported) Error() string {
 	return fmt.Sprintf(`not supported (path=%s, feature=%s)`, e.Path, e.Feature)
 }
 
+// Is reports whether the target is ErrSyntax.
+func (e ErrorNotSupported) Is(target error) bool {
+	return target == ErrSyntax
+}
+
+// As sets the error to the target that is either *ErrorNotSupported or **ErrorNotSupported.
+func (e ErrorNotSupported) As(target any) bool {
+	return asError(e, target)
+}
+
 func NewErrorNotSupported(feature string, path string) ErrorNotSupported {
 	return ErrorNotSupported{
 		Feature: feature,
@@ -64,6 +158,16 @@ func (e ErrorFunctionNotFound) Error() string {
 	return fmt.Sprintf(`function not found (path=%s)`, e.Function)
 }
 
+// Is reports whether the target is ErrSyntax.
+func (e ErrorFunctionNotFound) Is(target error) bool {
+	return target == ErrSyntax
+}
+
+// As sets the error to the target that is either *ErrorFunctionNotFound or **ErrorFunctionNotFound.
+func (e ErrorFunctionNotFound) As(target any) bool {
+	return asError(e, target)
+}
+
 func NewErrorFunctionNotFound(function string) ErrorFunctionNotFound {
 	return ErrorFunctionNotFound{
 		Function: function,
@@ -81,6 +185,16 @@ func (e ErrorTypeUnmatched) Error() string {
 	return fmt.Sprintf(`type unmatched (path=%s, expected=%s, found=%s)`, e.ErrorBasicRuntime.GetPath(), e.ExpectedType, e.FoundType)
 }
 
+// Is reports whether the target is ErrTypeUnmatched.
+func (e ErrorTypeUnmatched) Is(target error) bool {
+	return target == ErrTypeUnmatched
+}
+
+// As sets the error to the target that is either *ErrorTypeUnmatched or **ErrorTypeUnmatched.
+func (e ErrorTypeUnmatched) As(target any) bool {
+	return asError(e, target)
+}
+
 func NewErrorTypeUnmatched(errBasicRuntime *ErrorBasicRuntime, expected string, found string) ErrorTypeUnmatched {
 	return ErrorTypeUnmatched{
 		ErrorBasicRuntime: errBasicRuntime,
@@ -98,6 +212,16 @@ func (e ErrorMemberNotExist) Error() string {
 	return fmt.Sprintf(`member did not exist (path=%s)`, e.ErrorBasicRuntime.GetPath())
 }
 
+// Is reports whether the target is ErrNotFound.
+func (e ErrorMemberNotExist) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// As sets the error to the target that is either *ErrorMemberNotExist or **ErrorMemberNotExist.
+func (e ErrorMemberNotExist) As(target any) bool {
+	return asError(e, target)
+}
+
 func NewErrorMemberNotExist(errBasicRuntime *ErrorBasicRuntime) ErrorMemberNotExist {
 	return ErrorMemberNotExist{
 		ErrorBasicRuntime: errBasicRuntime,
@@ -114,9 +238,214 @@ func (e ErrorFunctionFailed) Error() string {
 	return fmt.Sprintf(`function failed (path=%s, error=%s)`, e.ErrorBasicRuntime.GetPath(), e.Err)
 }
 
+// Is reports whether the target is ErrFunctionFailed.
+func (e ErrorFunctionFailed) Is(target error) bool {
+	return target == ErrFunctionFailed
+}
+
+// As sets the error to the target that is either *ErrorFunctionFailed or **ErrorFunctionFailed.
+func (e ErrorFunctionFailed) As(target any) bool {
+	return asError(e, target)
+}
+
+// Unwrap returns the error that caused this error.
+func (e ErrorFunctionFailed) Unwrap() error {
+	return e.Err
+}
+
 func NewErrorFunctionFailed(path string, remainingPathLen int, err error) ErrorFunctionFailed {
 	return ErrorFunctionFailed{
 		ErrorBasicRuntime: &ErrorBasicRuntime{path: path, remainingPathLen: remainingPathLen},
 		Err:               err,
 	}
 }
+
+// ErrorReadOnly represents the error that the result node specified in the JSONPath cannot be updated.
+type ErrorReadOnly struct {
+	*ErrorBasicRuntime
+}
+
+func (e ErrorReadOnly) Error() string {
+	return fmt.Sprintf(`read-only (path=%s)`, e.ErrorBasicRuntime.GetPath())
+}
+
+// Is reports whether the target is ErrReadOnly.
+func (e ErrorReadOnly) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+// As sets the error to the target that is either *ErrorReadOnly or **ErrorReadOnly.
+func (e ErrorReadOnly) As(target any) bool {
+	return asError(e, target)
+}
+
+func NewErrorReadOnly(errBasicRuntime *ErrorBasicRuntime) ErrorReadOnly {
+	return ErrorReadOnly{
+		ErrorBasicRuntime: errBasicRuntime,
+	}
+}
+
+// ErrorTypeConversion represents the error that the retrieved value cannot be converted to the requested Go type.
+// Location is the normalized path of the value. Err is set when the value is a number out of range or not an integer.
+type ErrorTypeConversion struct {
+	Location string
+	Expected string
+	Found    string
+	Err      error
+}
+
+func (e ErrorTypeConversion) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf(`type conversion failed (location=%s, expected=%s, found=%s, error=%s)`, e.Location, e.Expected, e.Found, e.Err)
+	}
+	return fmt.Sprintf(`type conversion failed (location=%s, expected=%s, found=%s)`, e.Location, e.Expected, e.Found)
+}
+
+// Is reports whether the target is ErrTypeConversion.
+func (e ErrorTypeConversion) Is(target error) bool {
+	return target == ErrTypeConversion
+}
+
+// As sets the error to the target that is either *ErrorTypeConversion or **ErrorTypeConversion.
+func (e ErrorTypeConversion) As(target any) bool {
+	return asError(e, target)
+}
+
+// Unwrap returns the error that caused this error.
+func (e ErrorTypeConversion) Unwrap() error {
+	return e.Err
+}
+
+func NewErrorTypeConversion(location string, expected string, found string, err error) ErrorTypeConversion {
+	return ErrorTypeConversion{
+		Location: location,
+		Expected: expected,
+		Found:    found,
+		Err:      err,
+	}
+}
+
+// ErrorBranchFailed represents the error of a branch of the retrieval, such as an element selected by the wildcard.
+// Location is the normalized path of the node where the rest of the JSONPath failed.
+type ErrorBranchFailed struct {
+	Location string
+	Err      ErrorRuntime
+}
+
+func (e ErrorBranchFailed) Error() string {
+	return fmt.Sprintf(`branch failed (location=%s, error=%s)`, e.Location, e.Err)
+}
+
+// As sets the error to the target that is either *ErrorBranchFailed or **ErrorBranchFailed.
+func (e ErrorBranchFailed) As(target any) bool {
+	return asError(e, target)
+}
+
+// Unwrap returns the error that caused this error.
+func (e ErrorBranchFailed) Unwrap() error {
+	return e.Err
+}
+
+func NewErrorBranchFailed(location string, err ErrorRuntime) ErrorBranchFailed {
+	return ErrorBranchFailed{
+		Location: location,
+		Err:      err,
+	}
+}
+
+// ErrorMultiple represents the errors of all the failed branches, returned together with the partial results.
+// It works like the error made by errors.Join.
+type ErrorMultiple struct {
+	Errors []ErrorBranchFailed
+}
+
+func (e ErrorMultiple) Error() string {
+	messages := make([]string, len(e.Errors))
+	for index := range e.Errors {
+		messages[index] = e.Errors[index].Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// As sets the error to the target that is either *ErrorMultiple or **ErrorMultiple.
+func (e ErrorMultiple) As(target any) bool {
+	return asError(e, target)
+}
+
+// Unwrap returns the errors of the failed branches.
+func (e ErrorMultiple) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for index := range e.Errors {
+		errs[index] = e.Errors[index]
+	}
+	return errs
+}
+
+func NewErrorMultiple(errs []ErrorBranchFailed) ErrorMultiple {
+	return ErrorMultiple{
+		Errors: errs,
+	}
+}
+
+// ErrorFieldFailed represents the error of a field of the struct that is extracted by the JSONPath in its tag.
+type ErrorFieldFailed struct {
+	Field    string
+	JSONPath string
+	Err      error
+}
+
+func (e ErrorFieldFailed) Error() string {
+	return fmt.Sprintf(`field failed (field=%s, jsonpath=%s, error=%s)`, e.Field, e.JSONPath, e.Err)
+}
+
+// As sets the error to the target that is either *ErrorFieldFailed or **ErrorFieldFailed.
+func (e ErrorFieldFailed) As(target any) bool {
+	return asError(e, target)
+}
+
+// Unwrap returns the error that caused this error.
+func (e ErrorFieldFailed) Unwrap() error {
+	return e.Err
+}
+
+func NewErrorFieldFailed(field string, jsonPath string, err error) ErrorFieldFailed {
+	return ErrorFieldFailed{
+		Field:    field,
+		JSONPath: jsonPath,
+		Err:      err,
+	}
+}
+
+// ErrorUnmarshal represents the errors of all the failed fields of the struct.
+// It works like the error made by errors.Join.
+type ErrorUnmarshal struct {
+	Errors []ErrorFieldFailed
+}
+
+func (e ErrorUnmarshal) Error() string {
+	messages := make([]string, len(e.Errors))
+	for index := range e.Errors {
+		messages[index] = e.Errors[index].Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// As sets the error to the target that is either *ErrorUnmarshal or **ErrorUnmarshal.
+func (e ErrorUnmarshal) As(target any) bool {
+	return asError(e, target)
+}
+
+// Unwrap returns the errors of the failed fields.
+func (e ErrorUnmarshal) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for index := range e.Errors {
+		errs[index] = e.Errors[index]
+	}
+	return errs
+}
+
+func NewErrorUnmarshal(errs []ErrorFieldFailed) ErrorUnmarshal {
+	return ErrorUnmarshal{
+		Errors: errs,
+	}
+}
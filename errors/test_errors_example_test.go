@@ -1,7 +1,9 @@
 package errors_test
 
 import (
+	"context"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"reflect"
 
@@ -26,6 +28,21 @@ func ExampleErrorInvalidSyntax() {
 	// type: errors.ErrorInvalidSyntax, value: invalid syntax (position=1, reason=unrecognized input, near=.)
 }
 
+func ExampleErrorInvalidSyntax_Pretty() {
+	jsonPath := `$.a[?(@.b = 1)]`
+	_, err := jsonpath.Parse(jsonPath)
+	var syntaxErr errors.ErrorInvalidSyntax
+	if goerrors.As(err, &syntaxErr) {
+		fmt.Println(syntaxErr.Pretty())
+	}
+	// Output:
+	// invalid syntax at line 1, column 11: unrecognized input
+	//   $.a[?(@.b = 1)]
+	//             ^
+	// expected: ')', '==', '!=', '<', '<=', '>', '>=', '=~', '&&', '||'
+	// hint: use '==' to compare the values
+}
+
 func ExampleErrorInvalidArgument() {
 	jsonPath, srcJSON := `$[?(1.0.0>0)]`, `{}`
 	var src any
@@ -125,3 +142,98 @@ func ExampleErrorFunctionFailed() {
 	// Output:
 	// type: errors.ErrorFunctionFailed, value: function failed (path=.invalid(), error=invalid function executed)
 }
+
+func ExampleErrorReadOnly() {
+	cfg := config.Config{}
+	cfg.SetAccessorMode()
+	cfg.SetAggregateFunction(`count`, func(params []any) (any, error) {
+		return float64(len(params)), nil
+	})
+	jsonPath, srcJSON := `$[*].count()`, `[1,2,3]`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, _ := jsonpath.Retrieve(jsonPath, src, cfg)
+	accessor := output[0].(config.Accessor)
+	err := accessor.TrySet(4)
+	switch err.(type) {
+	case errors.ErrorReadOnly:
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	// Output:
+	// type: errors.ErrorReadOnly, value: read-only (path=.count())
+}
+
+func ExampleErrorTypeConversion() {
+	query, _ := jsonpath.Compile(`$.items[*].count`)
+	srcJSON := `{"items":[{"count":1},{"count":300}]}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	_, err := jsonpath.GetAll[int8](query, src)
+	switch err.(type) {
+	case errors.ErrorTypeConversion:
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	// Output:
+	// type: errors.ErrorTypeConversion, value: type conversion failed (location=$['items'][1]['count'], expected=int8, found=float64, error=300 overflows int8)
+}
+
+func ExampleErrorUnmarshal() {
+	type User struct {
+		Name string `jsonpath:"$.name"`
+		Age  uint8  `jsonpath:"$.age"`
+		Mail string `jsonpath:"$.mail"`
+	}
+	srcJSON := `{"name":"alice","age":-1}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	var user User
+	err := jsonpath.Unmarshal(src, &user)
+	var unmarshalErr errors.ErrorUnmarshal
+	if goerrors.As(err, &unmarshalErr) {
+		for _, fieldErr := range unmarshalErr.Errors {
+			fmt.Println(fieldErr.Field, goerrors.Is(fieldErr, errors.ErrNotFound))
+		}
+	}
+	fmt.Println(err)
+	// Output:
+	// Age false
+	// Mail true
+	// field failed (field=Age, jsonpath=$.age, error=type conversion failed (location=$['age'], expected=uint8, found=float64, error=-1 overflows uint8))
+	// field failed (field=Mail, jsonpath=$.mail, error=member did not exist (path=.mail))
+}
+
+func ExampleErrorFunctionFailed_Unwrap() {
+	cfg := config.Config{}
+	cfg.SetFilterFunctionWithContext(`check`, 0,
+		func(ctx config.FunctionContext, param any, _ []any) (any, error) {
+			if err := ctx.Context.Err(); err != nil {
+				return nil, err
+			}
+			return param, nil
+		})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := jsonpath.RetrieveContext(ctx, `$.check()`, map[string]any{}, cfg)
+	fmt.Println(goerrors.Is(err, context.Canceled), goerrors.Is(err, errors.ErrFunctionFailed))
+	// Output:
+	// true true
+}
+
+func ExampleErrorMemberNotExist_As() {
+	_, err := jsonpath.Retrieve(`$.a.b`, map[string]any{`a`: map[string]any{}})
+	fmt.Println(goerrors.Is(err, errors.ErrNotFound))
+	var valueErr errors.ErrorMemberNotExist
+	if goerrors.As(err, &valueErr) {
+		fmt.Println(valueErr.GetPath())
+	}
+	var pointerErr *errors.ErrorMemberNotExist
+	if goerrors.As(err, &pointerErr) {
+		fmt.Println(pointerErr.GetPath())
+	}
+	// Output:
+	// true
+	// .b
+	// .b
+}
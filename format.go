@@ -0,0 +1,313 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/internal/syntax"
+)
+
+// Format returns the JSONPath in the style of Config.FormatStyle.
+// The JSONPaths that have the same meaning are formatted to the same text,
+// such as `$.a['b']`, `$['a'].b` and `$[ 'a' ][ 'b' ]`.
+func Format(jsonPath string, config ...config.Config) (string, error) {
+	query, err := syntax.Compile(jsonPath, config...)
+	if err != nil {
+		return ``, err
+	}
+	if len(config) > 0 {
+		return formatQuery(query.AST(), config[0].FormatStyle), nil
+	}
+	return formatQuery(query.AST(), defaultFormatStyle), nil
+}
+
+var defaultFormatStyle = config.FormatStyle{}
+
+type formatter struct {
+	builder strings.Builder
+	style   config.FormatStyle
+}
+
+func formatQuery(query *ast.Query, style config.FormatStyle) string {
+	f := formatter{style: style}
+	f.writeQuery(query)
+	return f.builder.String()
+}
+
+func formatExpression(expression ast.Expression, style config.FormatStyle) string {
+	f := formatter{style: style}
+	f.writeExpression(expression)
+	return f.builder.String()
+}
+
+func (f *formatter) writeQuery(query *ast.Query) {
+	f.builder.WriteString(query.Identifier.String())
+	for _, segment := range query.Segments {
+		f.writeSegment(segment)
+	}
+}
+
+func (f *formatter) writeSegment(segment ast.Segment) {
+	switch typedSegment := segment.(type) {
+	case *ast.ChildSegment:
+		if f.isShorthand(typedSegment.Selectors) {
+			f.builder.WriteString(`.`)
+			f.writeShorthand(typedSegment.Selectors[0])
+			return
+		}
+		f.writeSelectors(typedSegment.Selectors)
+	case *ast.DescendantSegment:
+		f.builder.WriteString(`..`)
+		if f.isShorthand(typedSegment.Selectors) {
+			f.writeShorthand(typedSegment.Selectors[0])
+			return
+		}
+		f.writeSelectors(typedSegment.Selectors)
+	case *ast.FunctionSegment:
+		f.builder.WriteString(`.`)
+		f.writeFunction(typedSegment.Function)
+	}
+}
+
+func (f *formatter) isShorthand(selectors []ast.Selector) bool {
+	if f.style.Notation != config.DotNotation || len(selectors) != 1 {
+		return false
+	}
+	switch typedSelector := selectors[0].(type) {
+	case *ast.WildcardSelector:
+		return true
+	case *ast.NameSelector:
+		if len(typedSelector.Name) == 0 {
+			return false
+		}
+		for _, r := range typedSelector.Name {
+			if !isShorthandRune(r) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func isShorthandRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '-' || r == '_' || r >= 0x80
+}
+
+func (f *formatter) writeShorthand(selector ast.Selector) {
+	if nameSelector, ok := selector.(*ast.NameSelector); ok {
+		f.builder.WriteString(nameSelector.Name)
+		return
+	}
+	f.builder.WriteString(`*`)
+}
+
+func (f *formatter) writeSelectors(selectors []ast.Selector) {
+	f.builder.WriteString(`[`)
+	for index, selector := range selectors {
+		if index > 0 {
+			f.writeSeparator()
+		}
+		f.writeSelector(selector)
+	}
+	f.builder.WriteString(`]`)
+}
+
+func (f *formatter) writeSelector(selector ast.Selector) {
+	switch typedSelector := selector.(type) {
+	case *ast.NameSelector:
+		f.writeString(typedSelector.Name)
+	case *ast.WildcardSelector:
+		f.builder.WriteString(`*`)
+	case *ast.IndexSelector:
+		f.builder.WriteString(strconv.Itoa(typedSelector.Index))
+	case *ast.SliceSelector:
+		f.writeSliceIndex(typedSelector.Start)
+		f.builder.WriteString(`:`)
+		f.writeSliceIndex(typedSelector.End)
+		if typedSelector.Step != nil {
+			f.builder.WriteString(`:`)
+			f.writeSliceIndex(typedSelector.Step)
+		}
+	case *ast.FilterSelector:
+		f.builder.WriteString(`?(`)
+		f.writeExpression(typedSelector.Expression)
+		f.builder.WriteString(`)`)
+	}
+}
+
+func (f *formatter) writeSliceIndex(index *int) {
+	if index != nil {
+		f.builder.WriteString(strconv.Itoa(*index))
+	}
+}
+
+func (f *formatter) writeSeparator() {
+	f.builder.WriteString(`,`)
+	if f.style.Spacing {
+		f.builder.WriteString(` `)
+	}
+}
+
+func (f *formatter) writeOperator(operator string) {
+	if f.style.Spacing {
+		f.builder.WriteString(` `)
+		f.builder.WriteString(operator)
+		f.builder.WriteString(` `)
+		return
+	}
+	f.builder.WriteString(operator)
+}
+
+func (f *formatter) writeExpression(expression ast.Expression) {
+	switch typedExpression := expression.(type) {
+	case *ast.LogicalOr:
+		f.writeOperand(typedExpression.Left, false)
+		f.writeOperator(`||`)
+		f.writeOperand(typedExpression.Right, isLogicalOr(typedExpression.Right))
+	case *ast.LogicalAnd:
+		f.writeOperand(typedExpression.Left, isLogicalOr(typedExpression.Left))
+		f.writeOperator(`&&`)
+		f.writeOperand(typedExpression.Right,
+			isLogicalOr(typedExpression.Right) || isLogicalAnd(typedExpression.Right))
+	case *ast.LogicalNot:
+		f.builder.WriteString(`!`)
+		f.writeExpression(typedExpression.Expression)
+	case *ast.Comparison:
+		f.writeComparable(typedExpression.Left)
+		f.writeOperator(typedExpression.Operator.String())
+		f.writeComparable(typedExpression.Right)
+	case *ast.RegexMatch:
+		f.writeComparable(typedExpression.Left)
+		f.writeOperator(`=~`)
+		f.builder.WriteString(`/`)
+		f.writeRegex(typedExpression.Pattern)
+		f.builder.WriteString(`/`)
+	case *ast.ExistenceTest:
+		f.writeQuery(typedExpression.Query)
+	case *ast.FunctionTest:
+		f.writeFunction(typedExpression.Function)
+	}
+}
+
+func (f *formatter) writeOperand(expression ast.Expression, isParenthesized bool) {
+	if isParenthesized {
+		f.builder.WriteString(`(`)
+		f.writeExpression(expression)
+		f.builder.WriteString(`)`)
+		return
+	}
+	f.writeExpression(expression)
+}
+
+func isLogicalOr(expression ast.Expression) bool {
+	_, ok := expression.(*ast.LogicalOr)
+	return ok
+}
+
+func isLogicalAnd(expression ast.Expression) bool {
+	_, ok := expression.(*ast.LogicalAnd)
+	return ok
+}
+
+func (f *formatter) writeComparable(comparable ast.Comparable) {
+	switch typedComparable := comparable.(type) {
+	case *ast.Literal:
+		f.writeLiteral(typedComparable.Value)
+	case *ast.Query:
+		f.writeQuery(typedComparable)
+	case *ast.Function:
+		f.writeFunction(typedComparable)
+	}
+}
+
+func (f *formatter) writeFunction(function *ast.Function) {
+	f.builder.WriteString(function.Name)
+	f.builder.WriteString(`(`)
+	for index, argument := range function.Arguments {
+		if index > 0 {
+			f.writeSeparator()
+		}
+		switch typedArgument := argument.(type) {
+		case ast.Comparable:
+			f.writeComparable(typedArgument)
+		case ast.Expression:
+			f.writeExpression(typedArgument)
+		}
+	}
+	f.builder.WriteString(`)`)
+}
+
+func (f *formatter) writeLiteral(value any) {
+	switch typedValue := value.(type) {
+	case string:
+		f.writeString(typedValue)
+	case float64:
+		if number, err := json.Marshal(typedValue); err == nil {
+			f.builder.Write(number)
+			return
+		}
+		f.builder.WriteString(strconv.FormatFloat(typedValue, 'g', -1, 64))
+	case bool:
+		f.builder.WriteString(strconv.FormatBool(typedValue))
+	case nil:
+		f.builder.WriteString(`null`)
+	}
+}
+
+func (f *formatter) writeString(text string) {
+	quote := '\''
+	if f.style.Quote == config.DoubleQuote {
+		quote = '"'
+	}
+
+	f.builder.WriteRune(quote)
+	for _, r := range text {
+		switch r {
+		case quote:
+			f.builder.WriteRune('\\')
+			f.builder.WriteRune(r)
+		case '\\':
+			f.builder.WriteString(`\\`)
+		case '\b':
+			f.builder.WriteString(`\b`)
+		case '\f':
+			f.builder.WriteString(`\f`)
+		case '\n':
+			f.builder.WriteString(`\n`)
+		case '\r':
+			f.builder.WriteString(`\r`)
+		case '\t':
+			f.builder.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				f.builder.WriteString(`\u00`)
+				f.builder.WriteString(strconv.FormatInt(int64(r)>>4, 16))
+				f.builder.WriteString(strconv.FormatInt(int64(r)&0xf, 16))
+				continue
+			}
+			f.builder.WriteRune(r)
+		}
+	}
+	f.builder.WriteRune(quote)
+}
+
+// writeRegex escapes the slashes that are not escaped yet.
+func (f *formatter) writeRegex(pattern string) {
+	var isEscaped bool
+	for _, r := range pattern {
+		switch {
+		case isEscaped:
+			isEscaped = false
+		case r == '\\':
+			isEscaped = true
+		case r == '/':
+			f.builder.WriteString(`\`)
+		}
+		f.builder.WriteRune(r)
+	}
+}
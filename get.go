@@ -0,0 +1,186 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// Get returns the first value retrieved by the query, converted to T.
+// The numbers, either float64 or json.Number, are converted to the integer and float types,
+// the arrays are converted element by element to the slice types,
+// and the accessors are converted by their values unless T is config.Accessor.
+// The value that cannot be converted results in errors.ErrorTypeConversion with its normalized path.
+func Get[T any](q *Query, src any) (T, error) {
+	output, paths, err := q.RetrievePaths(src)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convert[T](output[0], paths[0])
+}
+
+// GetAll returns all the values retrieved by the query, converted to T in the same way as Get.
+// It fails on the first value that cannot be converted.
+func GetAll[T any](q *Query, src any) ([]T, error) {
+	output, paths, err := q.RetrievePaths(src)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]T, len(output))
+	for index := range output {
+		if values[index], err = convert[T](output[index], paths[index]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func convert[T any](value any, location string) (T, error) {
+	var result T
+	err := setValue(reflect.ValueOf(&result).Elem(), value, location)
+	return result, err
+}
+
+var accessorType = reflect.TypeFor[config.Accessor]()
+
+// setValue sets the value to the target, converting the numbers and the elements of the arrays.
+func setValue(target reflect.Value, value any, location string) error {
+	if accessor, ok := value.(config.Accessor); ok && target.Type() != accessorType {
+		var err error
+		if value, err = accessor.TryGet(); err != nil {
+			return err
+		}
+	}
+
+	if value == nil {
+		switch target.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice:
+			target.SetZero()
+			return nil
+		}
+		return errors.NewErrorTypeConversion(location, target.Type().String(), typeName(value), nil)
+	}
+
+	if reflect.TypeOf(value).AssignableTo(target.Type()) {
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	switch typedValue := value.(type) {
+	case float64, json.Number:
+		if target.CanInt() || target.CanUint() || target.CanFloat() {
+			if err := setNumber(target, value); err != nil {
+				return errors.NewErrorTypeConversion(location, target.Type().String(), typeName(value), err)
+			}
+			return nil
+		}
+	case []any:
+		if target.Kind() == reflect.Slice {
+			list := reflect.MakeSlice(target.Type(), len(typedValue), len(typedValue))
+			for index := range typedValue {
+				if err := setValue(list.Index(index), typedValue[index], location+`[`+strconv.Itoa(index)+`]`); err != nil {
+					return err
+				}
+			}
+			target.Set(list)
+			return nil
+		}
+	}
+	return errors.NewErrorTypeConversion(location, target.Type().String(), typeName(value), nil)
+}
+
+// setNumber sets the number, either float64 or json.Number, to the integer or float value.
+func setNumber(target reflect.Value, value any) error {
+	switch {
+	case target.CanInt():
+		number, err := toInt64(value, target.Type())
+		if err != nil {
+			return err
+		}
+		if target.OverflowInt(number) {
+			return fmt.Errorf(`%v overflows %s`, value, target.Type())
+		}
+		target.SetInt(number)
+		return nil
+
+	case target.CanUint():
+		number, err := toUint64(value, target.Type())
+		if err != nil {
+			return err
+		}
+		if target.OverflowUint(number) {
+			return fmt.Errorf(`%v overflows %s`, value, target.Type())
+		}
+		target.SetUint(number)
+		return nil
+
+	default:
+		number, err := toFloat64(value)
+		if err != nil {
+			return err
+		}
+		if target.OverflowFloat(number) {
+			return fmt.Errorf(`%v overflows %s`, value, target.Type())
+		}
+		target.SetFloat(number)
+		return nil
+	}
+}
+
+func toFloat64(value any) (float64, error) {
+	if typedValue, ok := value.(json.Number); ok {
+		return strconv.ParseFloat(string(typedValue), 64)
+	}
+	return value.(float64), nil
+}
+
+func toInt64(value any, targetType reflect.Type) (int64, error) {
+	if number, ok := value.(json.Number); ok {
+		if integer, err := strconv.ParseInt(string(number), 10, 64); err == nil {
+			return integer, nil
+		}
+	}
+	number, err := toFloat64(value)
+	if err != nil {
+		return 0, err
+	}
+	if number != math.Trunc(number) {
+		return 0, fmt.Errorf(`%v is not an integer`, value)
+	}
+	if number < math.MinInt64 || number >= math.MaxInt64 {
+		return 0, fmt.Errorf(`%v overflows %s`, value, targetType)
+	}
+	return int64(number), nil
+}
+
+func toUint64(value any, targetType reflect.Type) (uint64, error) {
+	if number, ok := value.(json.Number); ok {
+		if integer, err := strconv.ParseUint(string(number), 10, 64); err == nil {
+			return integer, nil
+		}
+	}
+	number, err := toFloat64(value)
+	if err != nil {
+		return 0, err
+	}
+	if number != math.Trunc(number) {
+		return 0, fmt.Errorf(`%v is not an integer`, value)
+	}
+	if number < 0 || number >= math.MaxUint64 {
+		return 0, fmt.Errorf(`%v overflows %s`, value, targetType)
+	}
+	return uint64(number), nil
+}
+
+func typeName(value any) string {
+	if value == nil {
+		return `null`
+	}
+	return reflect.TypeOf(value).String()
+}
@@ -0,0 +1,199 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
+)
+
+// Min returns the smallest number as it is in the values, either float64 or json.Number.
+func Min(values []any) (any, error) {
+	return selectNumber(values, -1)
+}
+
+// Max returns the largest number as it is in the values, either float64 or json.Number.
+func Max(values []any) (any, error) {
+	return selectNumber(values, 1)
+}
+
+// selectNumber returns the first value that compares to all the others by the given sign or equal.
+func selectNumber(values []any, sign int) (any, error) {
+	numbers, err := toNumbers(values)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, errors.New(msgErrorEmptyInput)
+	}
+	selected := 0
+	for index := 1; index < len(values); index++ {
+		if compareNumbers(values[index], numbers[index], values[selected], numbers[selected]) == sign {
+			selected = index
+		}
+	}
+	return values[selected], nil
+}
+
+// Sum returns the sum of the numbers.
+func Sum(values []any) (any, error) {
+	numbers, err := toNumbers(values)
+	if err != nil {
+		return nil, err
+	}
+	var sum float64
+	for _, number := range numbers {
+		sum += number
+	}
+	return sum, nil
+}
+
+// Avg returns the arithmetic mean of the numbers.
+func Avg(values []any) (any, error) {
+	numbers, err := toNumbers(values)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, errors.New(msgErrorEmptyInput)
+	}
+	var sum float64
+	for _, number := range numbers {
+		sum += number
+	}
+	return sum / float64(len(numbers)), nil
+}
+
+// Count returns the number of the values.
+func Count(values []any) (any, error) {
+	return float64(len(values)), nil
+}
+
+// First returns the first value.
+func First(values []any) (any, error) {
+	if len(values) == 0 {
+		return nil, errors.New(msgErrorEmptyInput)
+	}
+	return values[0], nil
+}
+
+// Last returns the last value.
+func Last(values []any) (any, error) {
+	if len(values) == 0 {
+		return nil, errors.New(msgErrorEmptyInput)
+	}
+	return values[len(values)-1], nil
+}
+
+// Distinct returns the values without duplicates, keeping the first occurrence.
+// Numbers are compared by their value regardless of float64 or json.Number.
+func Distinct(values []any) (any, error) {
+	result := make([]any, 0, len(values))
+	found := make(map[any]struct{}, len(values))
+	for _, value := range values {
+		if key, ok := normalize(value); ok {
+			if _, exists := found[key]; exists {
+				continue
+			}
+			found[key] = struct{}{}
+			result = append(result, value)
+			continue
+		}
+		if !slices.ContainsFunc(result, func(resultValue any) bool {
+			return ordered.Equal(resultValue, value)
+		}) {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
+
+// Sort returns the numbers or the strings in ascending order.
+func Sort(values []any) (any, error) {
+	result := make([]any, len(values))
+	copy(result, values)
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	if _, isString := result[0].(string); isString {
+		for _, value := range result {
+			if _, ok := value.(string); !ok {
+				return nil, fmt.Errorf(msgErrorMixedTypes, msgTypeString)
+			}
+		}
+		slices.SortStableFunc(result, func(a, b any) int {
+			return strings.Compare(a.(string), b.(string))
+		})
+		return result, nil
+	}
+
+	numbers, err := toNumbers(result)
+	if err != nil {
+		if _, ok := toNumber(result[0]); ok {
+			return nil, fmt.Errorf(msgErrorMixedTypes, msgTypeNumber)
+		}
+		return nil, newErrorTypeUnmatched(msgTypeNumberOrString, result[0])
+	}
+	indexes := make([]int, len(result))
+	for index := range indexes {
+		indexes[index] = index
+	}
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		switch {
+		case numbers[a] < numbers[b]:
+			return -1
+		case numbers[a] > numbers[b]:
+			return 1
+		}
+		return 0
+	})
+	for index := range indexes {
+		result[index] = values[indexes[index]]
+	}
+	return result, nil
+}
+
+// Reverse returns the values in reverse order.
+func Reverse(values []any) (any, error) {
+	result := make([]any, len(values))
+	for index := range values {
+		result[len(values)-1-index] = values[index]
+	}
+	return result, nil
+}
+
+// Concat joins the strings, or flattens the arrays into one array.
+func Concat(values []any) (any, error) {
+	if len(values) == 0 {
+		return nil, errors.New(msgErrorEmptyInput)
+	}
+
+	switch values[0].(type) {
+	case string:
+		var builder strings.Builder
+		for _, value := range values {
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf(msgErrorMixedTypes, msgTypeString)
+			}
+			builder.WriteString(text)
+		}
+		return builder.String(), nil
+
+	case []any:
+		result := make([]any, 0, len(values))
+		for _, value := range values {
+			array, ok := value.([]any)
+			if !ok {
+				return nil, fmt.Errorf(msgErrorMixedTypes, msgTypeArray)
+			}
+			result = append(result, array...)
+		}
+		return result, nil
+	}
+
+	return nil, newErrorTypeUnmatched(msgTypeStringOrArray, values[0])
+}
@@ -0,0 +1,136 @@
+package functions
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
+)
+
+// Keys returns the member names of the object in sorted order.
+func Keys(value any) (any, error) {
+	object, ok := toObject(value)
+	if !ok {
+		return nil, newErrorTypeUnmatched(msgTypeObject, value)
+	}
+	keys := sortedKeys(object)
+	result := make([]any, len(keys))
+	for index := range keys {
+		result[index] = keys[index]
+	}
+	return result, nil
+}
+
+// Values returns the member values of the object in the sorted order of their names.
+func Values(value any) (any, error) {
+	object, ok := toObject(value)
+	if !ok {
+		return nil, newErrorTypeUnmatched(msgTypeObject, value)
+	}
+	keys := sortedKeys(object)
+	result := make([]any, len(keys))
+	for index := range keys {
+		result[index] = object[keys[index]]
+	}
+	return result, nil
+}
+
+// toObject returns the members of the object, either map[string]any or *ordered.Object.
+func toObject(value any) (map[string]any, bool) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		return typedValue, true
+	case *ordered.Object:
+		return typedValue.Map(), true
+	}
+	return nil, false
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Length returns the number of characters of the string,
+// or the number of elements of the array or the object.
+func Length(value any) (any, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return float64(utf8.RuneCountInString(typedValue)), nil
+	case []any:
+		return float64(len(typedValue)), nil
+	case map[string]any:
+		return float64(len(typedValue)), nil
+	case *ordered.Object:
+		return float64(typedValue.Len()), nil
+	}
+	return nil, newErrorTypeUnmatched(msgTypeStringArrayOrObject, value)
+}
+
+// Lower returns the string converted to lower case.
+func Lower(value any) (any, error) {
+	text, ok := value.(string)
+	if !ok {
+		return nil, newErrorTypeUnmatched(msgTypeString, value)
+	}
+	return strings.ToLower(text), nil
+}
+
+// Upper returns the string converted to upper case.
+func Upper(value any) (any, error) {
+	text, ok := value.(string)
+	if !ok {
+		return nil, newErrorTypeUnmatched(msgTypeString, value)
+	}
+	return strings.ToUpper(text), nil
+}
+
+// Trim returns the string without leading and trailing white space.
+func Trim(value any) (any, error) {
+	text, ok := value.(string)
+	if !ok {
+		return nil, newErrorTypeUnmatched(msgTypeString, value)
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// ToNumber returns the number parsed from the string, or the number itself as float64.
+func ToNumber(value any) (any, error) {
+	if number, ok := toNumber(value); ok {
+		return number, nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return nil, newErrorTypeUnmatched(msgTypeNumberOrString, value)
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return nil, fmt.Errorf(msgErrorInvalidNumber, strconv.Quote(text))
+	}
+	return number, nil
+}
+
+// ToString returns the string representation of the scalar value.
+func ToString(value any) (any, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, nil
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64), nil
+	case json.Number:
+		return typedValue.String(), nil
+	case bool:
+		return strconv.FormatBool(typedValue), nil
+	case nil:
+		return msgTypeNull, nil
+	}
+	return nil, newErrorTypeUnmatched(msgTypeScalar, value)
+}
@@ -0,0 +1,100 @@
+// Package functions provides the standard filter and aggregate functions.
+package functions
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
+)
+
+const (
+	msgErrorTypeUnmatched string = `type unmatched (expected=%s, found=%s)`
+	msgErrorEmptyInput    string = `empty input`
+	msgErrorMixedTypes    string = `mixed types (expected=%s)`
+	msgErrorInvalidNumber string = `invalid number (value=%s)`
+
+	msgTypeNull                string = `null`
+	msgTypeNumber              string = `number`
+	msgTypeString              string = `string`
+	msgTypeObject              string = `object`
+	msgTypeArray               string = `array`
+	msgTypeNumberOrString      string = `number/string`
+	msgTypeStringOrArray       string = `string/array`
+	msgTypeStringArrayOrObject string = `string/array/object`
+	msgTypeScalar              string = `string/number/boolean/null`
+)
+
+func newErrorTypeUnmatched(expected string, found any) error {
+	if found == nil {
+		return fmt.Errorf(msgErrorTypeUnmatched, expected, msgTypeNull)
+	}
+	return fmt.Errorf(msgErrorTypeUnmatched, expected, reflect.TypeOf(found).String())
+}
+
+func toNumber(value any) (float64, bool) {
+	switch typedValue := value.(type) {
+	case float64:
+		return typedValue, true
+	case json.Number:
+		number, err := typedValue.Float64()
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// compareNumbers compares the numbers by their float64 values,
+// and by their exact values if the float64 values are equal, such as the large integers of json.Number.
+func compareNumbers(a any, aFloat float64, b any, bFloat float64) int {
+	if result := cmp.Compare(aFloat, bFloat); result != 0 {
+		return result
+	}
+	aRat, aOK := toRat(a)
+	bRat, bOK := toRat(b)
+	if !aOK || !bOK {
+		return 0
+	}
+	return aRat.Cmp(bRat)
+}
+
+func toRat(value any) (*big.Rat, bool) {
+	switch typedValue := value.(type) {
+	case float64:
+		if math.IsInf(typedValue, 0) || math.IsNaN(typedValue) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(typedValue), true
+	case json.Number:
+		return new(big.Rat).SetString(string(typedValue))
+	}
+	return nil, false
+}
+
+func toNumbers(values []any) ([]float64, error) {
+	numbers := make([]float64, len(values))
+	for index := range values {
+		number, ok := toNumber(values[index])
+		if !ok {
+			return nil, newErrorTypeUnmatched(msgTypeNumber, values[index])
+		}
+		numbers[index] = number
+	}
+	return numbers, nil
+}
+
+// normalize returns the value that can be compared with ==,
+// or false if the value is an object or an array.
+func normalize(value any) (any, bool) {
+	switch value.(type) {
+	case map[string]any, []any, *ordered.Object:
+		return nil, false
+	}
+	if number, ok := toNumber(value); ok {
+		return number, true
+	}
+	return value, true
+}
@@ -0,0 +1,28 @@
+package functions
+
+// StandardFilterFunctions is the collection of the standard filter functions.
+var StandardFilterFunctions = map[string]func(any) (any, error){
+	`keys`:     Keys,
+	`values`:   Values,
+	`length`:   Length,
+	`lower`:    Lower,
+	`upper`:    Upper,
+	`trim`:     Trim,
+	`toNumber`: ToNumber,
+	`toString`: ToString,
+}
+
+// StandardAggregateFunctions is the collection of the standard aggregate functions.
+var StandardAggregateFunctions = map[string]func([]any) (any, error){
+	`min`:      Min,
+	`max`:      Max,
+	`sum`:      Sum,
+	`avg`:      Avg,
+	`count`:    Count,
+	`first`:    First,
+	`last`:     Last,
+	`distinct`: Distinct,
+	`sort`:     Sort,
+	`reverse`:  Reverse,
+	`concat`:   Concat,
+}
@@ -3,6 +3,20 @@ package syntax
 import (
 	"sort"
 	"sync"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
+)
+
+// keyOrder is the order in which the members of the objects are retrieved.
+type keyOrder uint8
+
+const (
+	// keyOrderSorted retrieves the members in sorted order of their keys.
+	keyOrderSorted keyOrder = iota
+	// keyOrderDocument retrieves the members of ordered.Object in order of the document, and the others sorted.
+	keyOrderDocument
+	// keyOrderNone retrieves the members in the order of ranging over the map, without sorting the keys.
+	keyOrderNone
 )
 
 var sortSliceSyncPool = &sync.Pool{
@@ -16,7 +30,8 @@ var nodeSliceSyncPool = &sync.Pool{
 	},
 }
 
-func getSortedKeys(srcMap map[string]any) (*sort.StringSlice, int) {
+// getMapKeys returns the keys of the map, sorted unless the order is keyOrderNone.
+func getMapKeys(srcMap map[string]any, order keyOrder) (*sort.StringSlice, int) {
 	mapLength := len(srcMap)
 	sortKeys := sortSliceSyncPool.Get().(*sort.StringSlice)
 	if cap(*sortKeys) < mapLength {
@@ -28,7 +43,7 @@ func getSortedKeys(srcMap map[string]any) (*sort.StringSlice, int) {
 		(*sortKeys)[index] = key
 		index++
 	}
-	if mapLength > 1 {
+	if order != keyOrderNone && mapLength > 1 {
 		sort.Sort(sortKeys)
 	}
 	return sortKeys, mapLength
@@ -43,7 +58,7 @@ func getSortedRecursiveKeys(srcMap map[string]any) (*sort.StringSlice, int) {
 	*sortKeys = (*sortKeys)[:0]
 	for key, value := range srcMap {
 		switch value.(type) {
-		case map[string]any, []any:
+		case map[string]any, []any, *ordered.Object:
 			*sortKeys = append(*sortKeys, key)
 		}
 	}
@@ -54,6 +69,57 @@ func getSortedRecursiveKeys(srcMap map[string]any) (*sort.StringSlice, int) {
 	return sortKeys, keyLength
 }
 
+// getRecursiveKeys returns the members of the object, either map[string]any or *ordered.Object,
+// and the keys of the members that are objects or arrays.
+func getRecursiveKeys(object any, order keyOrder) (map[string]any, *sort.StringSlice, int) {
+	if orderedObject, ok := object.(*ordered.Object); ok {
+		sortKeys, keyLength := getOrderedRecursiveKeys(orderedObject, order)
+		return orderedObject.Map(), sortKeys, keyLength
+	}
+	srcMap := object.(map[string]any)
+	sortKeys, keyLength := getSortedRecursiveKeys(srcMap)
+	return srcMap, sortKeys, keyLength
+}
+
+// getOrderedKeys returns the keys of the object, sorted if the order is keyOrderSorted,
+// or in order of the document otherwise.
+func getOrderedKeys(object *ordered.Object, order keyOrder) (*sort.StringSlice, int) {
+	sortKeys := sortSliceSyncPool.Get().(*sort.StringSlice)
+	*sortKeys = append((*sortKeys)[:0], object.Keys()...)
+	keyLength := len(*sortKeys)
+	if order == keyOrderSorted && keyLength > 1 {
+		sort.Sort(sortKeys)
+	}
+	return sortKeys, keyLength
+}
+
+// getOrderedRecursiveKeys returns the keys of the members that are objects or arrays,
+// in the same order as getOrderedKeys.
+func getOrderedRecursiveKeys(object *ordered.Object, order keyOrder) (*sort.StringSlice, int) {
+	sortKeys := sortSliceSyncPool.Get().(*sort.StringSlice)
+	*sortKeys = (*sortKeys)[:0]
+	srcMap := object.Map()
+	for _, key := range object.Keys() {
+		switch srcMap[key].(type) {
+		case map[string]any, []any, *ordered.Object:
+			*sortKeys = append(*sortKeys, key)
+		}
+	}
+	keyLength := len(*sortKeys)
+	if order == keyOrderSorted && keyLength > 1 {
+		sort.Sort(sortKeys)
+	}
+	return sortKeys, keyLength
+}
+
+// getObjectMap returns the members of the object, either map[string]any or *ordered.Object.
+func getObjectMap(object any) map[string]any {
+	if orderedObject, ok := object.(*ordered.Object); ok {
+		return orderedObject.Map()
+	}
+	return object.(map[string]any)
+}
+
 func putSortSlice(sortKeys *sort.StringSlice) {
 	if sortKeys != nil {
 		sortSliceSyncPool.Put(sortKeys)
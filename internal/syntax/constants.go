@@ -7,10 +7,24 @@ const (
 	msgErrorInvalidSyntaxTwoCurrentNode    string = `comparison between two current nodes is prohibited`
 	msgErrorInvalidSyntaxFilterValueGroup  string = `JSONPath that returns a value group is prohibited`
 
-	msgTypeNull          string = `null`
-	msgTypeObject        string = `object`
-	msgTypeArray         string = `array`
-	msgTypeObjectOrArray string = `object/array`
+	msgErrorInvalidSyntaxFunctionArgumentValueGroup string = `function argument that returns a value group is prohibited`
+	msgErrorInvalidSyntaxFunctionArity              string = `wrong number of function arguments (expected=%d, found=%d)`
+	msgErrorInvalidSyntaxFunctionArgumentType       string = `function argument type unmatched (argument=%d, expected=%s)`
+	msgErrorInvalidSyntaxFunctionResultType         string = `function result type unmatched (expected=%s, found=%s)`
+
+	msgErrorFunctionNodesArgument string = `nodes argument type unmatched (expected=[]interface {}, found=%s)`
+	msgErrorFunctionResultType    string = `result type unmatched (expected=%s, found=%s)`
+
+	msgHintAssignment         string = `use '==' to compare the values`
+	msgHintMissingFilterMark  string = `write the filter as '[?(...)]'`
+	msgHintUnclosedQuote      string = `the string literal that starts at column %d is not closed`
+	msgHintTrailingDescendant string = `'..' must be followed by a member name, '*' or a bracketed selection`
+
+	msgTypeNull           string = `null`
+	msgTypeObject         string = `object`
+	msgTypeArray          string = `array`
+	msgTypeObjectOrArray  string = `object/array`
+	msgTypeLogicalOrNodes string = `logical/nodes`
 )
 
 type emptyEntityIdentifier struct{}
@@ -22,6 +36,9 @@ var emptyList = []any{emptyEntity}
 var fullEntity = fullEntityIdentifier{}
 var fullList = []any{fullEntity}
 
+// failedList is the values of the operand whose function failed, which matches nothing.
+var failedList = []any{}
+
 var literalParamTypes = map[reflect.Type]struct{}{
 	reflect.TypeOf(syntaxQueryParamLiteral{}):      {},
 	reflect.TypeOf(syntaxQueryParamRootNode{}):     {},
@@ -29,6 +46,9 @@ var literalParamTypes = map[reflect.Type]struct{}{
 }
 
 func isLiteralParam(v any) bool {
+	if function, ok := v.(*syntaxQueryParamFunction); ok {
+		return !function.isCurrentNodeDependent
+	}
 	t := reflect.TypeOf(v)
 	for t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -33,6 +33,10 @@ const (
 	rulechildSegment
 	rulebracketedSelection
 	rulefunction
+	rulefunctionCall
+	rulefunctionArguments
+	rulefunctionArgument
+	rulefunctionArgumentPath
 	rulefunctionName
 	rulememberNameShorthand
 	rulenameChars
@@ -64,6 +68,13 @@ const (
 	rulecomparator
 	ruleqParam
 	ruleqNumberOrStringParam
+	rulefunctionValue
+	rulefunctionTest
+	rulefunctionExpression
+	rulefunctionExpressionArguments
+	ruleemptyFunctionExpressionArguments
+	rulefunctionExpressionArgument
+	rulefunctionArgumentEnd
 	rulesingleJsonpathFilter
 	rulerootWithSegment
 	rulejsonpathFilter
@@ -82,6 +93,8 @@ const (
 	rulefilterSelectorEnd
 	rulesubQueryStart
 	rulesubQueryEnd
+	rulefunctionArgumentsStart
+	rulefunctionArgumentsEnd
 	rulespace
 	ruleAction0
 	rulePegText
@@ -129,6 +142,21 @@ const (
 	ruleAction42
 	ruleAction43
 	ruleAction44
+	ruleAction45
+	ruleAction46
+	ruleAction47
+	ruleAction48
+	ruleAction49
+	ruleAction50
+	ruleAction51
+	ruleAction52
+	ruleAction53
+	ruleAction54
+	ruleAction55
+	ruleAction56
+	ruleAction57
+	ruleAction58
+	ruleAction59
 )
 
 var rul3s = [...]string{
@@ -148,6 +176,10 @@ var rul3s = [...]string{
 	"childSegment",
 	"bracketedSelection",
 	"function",
+	"functionCall",
+	"functionArguments",
+	"functionArgument",
+	"functionArgumentPath",
 	"functionName",
 	"memberNameShorthand",
 	"nameChars",
@@ -179,6 +211,13 @@ var rul3s = [...]string{
 	"comparator",
 	"qParam",
 	"qNumberOrStringParam",
+	"functionValue",
+	"functionTest",
+	"functionExpression",
+	"functionExpressionArguments",
+	"emptyFunctionExpressionArguments",
+	"functionExpressionArgument",
+	"functionArgumentEnd",
 	"singleJsonpathFilter",
 	"rootWithSegment",
 	"jsonpathFilter",
@@ -197,6 +236,8 @@ var rul3s = [...]string{
 	"filterSelectorEnd",
 	"subQueryStart",
 	"subQueryEnd",
+	"functionArgumentsStart",
+	"functionArgumentsEnd",
 	"space",
 	"Action0",
 	"PegText",
@@ -244,6 +285,21 @@ var rul3s = [...]string{
 	"Action42",
 	"Action43",
 	"Action44",
+	"Action45",
+	"Action46",
+	"Action47",
+	"Action48",
+	"Action49",
+	"Action50",
+	"Action51",
+	"Action52",
+	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
+	"Action59",
 }
 
 type Uint interface {
@@ -368,7 +424,7 @@ type pegJSONPathParser[U Uint] struct {
 
 	Buffer         string
 	buffer         []rune
-	rules          [112]func() bool
+	rules          [140]func() bool
 	parse          func(rule ...int) error
 	reset          func()
 	Pretty         bool
@@ -484,8 +540,7 @@ func (p *pegJSONPathParser[_]) Execute() {
 
 		case ruleAction1:
 
-			panic(p.syntaxErr(
-				begin, msgErrorInvalidSyntaxUnrecognizedInput, buffer))
+			panic(p.unrecognizedInputErr(begin, buffer))
 
 		case ruleAction2:
 
@@ -518,31 +573,68 @@ func (p *pegJSONPathParser[_]) Execute() {
 
 		case ruleAction9:
 
-			p.pushFunction(text, p.pop().(string))
+			p.pushFunction(begin, buffer, text, p.pop().(string), nil)
 
 		case ruleAction10:
 
-			p.push(text)
+			arguments := p.pop().(syntaxFunctionArguments)
+			p.pushFunction(begin, buffer, text, p.pop().(string), arguments)
 
 		case ruleAction11:
 
-			p.pushChildSingleIdentifier(p.unescape(text))
+			p.push(syntaxFunctionArguments{p.pop().(syntaxFunctionArgument)})
 
 		case ruleAction12:
 
+			argument := p.pop().(syntaxFunctionArgument)
+			arguments := p.pop().(syntaxFunctionArguments)
+			p.push(append(arguments, argument))
+
+		case ruleAction13:
+
+			p.pushFunctionArgumentLiteral(p.pop())
+
+		case ruleAction14:
+
+			argument := p.pop().(*syntaxFunctionArgumentPath)
+			if argument.param.isValueGroup() {
+				panic(p.syntaxErr(
+					begin, msgErrorInvalidSyntaxFunctionArgumentValueGroup, buffer))
+			}
+			p.push(argument)
+
+		case ruleAction15:
+
+			p.saveParams()
+
+		case ruleAction16:
+
+			p.loadParams()
+			p.pushFunctionArgumentPath(p.pop().(syntaxNode))
+
+		case ruleAction17:
+
+			p.push(text)
+
+		case ruleAction18:
+
+			p.pushChildSingleIdentifier(p.unescape(text))
+
+		case ruleAction19:
+
 			identifier2 := p.pop().(syntaxNode)
 			identifier1 := p.pop().(syntaxNode)
 			p.pushChildMultiIdentifier(identifier1, identifier2)
 
-		case ruleAction13:
+		case ruleAction20:
 
 			p.pushChildWildcardIdentifier()
 
-		case ruleAction14:
+		case ruleAction21:
 
 			p.pushChildSingleIdentifier(p.pop().(string))
 
-		case ruleAction15:
+		case ruleAction22:
 
 			childIndexUnion := p.pop().(*syntaxUnionQualifier)
 			parentIndexUnion := p.pop().(*syntaxUnionQualifier)
@@ -550,7 +642,7 @@ func (p *pegJSONPathParser[_]) Execute() {
 			parentIndexUnion.setValueGroup()
 			p.push(parentIndexUnion)
 
-		case ruleAction16:
+		case ruleAction23:
 
 			step := p.pop().(*syntaxIndexSubscript)
 			end := p.pop().(*syntaxIndexSubscript)
@@ -566,43 +658,43 @@ func (p *pegJSONPathParser[_]) Execute() {
 				p.pushSliceNegativeStepSubscript(start, end, step)
 			}
 
-		case ruleAction17:
+		case ruleAction24:
 
 			p.pushWildcardSubscript()
 
-		case ruleAction18:
+		case ruleAction25:
 
 			p.pushUnionQualifier(p.pop().(syntaxSubscript))
 
-		case ruleAction19:
+		case ruleAction26:
 
 			p.pushOmittedIndexSubscript()
 
-		case ruleAction20:
+		case ruleAction27:
 
 			p.pushIndexSubscript(text)
 
-		case ruleAction21:
+		case ruleAction28:
 
 			p.pushScriptQualifier(text)
 
-		case ruleAction22:
+		case ruleAction29:
 
 			p.pushFilterQualifier(p.pop().(syntaxQuery))
 
-		case ruleAction23:
+		case ruleAction30:
 
 			rightQuery := p.pop().(syntaxQuery)
 			leftQuery := p.pop().(syntaxQuery)
 			p.pushLogicalOr(leftQuery, rightQuery)
 
-		case ruleAction24:
+		case ruleAction31:
 
 			rightQuery := p.pop().(syntaxQuery)
 			leftQuery := p.pop().(syntaxQuery)
 			p.pushLogicalAnd(leftQuery, rightQuery)
 
-		case ruleAction25:
+		case ruleAction32:
 
 			query := p.pop()
 			p.push(query)
@@ -612,15 +704,19 @@ func (p *pegJSONPathParser[_]) Execute() {
 			}
 			if checkQuery, ok := query.(*syntaxCompareQuery); ok {
 				leftIsCurrentNode := false
-				switch checkQuery.leftParam.(type) {
+				switch leftParam := checkQuery.leftParam.(type) {
 				case *syntaxQueryParamCurrentNode, *syntaxQueryParamCurrentNodePath:
 					leftIsCurrentNode = true
+				case *syntaxQueryParamFunction:
+					leftIsCurrentNode = leftParam.isCurrentNodeDependent
 				}
 
 				rightIsCurrentNode := false
-				switch checkQuery.rightParam.(type) {
+				switch rightParam := checkQuery.rightParam.(type) {
 				case *syntaxQueryParamCurrentNode, *syntaxQueryParamCurrentNodePath:
 					rightIsCurrentNode = true
+				case *syntaxQueryParamFunction:
+					rightIsCurrentNode = rightParam.isCurrentNodeDependent
 				}
 				if leftIsCurrentNode && rightIsCurrentNode {
 					panic(p.syntaxErr(
@@ -628,61 +724,101 @@ func (p *pegJSONPathParser[_]) Execute() {
 				}
 			}
 
-		case ruleAction26:
+		case ruleAction33:
+
+			functionTest := p.pop().(syntaxQuery)
+			p.pushLogicalNot(functionTest)
+
+		case ruleAction34:
 
 			jsonpathFilter := p.pop().(syntaxQuery)
 			p.pushLogicalNot(jsonpathFilter)
 
-		case ruleAction27:
+		case ruleAction35:
 
 			rightParam := p.pop().(syntaxCompareParameter)
 			leftParam := p.pop().(syntaxCompareParameter)
 			p.pushCompareEQ(leftParam, rightParam)
 
-		case ruleAction28:
+		case ruleAction36:
 
 			rightParam := p.pop().(syntaxCompareParameter)
 			leftParam := p.pop().(syntaxCompareParameter)
 			p.pushCompareNE(leftParam, rightParam)
 
-		case ruleAction29:
+		case ruleAction37:
 
 			rightParam := p.pop().(syntaxCompareParameter)
 			leftParam := p.pop().(syntaxCompareParameter)
 			p.pushCompareLE(leftParam, rightParam)
 
-		case ruleAction30:
+		case ruleAction38:
 
 			rightParam := p.pop().(syntaxCompareParameter)
 			leftParam := p.pop().(syntaxCompareParameter)
 			p.pushCompareLT(leftParam, rightParam)
 
-		case ruleAction31:
+		case ruleAction39:
 
 			rightParam := p.pop().(syntaxCompareParameter)
 			leftParam := p.pop().(syntaxCompareParameter)
 			p.pushCompareGE(leftParam, rightParam)
 
-		case ruleAction32:
+		case ruleAction40:
 
 			rightParam := p.pop().(syntaxCompareParameter)
 			leftParam := p.pop().(syntaxCompareParameter)
 			p.pushCompareGT(leftParam, rightParam)
 
-		case ruleAction33:
+		case ruleAction41:
 
 			leftParam := p.pop().(syntaxCompareParameter)
 			p.pushCompareRegex(leftParam, text)
 
-		case ruleAction34:
+		case ruleAction42:
 
 			p.pushCompareParameterLiteral(p.pop())
 
-		case ruleAction35:
+		case ruleAction43:
 
 			p.pushCompareParameterLiteral(p.pop())
 
-		case ruleAction36:
+		case ruleAction44:
+
+			function := p.pop().(*syntaxQueryParamFunction)
+			p.checkFunctionResultType(begin, buffer, function, false)
+			p.push(function)
+
+		case ruleAction45:
+
+			function := p.pop().(*syntaxQueryParamFunction)
+			p.checkFunctionResultType(begin, buffer, function, true)
+			p.pushFunctionTest(function)
+
+		case ruleAction46:
+
+			arguments := p.pop().([]any)
+			p.pushFunctionExpression(begin, buffer, text, p.pop().(string), arguments)
+
+		case ruleAction47:
+
+			p.push([]any{p.pop()})
+
+		case ruleAction48:
+
+			argument := p.pop()
+			arguments := p.pop().([]any)
+			p.push(append(arguments, argument))
+
+		case ruleAction49:
+
+			p.push([]any{})
+
+		case ruleAction50:
+
+			p.pushFunctionArgumentLiteral(p.pop())
+
+		case ruleAction51:
 
 			param := p.pop().(syntaxQueryJSONPathParameter)
 			if param.isValueGroupParameter() {
@@ -691,11 +827,11 @@ func (p *pegJSONPathParser[_]) Execute() {
 			}
 			p.push(param)
 
-		case ruleAction37:
+		case ruleAction52:
 
 			p.saveParams()
 
-		case ruleAction38:
+		case ruleAction53:
 
 			p.loadParams()
 
@@ -712,27 +848,27 @@ func (p *pegJSONPathParser[_]) Execute() {
 				p.pushCompareParameterCurrentNode(p.deleteRootNodeIdentifier(node))
 			}
 
-		case ruleAction39:
+		case ruleAction54:
 
 			p.push(p.toFloat(text))
 
-		case ruleAction40:
+		case ruleAction55:
 
 			p.push(true)
 
-		case ruleAction41:
+		case ruleAction56:
 
 			p.push(false)
 
-		case ruleAction42:
+		case ruleAction57:
 
 			p.push(p.unescapeSingleQuotedString(text))
 
-		case ruleAction43:
+		case ruleAction58:
 
 			p.push(p.unescapeDoubleQuotedString(text))
 
-		case ruleAction44:
+		case ruleAction59:
 
 			p.push(nil)
 
@@ -860,7 +996,9 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 	_rules = [...]func() bool{
 		nil,
 
-		/* 0 expression <- <(((jsonpathQuery / rootlessQuery) END Action0) / ((jsonpathQuery / rootlessQuery)? <.*> Action1))> */
+		/* 0 expression <- <(((jsonpathQuery / rootlessQuery) END Action0) / ((jsonpathQuery / rootlessQuery)? !{
+		    p.setFarthestFailure(int(maxToken.end), getExpectedTokens(memoization, maxToken.end))
+		} <.*> Action1))> */
 		func() bool {
 			if memoized, ok := memoization[memoKey[U]{0, position}]; ok {
 				return memoizedResult(memoized)
@@ -922,6 +1060,9 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 						position, tokenIndex = position9, tokenIndex9
 					}
 				l10:
+
+					p.setFarthestFailure(int(maxToken.end), getExpectedTokens(memoization, maxToken.end))
+
 					{
 						position13 := position
 					l14:
@@ -1010,29 +1151,63 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 			return false
 		},
 		/* 4 jsonpathParameter <- <(space parameterRootNode segments)> */
-		nil,
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{4, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position26, tokenIndex26 := position, tokenIndex
+			{
+				position27 := position
+				_rules[rulespace]()
+				{
+					position28 := position
+					{
+						position29, tokenIndex29 := position, tokenIndex
+						if !_rules[rulerootIdentifier]() {
+							goto l30
+						}
+						goto l29
+					l30:
+						position, tokenIndex = position29, tokenIndex29
+						if !_rules[rulecurrentNodeIdentifier]() {
+							goto l26
+						}
+					}
+				l29:
+					add(ruleparameterRootNode, position28)
+				}
+				_rules[rulesegments]()
+				add(rulejsonpathParameter, position27)
+			}
+			memoize(4, position26, tokenIndex26, true)
+			return true
+		l26:
+			memoize(4, position26, tokenIndex26, false)
+			position, tokenIndex = position26, tokenIndex26
+			return false
+		},
 		/* 5 rootIdentifier <- <('$' Action2)> */
 		func() bool {
 			if memoized, ok := memoization[memoKey[U]{5, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position27, tokenIndex27 := position, tokenIndex
+			position31, tokenIndex31 := position, tokenIndex
 			{
-				position28 := position
+				position32 := position
 				if buffer[position] != '$' {
-					goto l27
+					goto l31
 				}
 				position++
 				{
 					add(ruleAction2, position)
 				}
-				add(rulerootIdentifier, position28)
+				add(rulerootIdentifier, position32)
 			}
-			memoize(5, position27, tokenIndex27, true)
+			memoize(5, position31, tokenIndex31, true)
 			return true
-		l27:
-			memoize(5, position27, tokenIndex27, false)
-			position, tokenIndex = position27, tokenIndex27
+		l31:
+			memoize(5, position31, tokenIndex31, false)
+			position, tokenIndex = position31, tokenIndex31
 			return false
 		},
 		/* 6 rootlessNode <- <(Action3 (segment / memberNameShorthand))> */
@@ -1044,23 +1219,23 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 			if memoized, ok := memoization[memoKey[U]{8, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position32, tokenIndex32 := position, tokenIndex
+			position36, tokenIndex36 := position, tokenIndex
 			{
-				position33 := position
+				position37 := position
 				if buffer[position] != '@' {
-					goto l32
+					goto l36
 				}
 				position++
 				{
 					add(ruleAction4, position)
 				}
-				add(rulecurrentNodeIdentifier, position33)
+				add(rulecurrentNodeIdentifier, position37)
 			}
-			memoize(8, position32, tokenIndex32, true)
+			memoize(8, position36, tokenIndex36, true)
 			return true
-		l32:
-			memoize(8, position32, tokenIndex32, false)
-			position, tokenIndex = position32, tokenIndex32
+		l36:
+			memoize(8, position36, tokenIndex36, false)
+			position, tokenIndex = position36, tokenIndex36
 			return false
 		},
 		/* 9 segments <- <(segment* function* space Action5)> */
@@ -1068,36 +1243,36 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 			if memoized, ok := memoization[memoKey[U]{9, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position35, tokenIndex35 := position, tokenIndex
+			position39, tokenIndex39 := position, tokenIndex
 			{
-				position36 := position
-			l37:
+				position40 := position
+			l41:
 				{
-					position38, tokenIndex38 := position, tokenIndex
+					position42, tokenIndex42 := position, tokenIndex
 					if !_rules[rulesegment]() {
-						goto l38
+						goto l42
 					}
-					goto l37
-				l38:
-					position, tokenIndex = position38, tokenIndex38
+					goto l41
+				l42:
+					position, tokenIndex = position42, tokenIndex42
 				}
-			l39:
+			l43:
 				{
-					position40, tokenIndex40 := position, tokenIndex
+					position44, tokenIndex44 := position, tokenIndex
 					if !_rules[rulefunction]() {
-						goto l40
+						goto l44
 					}
-					goto l39
-				l40:
-					position, tokenIndex = position40, tokenIndex40
+					goto l43
+				l44:
+					position, tokenIndex = position44, tokenIndex44
 				}
 				_rules[rulespace]()
 				{
 					add(ruleAction5, position)
 				}
-				add(rulesegments, position36)
+				add(rulesegments, position40)
 			}
-			memoize(9, position35, tokenIndex35, true)
+			memoize(9, position39, tokenIndex39, true)
 			return true
 		},
 		/* 10 segment <- <(descendantSegment / childSegment)> */
@@ -1105,79 +1280,79 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 			if memoized, ok := memoization[memoKey[U]{10, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position42, tokenIndex42 := position, tokenIndex
+			position46, tokenIndex46 := position, tokenIndex
 			{
-				position43 := position
+				position47 := position
 				{
-					position44, tokenIndex44 := position, tokenIndex
+					position48, tokenIndex48 := position, tokenIndex
 					{
-						position46 := position
+						position50 := position
 						if buffer[position] != '.' {
-							goto l45
+							goto l49
 						}
 						position++
 						if buffer[position] != '.' {
-							goto l45
+							goto l49
 						}
 						position++
 						{
-							position47, tokenIndex47 := position, tokenIndex
+							position51, tokenIndex51 := position, tokenIndex
 							if !_rules[rulebracketedSelection]() {
-								goto l48
+								goto l52
 							}
-							goto l47
-						l48:
-							position, tokenIndex = position47, tokenIndex47
+							goto l51
+						l52:
+							position, tokenIndex = position51, tokenIndex51
 							if !_rules[rulememberNameShorthand]() {
-								goto l45
+								goto l49
 							}
 						}
-					l47:
+					l51:
 						{
 							add(ruleAction6, position)
 						}
-						add(ruledescendantSegment, position46)
+						add(ruledescendantSegment, position50)
 					}
-					goto l44
-				l45:
-					position, tokenIndex = position44, tokenIndex44
+					goto l48
+				l49:
+					position, tokenIndex = position48, tokenIndex48
 					{
-						position50 := position
+						position54 := position
 						{
-							position51, tokenIndex51 := position, tokenIndex
+							position55, tokenIndex55 := position, tokenIndex
 							{
-								position53 := position
+								position57 := position
 								if buffer[position] != '.' {
-									goto l52
+									goto l56
 								}
 								position++
 								if !_rules[rulememberNameShorthand]() {
-									goto l52
+									goto l56
 								}
-								add(rulePegText, position53)
+								add(rulePegText, position57)
 							}
 							{
 								add(ruleAction7, position)
 							}
-							goto l51
-						l52:
-							position, tokenIndex = position51, tokenIndex51
+							goto l55
+						l56:
+							position, tokenIndex = position55, tokenIndex55
 							if !_rules[rulebracketedSelection]() {
-								goto l42
+								goto l46
 							}
 						}
-					l51:
-						add(rulechildSegment, position50)
+					l55:
+						add(rulechildSegment, position54)
 					}
 				}
-			l44:
-				add(rulesegment, position43)
+			l48:
+				add(rulesegment, position47)
 			}
-			memoize(10, position42, tokenIndex42, true)
+			memoize(10, position46, tokenIndex46, true)
 			return true
-		l42:
-			memoize(10, position42, tokenIndex42, false)
-			position, tokenIndex = position42, tokenIndex42
+		l46:
+			memoize(10, position46, tokenIndex46, false)
+			position, tokenIndex = position46, tokenIndex46
 			return false
 		},
 		/* 11 descendantSegment <- <('.' '.' (bracketedSelection / memberNameShorthand) Action6)> */
@@ -1189,439 +1364,628 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 			if memoized, ok := memoization[memoKey[U]{13, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position57, tokenIndex57 := position, tokenIndex
+			position61, tokenIndex61 := position, tokenIndex
 			{
-				position58 := position
+				position62 := position
 				{
-					position59 := position
+					position63 := position
 					{
-						position60 := position
+						position64 := position
 						if buffer[position] != '[' {
-							goto l57
+							goto l61
 						}
 						position++
 						_rules[rulespace]()
-						add(rulesquareBracketStart, position60)
+						add(rulesquareBracketStart, position64)
 					}
 					{
-						position61 := position
+						position65 := position
 						{
-							position62, tokenIndex62 := position, tokenIndex
+							position66, tokenIndex66 := position, tokenIndex
 							{
-								position64 := position
+								position68 := position
 								if !_rules[ruleobjectElementSelector]() {
-									goto l63
+									goto l67
 								}
-							l65:
+							l69:
 								{
-									position66, tokenIndex66 := position, tokenIndex
+									position70, tokenIndex70 := position, tokenIndex
 									if !_rules[rulesep]() {
-										goto l66
+										goto l70
 									}
 									if !_rules[ruleobjectElementSelector]() {
-										goto l66
+										goto l70
 									}
 									{
-										add(ruleAction12, position)
+										add(ruleAction19, position)
 									}
-									goto l65
-								l66:
-									position, tokenIndex = position66, tokenIndex66
+									goto l69
+								l70:
+									position, tokenIndex = position70, tokenIndex70
 								}
 								{
-									position68, tokenIndex68 := position, tokenIndex
+									position72, tokenIndex72 := position, tokenIndex
 									if !_rules[rulesep]() {
-										goto l68
+										goto l72
 									}
-									goto l63
-								l68:
-									position, tokenIndex = position68, tokenIndex68
+									goto l67
+								l72:
+									position, tokenIndex = position72, tokenIndex72
 								}
-								add(ruleobjectElementSelectors, position64)
+								add(ruleobjectElementSelectors, position68)
 							}
-							goto l62
-						l63:
-							position, tokenIndex = position62, tokenIndex62
+							goto l66
+						l67:
+							position, tokenIndex = position66, tokenIndex66
 							{
 								switch buffer[position] {
 								case '(':
 									{
-										position70 := position
+										position74 := position
 										{
-											position71 := position
+											position75 := position
 											position++
 											_rules[rulespace]()
-											add(rulescriptSelectorStart, position71)
+											add(rulescriptSelectorStart, position75)
 										}
 										{
-											position72 := position
+											position76 := position
 											{
-												position73 := position
+												position77 := position
 												{
-													position76, tokenIndex76 := position, tokenIndex
+													position80, tokenIndex80 := position, tokenIndex
 													if !_rules[rulescriptSelectorEnd]() {
-														goto l76
+														goto l80
 													}
-													goto l57
-												l76:
-													position, tokenIndex = position76, tokenIndex76
+													goto l61
+												l80:
+													position, tokenIndex = position80, tokenIndex80
 												}
 												if !matchDot() {
-													goto l57
+													goto l61
 												}
-											l74:
+											l78:
 												{
-													position75, tokenIndex75 := position, tokenIndex
+													position79, tokenIndex79 := position, tokenIndex
 													{
-														position77, tokenIndex77 := position, tokenIndex
+														position81, tokenIndex81 := position, tokenIndex
 														if !_rules[rulescriptSelectorEnd]() {
-															goto l77
+															goto l81
 														}
-														goto l75
-													l77:
-														position, tokenIndex = position77, tokenIndex77
+														goto l79
+													l81:
+														position, tokenIndex = position81, tokenIndex81
 													}
 													if !matchDot() {
-														goto l75
+														goto l79
 													}
-													goto l74
-												l75:
-													position, tokenIndex = position75, tokenIndex75
+													goto l78
+												l79:
+													position, tokenIndex = position79, tokenIndex79
 												}
-												add(rulecommand, position73)
+												add(rulecommand, position77)
 											}
-											add(rulePegText, position72)
+											add(rulePegText, position76)
 										}
 										if !_rules[rulescriptSelectorEnd]() {
-											goto l57
+											goto l61
 										}
 										{
-											add(ruleAction21, position)
+											add(ruleAction28, position)
 										}
-										add(rulescriptSelector, position70)
+										add(rulescriptSelector, position74)
 									}
 								case '?':
 									{
-										position79 := position
+										position83 := position
 										{
-											position80 := position
+											position84 := position
 											position++
 											if buffer[position] != '(' {
-												goto l57
+												goto l61
 											}
 											position++
 											_rules[rulespace]()
-											add(rulefilterSelectorStart, position80)
+											add(rulefilterSelectorStart, position84)
 										}
 										if !_rules[rulequery]() {
-											goto l57
+											goto l61
 										}
 										{
-											position81 := position
+											position85 := position
 											_rules[rulespace]()
 											if buffer[position] != ')' {
-												goto l57
+												goto l61
 											}
 											position++
-											add(rulefilterSelectorEnd, position81)
+											add(rulefilterSelectorEnd, position85)
 										}
 										{
-											add(ruleAction22, position)
+											add(ruleAction29, position)
 										}
-										add(rulefilterSelector, position79)
+										add(rulefilterSelector, position83)
 									}
 								default:
 									{
-										position83 := position
+										position87 := position
 										if !_rules[rulearrayElementSelector]() {
-											goto l57
+											goto l61
 										}
-									l84:
+									l88:
 										{
-											position85, tokenIndex85 := position, tokenIndex
+											position89, tokenIndex89 := position, tokenIndex
 											if !_rules[rulesep]() {
-												goto l85
+												goto l89
 											}
 											if !_rules[rulearrayElementSelector]() {
-												goto l85
+												goto l89
 											}
 											{
-												add(ruleAction15, position)
+												add(ruleAction22, position)
 											}
-											goto l84
-										l85:
-											position, tokenIndex = position85, tokenIndex85
+											goto l88
+										l89:
+											position, tokenIndex = position89, tokenIndex89
 										}
 										{
-											position87, tokenIndex87 := position, tokenIndex
+											position91, tokenIndex91 := position, tokenIndex
 											if !_rules[rulesep]() {
-												goto l87
+												goto l91
 											}
-											goto l57
-										l87:
-											position, tokenIndex = position87, tokenIndex87
+											goto l61
+										l91:
+											position, tokenIndex = position91, tokenIndex91
 										}
-										add(rulearrayElementSelectors, position83)
+										add(rulearrayElementSelectors, position87)
 									}
 								}
 							}
 
 						}
-					l62:
-						add(ruleselectors, position61)
+					l66:
+						add(ruleselectors, position65)
 					}
 					{
-						position88 := position
+						position92 := position
 						_rules[rulespace]()
 						if buffer[position] != ']' {
-							goto l57
+							goto l61
 						}
 						position++
-						add(rulesquareBracketEnd, position88)
+						add(rulesquareBracketEnd, position92)
 					}
-					add(rulePegText, position59)
+					add(rulePegText, position63)
 				}
 				{
 					add(ruleAction8, position)
 				}
-				add(rulebracketedSelection, position58)
+				add(rulebracketedSelection, position62)
 			}
-			memoize(13, position57, tokenIndex57, true)
+			memoize(13, position61, tokenIndex61, true)
 			return true
-		l57:
-			memoize(13, position57, tokenIndex57, false)
-			position, tokenIndex = position57, tokenIndex57
+		l61:
+			memoize(13, position61, tokenIndex61, false)
+			position, tokenIndex = position61, tokenIndex61
 			return false
 		},
-		/* 14 function <- <(<('.' functionName ('(' ')'))> Action9)> */
+		/* 14 function <- <((<('.' functionName ('(' ')'))> Action9) / (<('.' functionName functionArgumentsStart functionArguments functionArgumentsEnd)> Action10))> */
 		func() bool {
 			if memoized, ok := memoization[memoKey[U]{14, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position90, tokenIndex90 := position, tokenIndex
+			position94, tokenIndex94 := position, tokenIndex
 			{
-				position91 := position
+				position95 := position
 				{
-					position92 := position
-					if buffer[position] != '.' {
-						goto l90
+					position96, tokenIndex96 := position, tokenIndex
+					{
+						position98 := position
+						if buffer[position] != '.' {
+							goto l97
+						}
+						position++
+						if !_rules[rulefunctionName]() {
+							goto l97
+						}
+						if buffer[position] != '(' {
+							goto l97
+						}
+						position++
+						if buffer[position] != ')' {
+							goto l97
+						}
+						position++
+						add(rulePegText, position98)
 					}
-					position++
 					{
-						position93 := position
-						{
-							position94 := position
-							if !_rules[rulenameChars]() {
-								goto l90
+						add(ruleAction9, position)
+					}
+					goto l96
+				l97:
+					position, tokenIndex = position96, tokenIndex96
+					{
+						position100 := position
+						if buffer[position] != '.' {
+							goto l94
+						}
+						position++
+						if !_rules[rulefunctionName]() {
+							goto l94
+						}
+						if !_rules[rulefunctionArgumentsStart]() {
+							goto l94
+						}
+						if !_rules[rulefunctionArguments]() {
+							goto l94
+						}
+						if !_rules[rulefunctionArgumentsEnd]() {
+							goto l94
+						}
+						add(rulePegText, position100)
+					}
+					{
+						add(ruleAction10, position)
+					}
+				}
+			l96:
+				add(rulefunction, position95)
+			}
+			memoize(14, position94, tokenIndex94, true)
+			return true
+		l94:
+			memoize(14, position94, tokenIndex94, false)
+			position, tokenIndex = position94, tokenIndex94
+			return false
+		},
+		/* 15 functionCall <- <(('(' ')') / (functionArgumentsStart functionArguments functionArgumentsEnd))> */
+		nil,
+		/* 16 functionArguments <- <(functionArgument Action11 (sep functionArgument Action12)*)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{16, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position103, tokenIndex103 := position, tokenIndex
+			{
+				position104 := position
+				if !_rules[rulefunctionArgument]() {
+					goto l103
+				}
+				{
+					add(ruleAction11, position)
+				}
+			l106:
+				{
+					position107, tokenIndex107 := position, tokenIndex
+					if !_rules[rulesep]() {
+						goto l107
+					}
+					if !_rules[rulefunctionArgument]() {
+						goto l107
+					}
+					{
+						add(ruleAction12, position)
+					}
+					goto l106
+				l107:
+					position, tokenIndex = position107, tokenIndex107
+				}
+				add(rulefunctionArguments, position104)
+			}
+			memoize(16, position103, tokenIndex103, true)
+			return true
+		l103:
+			memoize(16, position103, tokenIndex103, false)
+			position, tokenIndex = position103, tokenIndex103
+			return false
+		},
+		/* 17 functionArgument <- <((((&('N' | 'n') lNull) | (&('"' | '\'') lString) | (&('F' | 'T' | 'f' | 't') lBool) | (&('+' | '-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') lNumber)) Action13) / (<functionArgumentPath> Action14))> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{17, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position109, tokenIndex109 := position, tokenIndex
+			{
+				position110 := position
+				{
+					position111, tokenIndex111 := position, tokenIndex
+					{
+						switch buffer[position] {
+						case 'N', 'n':
+							if !_rules[rulelNull]() {
+								goto l112
 							}
-						l95:
-							{
-								position96, tokenIndex96 := position, tokenIndex
-								if !_rules[rulenameChars]() {
-									goto l96
-								}
-								goto l95
-							l96:
-								position, tokenIndex = position96, tokenIndex96
+						case '"', '\'':
+							if !_rules[rulelString]() {
+								goto l112
+							}
+						case 'F', 'T', 'f', 't':
+							if !_rules[rulelBool]() {
+								goto l112
+							}
+						default:
+							if !_rules[rulelNumber]() {
+								goto l112
 							}
-							add(rulePegText, position94)
 						}
-						{
-							add(ruleAction10, position)
+					}
+
+					{
+						add(ruleAction13, position)
+					}
+					goto l111
+				l112:
+					position, tokenIndex = position111, tokenIndex111
+					{
+						position115 := position
+						if !_rules[rulefunctionArgumentPath]() {
+							goto l109
 						}
-						add(rulefunctionName, position93)
+						add(rulePegText, position115)
 					}
-					if buffer[position] != '(' {
-						goto l90
+					{
+						add(ruleAction14, position)
 					}
-					position++
-					if buffer[position] != ')' {
-						goto l90
+				}
+			l111:
+				add(rulefunctionArgument, position110)
+			}
+			memoize(17, position109, tokenIndex109, true)
+			return true
+		l109:
+			memoize(17, position109, tokenIndex109, false)
+			position, tokenIndex = position109, tokenIndex109
+			return false
+		},
+		/* 18 functionArgumentPath <- <(Action15 jsonpathParameter Action16)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{18, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position117, tokenIndex117 := position, tokenIndex
+			{
+				position118 := position
+				{
+					add(ruleAction15, position)
+				}
+				if !_rules[rulejsonpathParameter]() {
+					goto l117
+				}
+				{
+					add(ruleAction16, position)
+				}
+				add(rulefunctionArgumentPath, position118)
+			}
+			memoize(18, position117, tokenIndex117, true)
+			return true
+		l117:
+			memoize(18, position117, tokenIndex117, false)
+			position, tokenIndex = position117, tokenIndex117
+			return false
+		},
+		/* 19 functionName <- <(<nameChars+> Action17)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{19, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position121, tokenIndex121 := position, tokenIndex
+			{
+				position122 := position
+				{
+					position123 := position
+					if !_rules[rulenameChars]() {
+						goto l121
 					}
-					position++
-					add(rulePegText, position92)
+				l124:
+					{
+						position125, tokenIndex125 := position, tokenIndex
+						if !_rules[rulenameChars]() {
+							goto l125
+						}
+						goto l124
+					l125:
+						position, tokenIndex = position125, tokenIndex125
+					}
+					add(rulePegText, position123)
 				}
 				{
-					add(ruleAction9, position)
+					add(ruleAction17, position)
 				}
-				add(rulefunction, position91)
+				add(rulefunctionName, position122)
 			}
-			memoize(14, position90, tokenIndex90, true)
+			memoize(19, position121, tokenIndex121, true)
 			return true
-		l90:
-			memoize(14, position90, tokenIndex90, false)
-			position, tokenIndex = position90, tokenIndex90
+		l121:
+			memoize(19, position121, tokenIndex121, false)
+			position, tokenIndex = position121, tokenIndex121
 			return false
 		},
-		/* 15 functionName <- <(<nameChars+> Action10)> */
-		nil,
-		/* 16 memberNameShorthand <- <(wildcardSelector / (<(('\\' signsWithoutHyphenUnderscore) / (!(controlCodeChars / signsWithoutHyphenUnderscore) .))+> !('(' ')') Action11))> */
+		/* 20 memberNameShorthand <- <(wildcardSelector / (<(('\\' signsWithoutHyphenUnderscore) / (!(controlCodeChars / signsWithoutHyphenUnderscore) .))+> !functionCall Action18))> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{16, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{20, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position100, tokenIndex100 := position, tokenIndex
+			position127, tokenIndex127 := position, tokenIndex
 			{
-				position101 := position
+				position128 := position
 				{
-					position102, tokenIndex102 := position, tokenIndex
+					position129, tokenIndex129 := position, tokenIndex
 					if !_rules[rulewildcardSelector]() {
-						goto l103
+						goto l130
 					}
-					goto l102
-				l103:
-					position, tokenIndex = position102, tokenIndex102
+					goto l129
+				l130:
+					position, tokenIndex = position129, tokenIndex129
 					{
-						position104 := position
+						position131 := position
 						{
-							position107, tokenIndex107 := position, tokenIndex
+							position134, tokenIndex134 := position, tokenIndex
 							if buffer[position] != '\\' {
-								goto l108
+								goto l135
 							}
 							position++
 							if !_rules[rulesignsWithoutHyphenUnderscore]() {
-								goto l108
+								goto l135
 							}
-							goto l107
-						l108:
-							position, tokenIndex = position107, tokenIndex107
+							goto l134
+						l135:
+							position, tokenIndex = position134, tokenIndex134
 							{
-								position109, tokenIndex109 := position, tokenIndex
+								position136, tokenIndex136 := position, tokenIndex
 								{
-									position110, tokenIndex110 := position, tokenIndex
+									position137, tokenIndex137 := position, tokenIndex
 									{
-										position112 := position
+										position139 := position
 										{
-											position113, tokenIndex113 := position, tokenIndex
+											position140, tokenIndex140 := position, tokenIndex
 											if c := buffer[position]; c < '\x00' || c > '\x1f' {
-												goto l114
+												goto l141
 											}
 											position++
-											goto l113
-										l114:
-											position, tokenIndex = position113, tokenIndex113
+											goto l140
+										l141:
+											position, tokenIndex = position140, tokenIndex140
 											if buffer[position] != '\x7f' {
-												goto l111
+												goto l138
 											}
 											position++
 										}
-									l113:
-										add(rulecontrolCodeChars, position112)
+									l140:
+										add(rulecontrolCodeChars, position139)
 									}
-									goto l110
-								l111:
-									position, tokenIndex = position110, tokenIndex110
+									goto l137
+								l138:
+									position, tokenIndex = position137, tokenIndex137
 									if !_rules[rulesignsWithoutHyphenUnderscore]() {
-										goto l109
+										goto l136
 									}
 								}
-							l110:
-								goto l100
-							l109:
-								position, tokenIndex = position109, tokenIndex109
+							l137:
+								goto l127
+							l136:
+								position, tokenIndex = position136, tokenIndex136
 							}
 							if !matchDot() {
-								goto l100
+								goto l127
 							}
 						}
-					l107:
-					l105:
+					l134:
+					l132:
 						{
-							position106, tokenIndex106 := position, tokenIndex
+							position133, tokenIndex133 := position, tokenIndex
 							{
-								position115, tokenIndex115 := position, tokenIndex
+								position142, tokenIndex142 := position, tokenIndex
 								if buffer[position] != '\\' {
-									goto l116
+									goto l143
 								}
 								position++
 								if !_rules[rulesignsWithoutHyphenUnderscore]() {
-									goto l116
+									goto l143
 								}
-								goto l115
-							l116:
-								position, tokenIndex = position115, tokenIndex115
+								goto l142
+							l143:
+								position, tokenIndex = position142, tokenIndex142
 								{
-									position117, tokenIndex117 := position, tokenIndex
+									position144, tokenIndex144 := position, tokenIndex
 									{
-										position118, tokenIndex118 := position, tokenIndex
+										position145, tokenIndex145 := position, tokenIndex
 										{
-											position120 := position
+											position147 := position
 											{
-												position121, tokenIndex121 := position, tokenIndex
+												position148, tokenIndex148 := position, tokenIndex
 												if c := buffer[position]; c < '\x00' || c > '\x1f' {
-													goto l122
+													goto l149
 												}
 												position++
-												goto l121
-											l122:
-												position, tokenIndex = position121, tokenIndex121
+												goto l148
+											l149:
+												position, tokenIndex = position148, tokenIndex148
 												if buffer[position] != '\x7f' {
-													goto l119
+													goto l146
 												}
 												position++
 											}
-										l121:
-											add(rulecontrolCodeChars, position120)
+										l148:
+											add(rulecontrolCodeChars, position147)
 										}
-										goto l118
-									l119:
-										position, tokenIndex = position118, tokenIndex118
+										goto l145
+									l146:
+										position, tokenIndex = position145, tokenIndex145
 										if !_rules[rulesignsWithoutHyphenUnderscore]() {
-											goto l117
+											goto l144
 										}
 									}
-								l118:
-									goto l106
-								l117:
-									position, tokenIndex = position117, tokenIndex117
+								l145:
+									goto l133
+								l144:
+									position, tokenIndex = position144, tokenIndex144
 								}
 								if !matchDot() {
-									goto l106
+									goto l133
 								}
 							}
-						l115:
-							goto l105
-						l106:
-							position, tokenIndex = position106, tokenIndex106
+						l142:
+							goto l132
+						l133:
+							position, tokenIndex = position133, tokenIndex133
 						}
-						add(rulePegText, position104)
+						add(rulePegText, position131)
 					}
 					{
-						position123, tokenIndex123 := position, tokenIndex
-						if buffer[position] != '(' {
-							goto l123
-						}
-						position++
-						if buffer[position] != ')' {
-							goto l123
-						}
-						position++
-						goto l100
-					l123:
-						position, tokenIndex = position123, tokenIndex123
+						position150, tokenIndex150 := position, tokenIndex
+						{
+							position151 := position
+							{
+								position152, tokenIndex152 := position, tokenIndex
+								if buffer[position] != '(' {
+									goto l153
+								}
+								position++
+								if buffer[position] != ')' {
+									goto l153
+								}
+								position++
+								goto l152
+							l153:
+								position, tokenIndex = position152, tokenIndex152
+								if !_rules[rulefunctionArgumentsStart]() {
+									goto l150
+								}
+								if !_rules[rulefunctionArguments]() {
+									goto l150
+								}
+								if !_rules[rulefunctionArgumentsEnd]() {
+									goto l150
+								}
+							}
+						l152:
+							add(rulefunctionCall, position151)
+						}
+						goto l127
+					l150:
+						position, tokenIndex = position150, tokenIndex150
 					}
 					{
-						add(ruleAction11, position)
+						add(ruleAction18, position)
 					}
 				}
-			l102:
-				add(rulememberNameShorthand, position101)
+			l129:
+				add(rulememberNameShorthand, position128)
 			}
-			memoize(16, position100, tokenIndex100, true)
+			memoize(20, position127, tokenIndex127, true)
 			return true
-		l100:
-			memoize(16, position100, tokenIndex100, false)
-			position, tokenIndex = position100, tokenIndex100
+		l127:
+			memoize(20, position127, tokenIndex127, false)
+			position, tokenIndex = position127, tokenIndex127
 			return false
 		},
-		/* 17 nameChars <- <((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('_') '_') | (&('-') '-') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))> */
+		/* 21 nameChars <- <((&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('_') '_') | (&('-') '-') | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{17, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{21, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position125, tokenIndex125 := position, tokenIndex
+			position155, tokenIndex155 := position, tokenIndex
 			{
-				position126 := position
+				position156 := position
 				{
 					switch buffer[position] {
 					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
@@ -1634,1168 +1998,1247 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 						position++
 					default:
 						if c := buffer[position]; c < 'a' || c > 'z' {
-							goto l125
+							goto l155
 						}
 						position++
 					}
 				}
 
-				add(rulenameChars, position126)
+				add(rulenameChars, position156)
 			}
-			memoize(17, position125, tokenIndex125, true)
+			memoize(21, position155, tokenIndex155, true)
 			return true
-		l125:
-			memoize(17, position125, tokenIndex125, false)
-			position, tokenIndex = position125, tokenIndex125
+		l155:
+			memoize(21, position155, tokenIndex155, false)
+			position, tokenIndex = position155, tokenIndex155
 			return false
 		},
-		/* 18 signsWithoutHyphenUnderscore <- <(!nameChars [ -~])> */
+		/* 22 signsWithoutHyphenUnderscore <- <(!nameChars [ -~])> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{18, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{22, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position128, tokenIndex128 := position, tokenIndex
+			position158, tokenIndex158 := position, tokenIndex
 			{
-				position129 := position
+				position159 := position
 				{
-					position130, tokenIndex130 := position, tokenIndex
+					position160, tokenIndex160 := position, tokenIndex
 					if !_rules[rulenameChars]() {
-						goto l130
+						goto l160
 					}
-					goto l128
-				l130:
-					position, tokenIndex = position130, tokenIndex130
+					goto l158
+				l160:
+					position, tokenIndex = position160, tokenIndex160
 				}
 				if c := buffer[position]; c < ' ' || c > '~' {
-					goto l128
+					goto l158
 				}
 				position++
-				add(rulesignsWithoutHyphenUnderscore, position129)
+				add(rulesignsWithoutHyphenUnderscore, position159)
 			}
-			memoize(18, position128, tokenIndex128, true)
+			memoize(22, position158, tokenIndex158, true)
 			return true
-		l128:
-			memoize(18, position128, tokenIndex128, false)
-			position, tokenIndex = position128, tokenIndex128
+		l158:
+			memoize(22, position158, tokenIndex158, false)
+			position, tokenIndex = position158, tokenIndex158
 			return false
 		},
-		/* 19 controlCodeChars <- <([\x00-\x1f] / '\x7f')> */
+		/* 23 controlCodeChars <- <([\x00-\x1f] / '\x7f')> */
 		nil,
-		/* 20 selectors <- <(objectElementSelectors / ((&('(') scriptSelector) | (&('?') filterSelector) | (&(' ' | '*' | '+' | '-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | ':') arrayElementSelectors)))> */
+		/* 24 selectors <- <(objectElementSelectors / ((&('(') scriptSelector) | (&('?') filterSelector) | (&(' ' | '*' | '+' | '-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | ':') arrayElementSelectors)))> */
 		nil,
-		/* 21 objectElementSelectors <- <(objectElementSelector (sep objectElementSelector Action12)* !sep)> */
+		/* 25 objectElementSelectors <- <(objectElementSelector (sep objectElementSelector Action19)* !sep)> */
 		nil,
-		/* 22 objectElementSelector <- <(wildcardSelector / nameSelector)> */
+		/* 26 objectElementSelector <- <(wildcardSelector / nameSelector)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{22, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{26, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position134, tokenIndex134 := position, tokenIndex
+			position164, tokenIndex164 := position, tokenIndex
 			{
-				position135 := position
+				position165 := position
 				{
-					position136, tokenIndex136 := position, tokenIndex
+					position166, tokenIndex166 := position, tokenIndex
 					if !_rules[rulewildcardSelector]() {
-						goto l137
+						goto l167
 					}
-					goto l136
-				l137:
-					position, tokenIndex = position136, tokenIndex136
+					goto l166
+				l167:
+					position, tokenIndex = position166, tokenIndex166
 					{
-						position138 := position
+						position168 := position
 						if !_rules[rulelString]() {
-							goto l134
+							goto l164
 						}
 						{
-							add(ruleAction14, position)
+							add(ruleAction21, position)
 						}
-						add(rulenameSelector, position138)
+						add(rulenameSelector, position168)
 					}
 				}
-			l136:
-				add(ruleobjectElementSelector, position135)
+			l166:
+				add(ruleobjectElementSelector, position165)
 			}
-			memoize(22, position134, tokenIndex134, true)
+			memoize(26, position164, tokenIndex164, true)
 			return true
-		l134:
-			memoize(22, position134, tokenIndex134, false)
-			position, tokenIndex = position134, tokenIndex134
+		l164:
+			memoize(26, position164, tokenIndex164, false)
+			position, tokenIndex = position164, tokenIndex164
 			return false
 		},
-		/* 23 wildcardSelector <- <('*' Action13)> */
+		/* 27 wildcardSelector <- <('*' Action20)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{23, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{27, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position140, tokenIndex140 := position, tokenIndex
+			position170, tokenIndex170 := position, tokenIndex
 			{
-				position141 := position
+				position171 := position
 				if buffer[position] != '*' {
-					goto l140
+					goto l170
 				}
 				position++
 				{
-					add(ruleAction13, position)
+					add(ruleAction20, position)
 				}
-				add(rulewildcardSelector, position141)
+				add(rulewildcardSelector, position171)
 			}
-			memoize(23, position140, tokenIndex140, true)
+			memoize(27, position170, tokenIndex170, true)
 			return true
-		l140:
-			memoize(23, position140, tokenIndex140, false)
-			position, tokenIndex = position140, tokenIndex140
+		l170:
+			memoize(27, position170, tokenIndex170, false)
+			position, tokenIndex = position170, tokenIndex170
 			return false
 		},
-		/* 24 nameSelector <- <(lString Action14)> */
+		/* 28 nameSelector <- <(lString Action21)> */
 		nil,
-		/* 25 arrayElementSelectors <- <(arrayElementSelector (sep arrayElementSelector Action15)* !sep)> */
+		/* 29 arrayElementSelectors <- <(arrayElementSelector (sep arrayElementSelector Action22)* !sep)> */
 		nil,
-		/* 26 arrayElementSelector <- <(((arraySliceSelector Action16) / indexSelector / ('*' Action17)) Action18)> */
+		/* 30 arrayElementSelector <- <(((arraySliceSelector Action23) / indexSelector / ('*' Action24)) Action25)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{26, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{30, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position145, tokenIndex145 := position, tokenIndex
+			position175, tokenIndex175 := position, tokenIndex
 			{
-				position146 := position
+				position176 := position
 				{
-					position147, tokenIndex147 := position, tokenIndex
+					position177, tokenIndex177 := position, tokenIndex
 					{
-						position149 := position
+						position179 := position
 						_rules[ruleanyIndex]()
 						if !_rules[rulesepArraySlice]() {
-							goto l148
+							goto l178
 						}
 						_rules[ruleanyIndex]()
 						{
-							position150, tokenIndex150 := position, tokenIndex
+							position180, tokenIndex180 := position, tokenIndex
 							if !_rules[rulesepArraySlice]() {
-								goto l151
+								goto l181
 							}
 							_rules[ruleanyIndex]()
-							goto l150
-						l151:
-							position, tokenIndex = position150, tokenIndex150
+							goto l180
+						l181:
+							position, tokenIndex = position180, tokenIndex180
 							_rules[ruleomittedIndex]()
 						}
-					l150:
-						add(rulearraySliceSelector, position149)
+					l180:
+						add(rulearraySliceSelector, position179)
 					}
 					{
-						add(ruleAction16, position)
+						add(ruleAction23, position)
 					}
-					goto l147
-				l148:
-					position, tokenIndex = position147, tokenIndex147
+					goto l177
+				l178:
+					position, tokenIndex = position177, tokenIndex177
 					{
-						position154 := position
+						position184 := position
 						if !_rules[ruleindexNumber]() {
-							goto l153
+							goto l183
 						}
-						add(ruleindexSelector, position154)
+						add(ruleindexSelector, position184)
 					}
-					goto l147
-				l153:
-					position, tokenIndex = position147, tokenIndex147
+					goto l177
+				l183:
+					position, tokenIndex = position177, tokenIndex177
 					if buffer[position] != '*' {
-						goto l145
+						goto l175
 					}
 					position++
 					{
-						add(ruleAction17, position)
+						add(ruleAction24, position)
 					}
 				}
-			l147:
+			l177:
 				{
-					add(ruleAction18, position)
+					add(ruleAction25, position)
 				}
-				add(rulearrayElementSelector, position146)
+				add(rulearrayElementSelector, position176)
 			}
-			memoize(26, position145, tokenIndex145, true)
+			memoize(30, position175, tokenIndex175, true)
 			return true
-		l145:
-			memoize(26, position145, tokenIndex145, false)
-			position, tokenIndex = position145, tokenIndex145
+		l175:
+			memoize(30, position175, tokenIndex175, false)
+			position, tokenIndex = position175, tokenIndex175
 			return false
 		},
-		/* 27 arraySliceSelector <- <(anyIndex sepArraySlice anyIndex ((sepArraySlice anyIndex) / omittedIndex))> */
+		/* 31 arraySliceSelector <- <(anyIndex sepArraySlice anyIndex ((sepArraySlice anyIndex) / omittedIndex))> */
 		nil,
-		/* 28 anyIndex <- <(indexNumber / omittedIndex)> */
+		/* 32 anyIndex <- <(indexNumber / omittedIndex)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{28, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{32, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position158, tokenIndex158 := position, tokenIndex
+			position188, tokenIndex188 := position, tokenIndex
 			{
-				position159 := position
+				position189 := position
 				{
-					position160, tokenIndex160 := position, tokenIndex
+					position190, tokenIndex190 := position, tokenIndex
 					if !_rules[ruleindexNumber]() {
-						goto l161
+						goto l191
 					}
-					goto l160
-				l161:
-					position, tokenIndex = position160, tokenIndex160
+					goto l190
+				l191:
+					position, tokenIndex = position190, tokenIndex190
 					_rules[ruleomittedIndex]()
 				}
-			l160:
-				add(ruleanyIndex, position159)
+			l190:
+				add(ruleanyIndex, position189)
 			}
-			memoize(28, position158, tokenIndex158, true)
+			memoize(32, position188, tokenIndex188, true)
 			return true
 		},
-		/* 29 omittedIndex <- <Action19> */
+		/* 33 omittedIndex <- <Action26> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{29, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{33, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position162, tokenIndex162 := position, tokenIndex
+			position192, tokenIndex192 := position, tokenIndex
 			{
-				position163 := position
+				position193 := position
 				{
-					add(ruleAction19, position)
+					add(ruleAction26, position)
 				}
-				add(ruleomittedIndex, position163)
+				add(ruleomittedIndex, position193)
 			}
-			memoize(29, position162, tokenIndex162, true)
+			memoize(33, position192, tokenIndex192, true)
 			return true
 		},
-		/* 30 indexSelector <- <indexNumber> */
+		/* 34 indexSelector <- <indexNumber> */
 		nil,
-		/* 31 indexNumber <- <(<(('-' / '+')? [0-9]+)> Action20)> */
+		/* 35 indexNumber <- <(<(('-' / '+')? [0-9]+)> Action27)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{31, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{35, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position166, tokenIndex166 := position, tokenIndex
+			position196, tokenIndex196 := position, tokenIndex
 			{
-				position167 := position
+				position197 := position
 				{
-					position168 := position
+					position198 := position
 					{
-						position169, tokenIndex169 := position, tokenIndex
+						position199, tokenIndex199 := position, tokenIndex
 						{
-							position171, tokenIndex171 := position, tokenIndex
+							position201, tokenIndex201 := position, tokenIndex
 							if buffer[position] != '-' {
-								goto l172
+								goto l202
 							}
 							position++
-							goto l171
-						l172:
-							position, tokenIndex = position171, tokenIndex171
+							goto l201
+						l202:
+							position, tokenIndex = position201, tokenIndex201
 							if buffer[position] != '+' {
-								goto l169
+								goto l199
 							}
 							position++
 						}
-					l171:
-						goto l170
-					l169:
-						position, tokenIndex = position169, tokenIndex169
+					l201:
+						goto l200
+					l199:
+						position, tokenIndex = position199, tokenIndex199
 					}
-				l170:
+				l200:
 					if c := buffer[position]; c < '0' || c > '9' {
-						goto l166
+						goto l196
 					}
 					position++
-				l173:
+				l203:
 					{
-						position174, tokenIndex174 := position, tokenIndex
+						position204, tokenIndex204 := position, tokenIndex
 						if c := buffer[position]; c < '0' || c > '9' {
-							goto l174
+							goto l204
 						}
 						position++
-						goto l173
-					l174:
-						position, tokenIndex = position174, tokenIndex174
+						goto l203
+					l204:
+						position, tokenIndex = position204, tokenIndex204
 					}
-					add(rulePegText, position168)
+					add(rulePegText, position198)
 				}
 				{
-					add(ruleAction20, position)
+					add(ruleAction27, position)
 				}
-				add(ruleindexNumber, position167)
+				add(ruleindexNumber, position197)
 			}
-			memoize(31, position166, tokenIndex166, true)
+			memoize(35, position196, tokenIndex196, true)
 			return true
-		l166:
-			memoize(31, position166, tokenIndex166, false)
-			position, tokenIndex = position166, tokenIndex166
+		l196:
+			memoize(35, position196, tokenIndex196, false)
+			position, tokenIndex = position196, tokenIndex196
 			return false
 		},
-		/* 32 sep <- <(space ',' space)> */
+		/* 36 sep <- <(space ',' space)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{32, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{36, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position176, tokenIndex176 := position, tokenIndex
+			position206, tokenIndex206 := position, tokenIndex
 			{
-				position177 := position
+				position207 := position
 				_rules[rulespace]()
 				if buffer[position] != ',' {
-					goto l176
+					goto l206
 				}
 				position++
 				_rules[rulespace]()
-				add(rulesep, position177)
+				add(rulesep, position207)
 			}
-			memoize(32, position176, tokenIndex176, true)
+			memoize(36, position206, tokenIndex206, true)
 			return true
-		l176:
-			memoize(32, position176, tokenIndex176, false)
-			position, tokenIndex = position176, tokenIndex176
+		l206:
+			memoize(36, position206, tokenIndex206, false)
+			position, tokenIndex = position206, tokenIndex206
 			return false
 		},
-		/* 33 sepArraySlice <- <(space ':' space)> */
+		/* 37 sepArraySlice <- <(space ':' space)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{33, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{37, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position178, tokenIndex178 := position, tokenIndex
+			position208, tokenIndex208 := position, tokenIndex
 			{
-				position179 := position
+				position209 := position
 				_rules[rulespace]()
 				if buffer[position] != ':' {
-					goto l178
+					goto l208
 				}
 				position++
 				_rules[rulespace]()
-				add(rulesepArraySlice, position179)
+				add(rulesepArraySlice, position209)
 			}
-			memoize(33, position178, tokenIndex178, true)
+			memoize(37, position208, tokenIndex208, true)
 			return true
-		l178:
-			memoize(33, position178, tokenIndex178, false)
-			position, tokenIndex = position178, tokenIndex178
+		l208:
+			memoize(37, position208, tokenIndex208, false)
+			position, tokenIndex = position208, tokenIndex208
 			return false
 		},
-		/* 34 scriptSelector <- <(scriptSelectorStart <command> scriptSelectorEnd Action21)> */
+		/* 38 scriptSelector <- <(scriptSelectorStart <command> scriptSelectorEnd Action28)> */
 		nil,
-		/* 35 command <- <(!scriptSelectorEnd .)+> */
+		/* 39 command <- <(!scriptSelectorEnd .)+> */
 		nil,
-		/* 36 filterSelector <- <(filterSelectorStart query filterSelectorEnd Action22)> */
+		/* 40 filterSelector <- <(filterSelectorStart query filterSelectorEnd Action29)> */
 		nil,
-		/* 37 query <- <(andQuery (logicOr andQuery Action23)*)> */
+		/* 41 query <- <(andQuery (logicOr andQuery Action30)*)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{37, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{41, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position183, tokenIndex183 := position, tokenIndex
+			position213, tokenIndex213 := position, tokenIndex
 			{
-				position184 := position
+				position214 := position
 				if !_rules[ruleandQuery]() {
-					goto l183
+					goto l213
 				}
-			l185:
+			l215:
 				{
-					position186, tokenIndex186 := position, tokenIndex
+					position216, tokenIndex216 := position, tokenIndex
 					{
-						position187 := position
+						position217 := position
 						_rules[rulespace]()
 						if buffer[position] != '|' {
-							goto l186
+							goto l216
 						}
 						position++
 						if buffer[position] != '|' {
-							goto l186
+							goto l216
 						}
 						position++
 						_rules[rulespace]()
-						add(rulelogicOr, position187)
+						add(rulelogicOr, position217)
 					}
 					if !_rules[ruleandQuery]() {
-						goto l186
+						goto l216
 					}
 					{
-						add(ruleAction23, position)
+						add(ruleAction30, position)
 					}
-					goto l185
-				l186:
-					position, tokenIndex = position186, tokenIndex186
+					goto l215
+				l216:
+					position, tokenIndex = position216, tokenIndex216
 				}
-				add(rulequery, position184)
+				add(rulequery, position214)
 			}
-			memoize(37, position183, tokenIndex183, true)
+			memoize(41, position213, tokenIndex213, true)
 			return true
-		l183:
-			memoize(37, position183, tokenIndex183, false)
-			position, tokenIndex = position183, tokenIndex183
+		l213:
+			memoize(41, position213, tokenIndex213, false)
+			position, tokenIndex = position213, tokenIndex213
 			return false
 		},
-		/* 38 andQuery <- <(basicQuery (logicAnd basicQuery Action24)*)> */
+		/* 42 andQuery <- <(basicQuery (logicAnd basicQuery Action31)*)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{38, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{42, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position189, tokenIndex189 := position, tokenIndex
+			position219, tokenIndex219 := position, tokenIndex
 			{
-				position190 := position
+				position220 := position
 				if !_rules[rulebasicQuery]() {
-					goto l189
+					goto l219
 				}
-			l191:
+			l221:
 				{
-					position192, tokenIndex192 := position, tokenIndex
+					position222, tokenIndex222 := position, tokenIndex
 					{
-						position193 := position
+						position223 := position
 						_rules[rulespace]()
 						if buffer[position] != '&' {
-							goto l192
+							goto l222
 						}
 						position++
 						if buffer[position] != '&' {
-							goto l192
+							goto l222
 						}
 						position++
 						_rules[rulespace]()
-						add(rulelogicAnd, position193)
+						add(rulelogicAnd, position223)
 					}
 					if !_rules[rulebasicQuery]() {
-						goto l192
+						goto l222
 					}
 					{
-						add(ruleAction24, position)
+						add(ruleAction31, position)
 					}
-					goto l191
-				l192:
-					position, tokenIndex = position192, tokenIndex192
+					goto l221
+				l222:
+					position, tokenIndex = position222, tokenIndex222
 				}
-				add(ruleandQuery, position190)
+				add(ruleandQuery, position220)
 			}
-			memoize(38, position189, tokenIndex189, true)
+			memoize(42, position219, tokenIndex219, true)
 			return true
-		l189:
-			memoize(38, position189, tokenIndex189, false)
-			position, tokenIndex = position189, tokenIndex189
+		l219:
+			memoize(42, position219, tokenIndex219, false)
+			position, tokenIndex = position219, tokenIndex219
 			return false
 		},
-		/* 39 basicQuery <- <((<comparator> Action25) / ((&('!') (logicNot jsonpathFilter Action26)) | (&('(') (subQueryStart query subQueryEnd)) | (&(' ' | '$' | '@') jsonpathFilter)))> */
+		/* 43 basicQuery <- <((<comparator> Action32) / (logicNot functionTest Action33) / ((&(' ' | '$' | '@') jsonpathFilter) | (&('!') (logicNot jsonpathFilter Action34)) | (&('(') (subQueryStart query subQueryEnd)) | (&('-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') functionTest)))> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{39, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{43, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position195, tokenIndex195 := position, tokenIndex
+			position225, tokenIndex225 := position, tokenIndex
 			{
-				position196 := position
+				position226 := position
 				{
-					position197, tokenIndex197 := position, tokenIndex
+					position227, tokenIndex227 := position, tokenIndex
 					{
-						position199 := position
+						position229 := position
 						{
-							position200 := position
+							position230 := position
 							{
-								position201, tokenIndex201 := position, tokenIndex
+								position231, tokenIndex231 := position, tokenIndex
 								if !_rules[ruleqParam]() {
-									goto l202
+									goto l232
 								}
 								_rules[rulespace]()
 								{
-									position203, tokenIndex203 := position, tokenIndex
+									position233, tokenIndex233 := position, tokenIndex
 									if buffer[position] != '=' {
-										goto l204
+										goto l234
 									}
 									position++
 									if buffer[position] != '=' {
-										goto l204
+										goto l234
 									}
 									position++
 									_rules[rulespace]()
 									if !_rules[ruleqParam]() {
-										goto l204
+										goto l234
 									}
 									{
-										add(ruleAction27, position)
+										add(ruleAction35, position)
 									}
-									goto l203
-								l204:
-									position, tokenIndex = position203, tokenIndex203
+									goto l233
+								l234:
+									position, tokenIndex = position233, tokenIndex233
 									if buffer[position] != '!' {
-										goto l202
+										goto l232
 									}
 									position++
 									if buffer[position] != '=' {
-										goto l202
+										goto l232
 									}
 									position++
 									_rules[rulespace]()
 									if !_rules[ruleqParam]() {
-										goto l202
+										goto l232
 									}
 									{
-										add(ruleAction28, position)
+										add(ruleAction36, position)
 									}
 								}
-							l203:
-								goto l201
-							l202:
-								position, tokenIndex = position201, tokenIndex201
+							l233:
+								goto l231
+							l232:
+								position, tokenIndex = position231, tokenIndex231
 								if !_rules[ruleqNumberOrStringParam]() {
-									goto l207
+									goto l237
 								}
 								_rules[rulespace]()
 								{
-									position208, tokenIndex208 := position, tokenIndex
+									position238, tokenIndex238 := position, tokenIndex
 									if buffer[position] != '<' {
-										goto l209
+										goto l239
 									}
 									position++
 									if buffer[position] != '=' {
-										goto l209
+										goto l239
 									}
 									position++
 									_rules[rulespace]()
 									if !_rules[ruleqNumberOrStringParam]() {
-										goto l209
+										goto l239
 									}
 									{
-										add(ruleAction29, position)
+										add(ruleAction37, position)
 									}
-									goto l208
-								l209:
-									position, tokenIndex = position208, tokenIndex208
+									goto l238
+								l239:
+									position, tokenIndex = position238, tokenIndex238
 									if buffer[position] != '<' {
-										goto l211
+										goto l241
 									}
 									position++
 									_rules[rulespace]()
 									if !_rules[ruleqNumberOrStringParam]() {
-										goto l211
+										goto l241
 									}
 									{
-										add(ruleAction30, position)
+										add(ruleAction38, position)
 									}
-									goto l208
-								l211:
-									position, tokenIndex = position208, tokenIndex208
+									goto l238
+								l241:
+									position, tokenIndex = position238, tokenIndex238
 									if buffer[position] != '>' {
-										goto l213
+										goto l243
 									}
 									position++
 									if buffer[position] != '=' {
-										goto l213
+										goto l243
 									}
 									position++
 									_rules[rulespace]()
 									if !_rules[ruleqNumberOrStringParam]() {
-										goto l213
+										goto l243
 									}
 									{
-										add(ruleAction31, position)
+										add(ruleAction39, position)
 									}
-									goto l208
-								l213:
-									position, tokenIndex = position208, tokenIndex208
+									goto l238
+								l243:
+									position, tokenIndex = position238, tokenIndex238
 									if buffer[position] != '>' {
-										goto l207
+										goto l237
 									}
 									position++
 									_rules[rulespace]()
 									if !_rules[ruleqNumberOrStringParam]() {
-										goto l207
+										goto l237
 									}
 									{
-										add(ruleAction32, position)
+										add(ruleAction40, position)
 									}
 								}
-							l208:
-								goto l201
-							l207:
-								position, tokenIndex = position201, tokenIndex201
-								if !_rules[rulesingleJsonpathFilter]() {
-									goto l198
+							l238:
+								goto l231
+							l237:
+								position, tokenIndex = position231, tokenIndex231
+								{
+									position246, tokenIndex246 := position, tokenIndex
+									if !_rules[rulefunctionValue]() {
+										goto l247
+									}
+									goto l246
+								l247:
+									position, tokenIndex = position246, tokenIndex246
+									if !_rules[rulesingleJsonpathFilter]() {
+										goto l228
+									}
 								}
+							l246:
 								_rules[rulespace]()
 								if buffer[position] != '=' {
-									goto l198
+									goto l228
 								}
 								position++
 								if buffer[position] != '~' {
-									goto l198
+									goto l228
 								}
 								position++
 								_rules[rulespace]()
 								if buffer[position] != '/' {
-									goto l198
+									goto l228
 								}
 								position++
 								{
-									position216 := position
+									position248 := position
 									{
-										position217 := position
-									l218:
+										position249 := position
+									l250:
 										{
-											position219, tokenIndex219 := position, tokenIndex
+											position251, tokenIndex251 := position, tokenIndex
 											{
-												position220, tokenIndex220 := position, tokenIndex
+												position252, tokenIndex252 := position, tokenIndex
 												{
-													position222, tokenIndex222 := position, tokenIndex
+													position254, tokenIndex254 := position, tokenIndex
 													{
-														position223, tokenIndex223 := position, tokenIndex
+														position255, tokenIndex255 := position, tokenIndex
 														if buffer[position] != '/' {
-															goto l224
+															goto l256
 														}
 														position++
-														goto l223
-													l224:
-														position, tokenIndex = position223, tokenIndex223
+														goto l255
+													l256:
+														position, tokenIndex = position255, tokenIndex255
 														if buffer[position] != '\\' {
-															goto l222
+															goto l254
 														}
 														position++
 													}
-												l223:
-													goto l221
-												l222:
-													position, tokenIndex = position222, tokenIndex222
+												l255:
+													goto l253
+												l254:
+													position, tokenIndex = position254, tokenIndex254
 												}
 												if !matchDot() {
-													goto l221
+													goto l253
 												}
-												goto l220
-											l221:
-												position, tokenIndex = position220, tokenIndex220
+												goto l252
+											l253:
+												position, tokenIndex = position252, tokenIndex252
 												if buffer[position] != '\\' {
-													goto l219
+													goto l251
 												}
 												position++
 												if !matchDot() {
-													goto l219
+													goto l251
 												}
 											}
-										l220:
-											goto l218
-										l219:
-											position, tokenIndex = position219, tokenIndex219
+										l252:
+											goto l250
+										l251:
+											position, tokenIndex = position251, tokenIndex251
 										}
-										add(ruleregex, position217)
+										add(ruleregex, position249)
 									}
-									add(rulePegText, position216)
+									add(rulePegText, position248)
 								}
 								if buffer[position] != '/' {
-									goto l198
+									goto l228
 								}
 								position++
 								{
-									add(ruleAction33, position)
+									add(ruleAction41, position)
 								}
 							}
-						l201:
-							add(rulecomparator, position200)
+						l231:
+							add(rulecomparator, position230)
 						}
-						add(rulePegText, position199)
+						add(rulePegText, position229)
+					}
+					{
+						add(ruleAction32, position)
+					}
+					goto l227
+				l228:
+					position, tokenIndex = position227, tokenIndex227
+					if !_rules[rulelogicNot]() {
+						goto l259
+					}
+					if !_rules[rulefunctionTest]() {
+						goto l259
 					}
 					{
-						add(ruleAction25, position)
+						add(ruleAction33, position)
 					}
-					goto l197
-				l198:
-					position, tokenIndex = position197, tokenIndex197
+					goto l227
+				l259:
+					position, tokenIndex = position227, tokenIndex227
 					{
 						switch buffer[position] {
+						case ' ', '$', '@':
+							if !_rules[rulejsonpathFilter]() {
+								goto l225
+							}
 						case '!':
-							{
-								position228 := position
-								position++
-								_rules[rulespace]()
-								add(rulelogicNot, position228)
+							if !_rules[rulelogicNot]() {
+								goto l225
 							}
 							if !_rules[rulejsonpathFilter]() {
-								goto l195
+								goto l225
 							}
 							{
-								add(ruleAction26, position)
+								add(ruleAction34, position)
 							}
 						case '(':
 							{
-								position230 := position
+								position263 := position
 								position++
 								_rules[rulespace]()
-								add(rulesubQueryStart, position230)
+								add(rulesubQueryStart, position263)
 							}
 							if !_rules[rulequery]() {
-								goto l195
+								goto l225
 							}
 							{
-								position231 := position
+								position264 := position
 								_rules[rulespace]()
 								if buffer[position] != ')' {
-									goto l195
+									goto l225
 								}
 								position++
-								add(rulesubQueryEnd, position231)
+								add(rulesubQueryEnd, position264)
 							}
 						default:
-							if !_rules[rulejsonpathFilter]() {
-								goto l195
+							if !_rules[rulefunctionTest]() {
+								goto l225
 							}
 						}
 					}
 
 				}
-			l197:
-				add(rulebasicQuery, position196)
+			l227:
+				add(rulebasicQuery, position226)
 			}
-			memoize(39, position195, tokenIndex195, true)
+			memoize(43, position225, tokenIndex225, true)
 			return true
-		l195:
-			memoize(39, position195, tokenIndex195, false)
-			position, tokenIndex = position195, tokenIndex195
+		l225:
+			memoize(43, position225, tokenIndex225, false)
+			position, tokenIndex = position225, tokenIndex225
 			return false
 		},
-		/* 40 logicOr <- <(space ('|' '|') space)> */
-		nil,
-		/* 41 logicAnd <- <(space ('&' '&') space)> */
+		/* 44 logicOr <- <(space ('|' '|') space)> */
 		nil,
-		/* 42 logicNot <- <('!' space)> */
+		/* 45 logicAnd <- <(space ('&' '&') space)> */
 		nil,
-		/* 43 comparator <- <((qParam space (('=' '=' space qParam Action27) / ('!' '=' space qParam Action28))) / (qNumberOrStringParam space (('<' '=' space qNumberOrStringParam Action29) / ('<' space qNumberOrStringParam Action30) / ('>' '=' space qNumberOrStringParam Action31) / ('>' space qNumberOrStringParam Action32))) / (singleJsonpathFilter space ('=' '~') space '/' <regex> '/' Action33))> */
+		/* 46 logicNot <- <('!' space)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{46, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position267, tokenIndex267 := position, tokenIndex
+			{
+				position268 := position
+				if buffer[position] != '!' {
+					goto l267
+				}
+				position++
+				_rules[rulespace]()
+				add(rulelogicNot, position268)
+			}
+			memoize(46, position267, tokenIndex267, true)
+			return true
+		l267:
+			memoize(46, position267, tokenIndex267, false)
+			position, tokenIndex = position267, tokenIndex267
+			return false
+		},
+		/* 47 comparator <- <((qParam space (('=' '=' space qParam Action35) / ('!' '=' space qParam Action36))) / (qNumberOrStringParam space (('<' '=' space qNumberOrStringParam Action37) / ('<' space qNumberOrStringParam Action38) / ('>' '=' space qNumberOrStringParam Action39) / ('>' space qNumberOrStringParam Action40))) / ((functionValue / singleJsonpathFilter) space ('=' '~') space '/' <regex> '/' Action41))> */
 		nil,
-		/* 44 qParam <- <((((&('N' | 'n') lNull) | (&('"' | '\'') lString) | (&('F' | 'T' | 'f' | 't') lBool) | (&('+' | '-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') lNumber)) Action34) / singleJsonpathFilter)> */
+		/* 48 qParam <- <(functionValue / (((&('N' | 'n') lNull) | (&('"' | '\'') lString) | (&('F' | 'T' | 'f' | 't') lBool) | (&('+' | '-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') lNumber)) Action42) / singleJsonpathFilter)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{44, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{48, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position236, tokenIndex236 := position, tokenIndex
+			position270, tokenIndex270 := position, tokenIndex
 			{
-				position237 := position
+				position271 := position
 				{
-					position238, tokenIndex238 := position, tokenIndex
+					position272, tokenIndex272 := position, tokenIndex
+					if !_rules[rulefunctionValue]() {
+						goto l273
+					}
+					goto l272
+				l273:
+					position, tokenIndex = position272, tokenIndex272
 					{
 						switch buffer[position] {
 						case 'N', 'n':
-							{
-								position241 := position
-								{
-									position242, tokenIndex242 := position, tokenIndex
-									if buffer[position] != 'n' {
-										goto l243
-									}
-									position++
-									if buffer[position] != 'u' {
-										goto l243
-									}
-									position++
-									if buffer[position] != 'l' {
-										goto l243
-									}
-									position++
-									if buffer[position] != 'l' {
-										goto l243
-									}
-									position++
-									goto l242
-								l243:
-									position, tokenIndex = position242, tokenIndex242
-									if buffer[position] != 'N' {
-										goto l244
-									}
-									position++
-									if buffer[position] != 'u' {
-										goto l244
-									}
-									position++
-									if buffer[position] != 'l' {
-										goto l244
-									}
-									position++
-									if buffer[position] != 'l' {
-										goto l244
-									}
-									position++
-									goto l242
-								l244:
-									position, tokenIndex = position242, tokenIndex242
-									if buffer[position] != 'N' {
-										goto l239
-									}
-									position++
-									if buffer[position] != 'U' {
-										goto l239
-									}
-									position++
-									if buffer[position] != 'L' {
-										goto l239
-									}
-									position++
-									if buffer[position] != 'L' {
-										goto l239
-									}
-									position++
-								}
-							l242:
-								{
-									add(ruleAction44, position)
-								}
-								add(rulelNull, position241)
+							if !_rules[rulelNull]() {
+								goto l274
 							}
 						case '"', '\'':
 							if !_rules[rulelString]() {
-								goto l239
+								goto l274
 							}
 						case 'F', 'T', 'f', 't':
-							{
-								position246 := position
-								{
-									position247, tokenIndex247 := position, tokenIndex
-									{
-										position249, tokenIndex249 := position, tokenIndex
-										if buffer[position] != 't' {
-											goto l250
-										}
-										position++
-										if buffer[position] != 'r' {
-											goto l250
-										}
-										position++
-										if buffer[position] != 'u' {
-											goto l250
-										}
-										position++
-										if buffer[position] != 'e' {
-											goto l250
-										}
-										position++
-										goto l249
-									l250:
-										position, tokenIndex = position249, tokenIndex249
-										if buffer[position] != 'T' {
-											goto l251
-										}
-										position++
-										if buffer[position] != 'r' {
-											goto l251
-										}
-										position++
-										if buffer[position] != 'u' {
-											goto l251
-										}
-										position++
-										if buffer[position] != 'e' {
-											goto l251
-										}
-										position++
-										goto l249
-									l251:
-										position, tokenIndex = position249, tokenIndex249
-										if buffer[position] != 'T' {
-											goto l248
-										}
-										position++
-										if buffer[position] != 'R' {
-											goto l248
-										}
-										position++
-										if buffer[position] != 'U' {
-											goto l248
-										}
-										position++
-										if buffer[position] != 'E' {
-											goto l248
-										}
-										position++
-									}
-								l249:
-									{
-										add(ruleAction40, position)
-									}
-									goto l247
-								l248:
-									position, tokenIndex = position247, tokenIndex247
-									{
-										position253, tokenIndex253 := position, tokenIndex
-										if buffer[position] != 'f' {
-											goto l254
-										}
-										position++
-										if buffer[position] != 'a' {
-											goto l254
-										}
-										position++
-										if buffer[position] != 'l' {
-											goto l254
-										}
-										position++
-										if buffer[position] != 's' {
-											goto l254
-										}
-										position++
-										if buffer[position] != 'e' {
-											goto l254
-										}
-										position++
-										goto l253
-									l254:
-										position, tokenIndex = position253, tokenIndex253
-										if buffer[position] != 'F' {
-											goto l255
-										}
-										position++
-										if buffer[position] != 'a' {
-											goto l255
-										}
-										position++
-										if buffer[position] != 'l' {
-											goto l255
-										}
-										position++
-										if buffer[position] != 's' {
-											goto l255
-										}
-										position++
-										if buffer[position] != 'e' {
-											goto l255
-										}
-										position++
-										goto l253
-									l255:
-										position, tokenIndex = position253, tokenIndex253
-										if buffer[position] != 'F' {
-											goto l239
-										}
-										position++
-										if buffer[position] != 'A' {
-											goto l239
-										}
-										position++
-										if buffer[position] != 'L' {
-											goto l239
-										}
-										position++
-										if buffer[position] != 'S' {
-											goto l239
-										}
-										position++
-										if buffer[position] != 'E' {
-											goto l239
-										}
-										position++
-									}
-								l253:
-									{
-										add(ruleAction41, position)
-									}
-								}
-							l247:
-								add(rulelBool, position246)
+							if !_rules[rulelBool]() {
+								goto l274
 							}
 						default:
 							if !_rules[rulelNumber]() {
-								goto l239
+								goto l274
 							}
 						}
 					}
 
 					{
-						add(ruleAction34, position)
+						add(ruleAction42, position)
 					}
-					goto l238
-				l239:
-					position, tokenIndex = position238, tokenIndex238
+					goto l272
+				l274:
+					position, tokenIndex = position272, tokenIndex272
 					if !_rules[rulesingleJsonpathFilter]() {
-						goto l236
+						goto l270
 					}
 				}
-			l238:
-				add(ruleqParam, position237)
+			l272:
+				add(ruleqParam, position271)
 			}
-			memoize(44, position236, tokenIndex236, true)
+			memoize(48, position270, tokenIndex270, true)
 			return true
-		l236:
-			memoize(44, position236, tokenIndex236, false)
-			position, tokenIndex = position236, tokenIndex236
+		l270:
+			memoize(48, position270, tokenIndex270, false)
+			position, tokenIndex = position270, tokenIndex270
 			return false
 		},
-		/* 45 qNumberOrStringParam <- <(((lNumber / lString) Action35) / singleJsonpathFilter)> */
+		/* 49 qNumberOrStringParam <- <(functionValue / ((lNumber / lString) Action43) / singleJsonpathFilter)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{45, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{49, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position258, tokenIndex258 := position, tokenIndex
+			position277, tokenIndex277 := position, tokenIndex
 			{
-				position259 := position
+				position278 := position
 				{
-					position260, tokenIndex260 := position, tokenIndex
+					position279, tokenIndex279 := position, tokenIndex
+					if !_rules[rulefunctionValue]() {
+						goto l280
+					}
+					goto l279
+				l280:
+					position, tokenIndex = position279, tokenIndex279
 					{
-						position262, tokenIndex262 := position, tokenIndex
+						position282, tokenIndex282 := position, tokenIndex
 						if !_rules[rulelNumber]() {
-							goto l263
+							goto l283
 						}
-						goto l262
-					l263:
-						position, tokenIndex = position262, tokenIndex262
+						goto l282
+					l283:
+						position, tokenIndex = position282, tokenIndex282
 						if !_rules[rulelString]() {
-							goto l261
+							goto l281
 						}
 					}
-				l262:
+				l282:
 					{
-						add(ruleAction35, position)
+						add(ruleAction43, position)
 					}
-					goto l260
-				l261:
-					position, tokenIndex = position260, tokenIndex260
+					goto l279
+				l281:
+					position, tokenIndex = position279, tokenIndex279
 					if !_rules[rulesingleJsonpathFilter]() {
-						goto l258
+						goto l277
 					}
 				}
-			l260:
-				add(ruleqNumberOrStringParam, position259)
+			l279:
+				add(ruleqNumberOrStringParam, position278)
 			}
-			memoize(45, position258, tokenIndex258, true)
+			memoize(49, position277, tokenIndex277, true)
 			return true
-		l258:
-			memoize(45, position258, tokenIndex258, false)
-			position, tokenIndex = position258, tokenIndex258
+		l277:
+			memoize(49, position277, tokenIndex277, false)
+			position, tokenIndex = position277, tokenIndex277
 			return false
 		},
-		/* 46 singleJsonpathFilter <- <(<(&(rootWithSegment / currentNodeIdentifier) jsonpathFilter)> Action36)> */
+		/* 50 functionValue <- <(<functionExpression> Action44)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{46, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{50, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position285, tokenIndex285 := position, tokenIndex
+			{
+				position286 := position
+				{
+					position287 := position
+					if !_rules[rulefunctionExpression]() {
+						goto l285
+					}
+					add(rulePegText, position287)
+				}
+				{
+					add(ruleAction44, position)
+				}
+				add(rulefunctionValue, position286)
+			}
+			memoize(50, position285, tokenIndex285, true)
+			return true
+		l285:
+			memoize(50, position285, tokenIndex285, false)
+			position, tokenIndex = position285, tokenIndex285
+			return false
+		},
+		/* 51 functionTest <- <(<functionExpression> Action45)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{51, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position265, tokenIndex265 := position, tokenIndex
+			position289, tokenIndex289 := position, tokenIndex
 			{
-				position266 := position
+				position290 := position
 				{
-					position267 := position
+					position291 := position
+					if !_rules[rulefunctionExpression]() {
+						goto l289
+					}
+					add(rulePegText, position291)
+				}
+				{
+					add(ruleAction45, position)
+				}
+				add(rulefunctionTest, position290)
+			}
+			memoize(51, position289, tokenIndex289, true)
+			return true
+		l289:
+			memoize(51, position289, tokenIndex289, false)
+			position, tokenIndex = position289, tokenIndex289
+			return false
+		},
+		/* 52 functionExpression <- <(<(functionName functionArgumentsStart (functionExpressionArguments / emptyFunctionExpressionArguments) functionArgumentsEnd)> Action46)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{52, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position293, tokenIndex293 := position, tokenIndex
+			{
+				position294 := position
+				{
+					position295 := position
+					if !_rules[rulefunctionName]() {
+						goto l293
+					}
+					if !_rules[rulefunctionArgumentsStart]() {
+						goto l293
+					}
 					{
-						position268, tokenIndex268 := position, tokenIndex
+						position296, tokenIndex296 := position, tokenIndex
 						{
-							position269, tokenIndex269 := position, tokenIndex
+							position298 := position
+							if !_rules[rulefunctionExpressionArgument]() {
+								goto l297
+							}
 							{
-								position271 := position
-								if !_rules[rulerootIdentifier]() {
-									goto l270
+								add(ruleAction47, position)
+							}
+						l300:
+							{
+								position301, tokenIndex301 := position, tokenIndex
+								if !_rules[rulesep]() {
+									goto l301
+								}
+								if !_rules[rulefunctionExpressionArgument]() {
+									goto l301
 								}
 								{
-									position272, tokenIndex272 := position, tokenIndex
-									if !_rules[rulesegment]() {
-										goto l273
-									}
-									goto l272
-								l273:
-									position, tokenIndex = position272, tokenIndex272
-									if !_rules[rulefunction]() {
-										goto l270
-									}
+									add(ruleAction48, position)
 								}
-							l272:
-								add(rulerootWithSegment, position271)
+								goto l300
+							l301:
+								position, tokenIndex = position301, tokenIndex301
 							}
-							goto l269
-						l270:
-							position, tokenIndex = position269, tokenIndex269
-							if !_rules[rulecurrentNodeIdentifier]() {
-								goto l265
+							add(rulefunctionExpressionArguments, position298)
+						}
+						goto l296
+					l297:
+						position, tokenIndex = position296, tokenIndex296
+						{
+							position303 := position
+							{
+								add(ruleAction49, position)
 							}
+							add(ruleemptyFunctionExpressionArguments, position303)
 						}
-					l269:
-						position, tokenIndex = position268, tokenIndex268
 					}
-					if !_rules[rulejsonpathFilter]() {
-						goto l265
+				l296:
+					if !_rules[rulefunctionArgumentsEnd]() {
+						goto l293
 					}
-					add(rulePegText, position267)
+					add(rulePegText, position295)
 				}
 				{
-					add(ruleAction36, position)
+					add(ruleAction46, position)
 				}
-				add(rulesingleJsonpathFilter, position266)
+				add(rulefunctionExpression, position294)
 			}
-			memoize(46, position265, tokenIndex265, true)
+			memoize(52, position293, tokenIndex293, true)
 			return true
-		l265:
-			memoize(46, position265, tokenIndex265, false)
-			position, tokenIndex = position265, tokenIndex265
+		l293:
+			memoize(52, position293, tokenIndex293, false)
+			position, tokenIndex = position293, tokenIndex293
 			return false
 		},
-		/* 47 rootWithSegment <- <(rootIdentifier (segment / function))> */
+		/* 53 functionExpressionArguments <- <(functionExpressionArgument Action47 (sep functionExpressionArgument Action48)*)> */
+		nil,
+		/* 54 emptyFunctionExpressionArguments <- <Action49> */
 		nil,
-		/* 48 jsonpathFilter <- <(Action37 jsonpathParameter Action38)> */
+		/* 55 functionExpressionArgument <- <((functionExpression &functionArgumentEnd) / (((&('N' | 'n') lNull) | (&('"' | '\'') lString) | (&('F' | 'T' | 'f' | 't') lBool) | (&('+' | '-' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') lNumber)) &functionArgumentEnd Action50) / (functionArgumentPath &functionArgumentEnd) / query)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{48, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{55, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position308, tokenIndex308 := position, tokenIndex
+			{
+				position309 := position
+				{
+					position310, tokenIndex310 := position, tokenIndex
+					if !_rules[rulefunctionExpression]() {
+						goto l311
+					}
+					{
+						position312, tokenIndex312 := position, tokenIndex
+						if !_rules[rulefunctionArgumentEnd]() {
+							goto l311
+						}
+						position, tokenIndex = position312, tokenIndex312
+					}
+					goto l310
+				l311:
+					position, tokenIndex = position310, tokenIndex310
+					{
+						switch buffer[position] {
+						case 'N', 'n':
+							if !_rules[rulelNull]() {
+								goto l313
+							}
+						case '"', '\'':
+							if !_rules[rulelString]() {
+								goto l313
+							}
+						case 'F', 'T', 'f', 't':
+							if !_rules[rulelBool]() {
+								goto l313
+							}
+						default:
+							if !_rules[rulelNumber]() {
+								goto l313
+							}
+						}
+					}
+
+					{
+						position315, tokenIndex315 := position, tokenIndex
+						if !_rules[rulefunctionArgumentEnd]() {
+							goto l313
+						}
+						position, tokenIndex = position315, tokenIndex315
+					}
+					{
+						add(ruleAction50, position)
+					}
+					goto l310
+				l313:
+					position, tokenIndex = position310, tokenIndex310
+					if !_rules[rulefunctionArgumentPath]() {
+						goto l317
+					}
+					{
+						position318, tokenIndex318 := position, tokenIndex
+						if !_rules[rulefunctionArgumentEnd]() {
+							goto l317
+						}
+						position, tokenIndex = position318, tokenIndex318
+					}
+					goto l310
+				l317:
+					position, tokenIndex = position310, tokenIndex310
+					if !_rules[rulequery]() {
+						goto l308
+					}
+				}
+			l310:
+				add(rulefunctionExpressionArgument, position309)
+			}
+			memoize(55, position308, tokenIndex308, true)
+			return true
+		l308:
+			memoize(55, position308, tokenIndex308, false)
+			position, tokenIndex = position308, tokenIndex308
+			return false
+		},
+		/* 56 functionArgumentEnd <- <(space (',' / ')'))> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{56, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position276, tokenIndex276 := position, tokenIndex
+			position319, tokenIndex319 := position, tokenIndex
 			{
-				position277 := position
+				position320 := position
+				_rules[rulespace]()
 				{
-					add(ruleAction37, position)
+					position321, tokenIndex321 := position, tokenIndex
+					if buffer[position] != ',' {
+						goto l322
+					}
+					position++
+					goto l321
+				l322:
+					position, tokenIndex = position321, tokenIndex321
+					if buffer[position] != ')' {
+						goto l319
+					}
+					position++
 				}
+			l321:
+				add(rulefunctionArgumentEnd, position320)
+			}
+			memoize(56, position319, tokenIndex319, true)
+			return true
+		l319:
+			memoize(56, position319, tokenIndex319, false)
+			position, tokenIndex = position319, tokenIndex319
+			return false
+		},
+		/* 57 singleJsonpathFilter <- <(<(&(rootWithSegment / currentNodeIdentifier) jsonpathFilter)> Action51)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{57, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position323, tokenIndex323 := position, tokenIndex
+			{
+				position324 := position
 				{
-					position279 := position
-					_rules[rulespace]()
+					position325 := position
 					{
-						position280 := position
+						position326, tokenIndex326 := position, tokenIndex
 						{
-							position281, tokenIndex281 := position, tokenIndex
-							if !_rules[rulerootIdentifier]() {
-								goto l282
+							position327, tokenIndex327 := position, tokenIndex
+							{
+								position329 := position
+								if !_rules[rulerootIdentifier]() {
+									goto l328
+								}
+								{
+									position330, tokenIndex330 := position, tokenIndex
+									if !_rules[rulesegment]() {
+										goto l331
+									}
+									goto l330
+								l331:
+									position, tokenIndex = position330, tokenIndex330
+									if !_rules[rulefunction]() {
+										goto l328
+									}
+								}
+							l330:
+								add(rulerootWithSegment, position329)
 							}
-							goto l281
-						l282:
-							position, tokenIndex = position281, tokenIndex281
+							goto l327
+						l328:
+							position, tokenIndex = position327, tokenIndex327
 							if !_rules[rulecurrentNodeIdentifier]() {
-								goto l276
+								goto l323
 							}
 						}
-					l281:
-						add(ruleparameterRootNode, position280)
+					l327:
+						position, tokenIndex = position326, tokenIndex326
+					}
+					if !_rules[rulejsonpathFilter]() {
+						goto l323
 					}
-					_rules[rulesegments]()
-					add(rulejsonpathParameter, position279)
+					add(rulePegText, position325)
 				}
 				{
-					add(ruleAction38, position)
+					add(ruleAction51, position)
 				}
-				add(rulejsonpathFilter, position277)
+				add(rulesingleJsonpathFilter, position324)
 			}
-			memoize(48, position276, tokenIndex276, true)
+			memoize(57, position323, tokenIndex323, true)
 			return true
-		l276:
-			memoize(48, position276, tokenIndex276, false)
-			position, tokenIndex = position276, tokenIndex276
+		l323:
+			memoize(57, position323, tokenIndex323, false)
+			position, tokenIndex = position323, tokenIndex323
 			return false
 		},
-		/* 49 lNumber <- <(<(('-' / '+')? [0-9] ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('.') '.') | (&('+') '+') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> Action39)> */
+		/* 58 rootWithSegment <- <(rootIdentifier (segment / function))> */
+		nil,
+		/* 59 jsonpathFilter <- <(Action52 jsonpathParameter Action53)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{49, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{59, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position284, tokenIndex284 := position, tokenIndex
+			position334, tokenIndex334 := position, tokenIndex
 			{
-				position285 := position
+				position335 := position
 				{
-					position286 := position
+					add(ruleAction52, position)
+				}
+				if !_rules[rulejsonpathParameter]() {
+					goto l334
+				}
+				{
+					add(ruleAction53, position)
+				}
+				add(rulejsonpathFilter, position335)
+			}
+			memoize(59, position334, tokenIndex334, true)
+			return true
+		l334:
+			memoize(59, position334, tokenIndex334, false)
+			position, tokenIndex = position334, tokenIndex334
+			return false
+		},
+		/* 60 lNumber <- <(<(('-' / '+')? [0-9] ((&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('.') '.') | (&('+') '+') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))*)> Action54)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{60, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position338, tokenIndex338 := position, tokenIndex
+			{
+				position339 := position
+				{
+					position340 := position
 					{
-						position287, tokenIndex287 := position, tokenIndex
+						position341, tokenIndex341 := position, tokenIndex
 						{
-							position289, tokenIndex289 := position, tokenIndex
+							position343, tokenIndex343 := position, tokenIndex
 							if buffer[position] != '-' {
-								goto l290
+								goto l344
 							}
 							position++
-							goto l289
-						l290:
-							position, tokenIndex = position289, tokenIndex289
+							goto l343
+						l344:
+							position, tokenIndex = position343, tokenIndex343
 							if buffer[position] != '+' {
-								goto l287
+								goto l341
 							}
 							position++
 						}
-					l289:
-						goto l288
-					l287:
-						position, tokenIndex = position287, tokenIndex287
+					l343:
+						goto l342
+					l341:
+						position, tokenIndex = position341, tokenIndex341
 					}
-				l288:
+				l342:
 					if c := buffer[position]; c < '0' || c > '9' {
-						goto l284
+						goto l338
 					}
 					position++
-				l291:
+				l345:
 					{
-						position292, tokenIndex292 := position, tokenIndex
+						position346, tokenIndex346 := position, tokenIndex
 						{
 							switch buffer[position] {
 							case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
@@ -2810,62 +3253,218 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 								position++
 							default:
 								if c := buffer[position]; c < 'a' || c > 'z' {
-									goto l292
+									goto l346
 								}
 								position++
 							}
 						}
 
-						goto l291
-					l292:
-						position, tokenIndex = position292, tokenIndex292
+						goto l345
+					l346:
+						position, tokenIndex = position346, tokenIndex346
 					}
-					add(rulePegText, position286)
+					add(rulePegText, position340)
 				}
 				{
-					add(ruleAction39, position)
+					add(ruleAction54, position)
 				}
-				add(rulelNumber, position285)
+				add(rulelNumber, position339)
 			}
-			memoize(49, position284, tokenIndex284, true)
+			memoize(60, position338, tokenIndex338, true)
 			return true
-		l284:
-			memoize(49, position284, tokenIndex284, false)
-			position, tokenIndex = position284, tokenIndex284
+		l338:
+			memoize(60, position338, tokenIndex338, false)
+			position, tokenIndex = position338, tokenIndex338
 			return false
 		},
-		/* 50 lBool <- <(((('t' 'r' 'u' 'e') / ('T' 'r' 'u' 'e') / ('T' 'R' 'U' 'E')) Action40) / ((('f' 'a' 'l' 's' 'e') / ('F' 'a' 'l' 's' 'e') / ('F' 'A' 'L' 'S' 'E')) Action41))> */
-		nil,
-		/* 51 lString <- <(('\'' <(('\\' ((&('u') hexDigits) | (&('t') 't') | (&('r') 'r') | (&('n') 'n') | (&('f') 'f') | (&('b') 'b') | (&('\\') '\\') | (&('/') '/') | (&('\'') '\''))) / (!('\'' / '\\') .))*> '\'' Action42) / ('"' <(('\\' ((&('u') hexDigits) | (&('t') 't') | (&('r') 'r') | (&('n') 'n') | (&('f') 'f') | (&('b') 'b') | (&('\\') '\\') | (&('/') '/') | (&('"') '"'))) / (!('"' / '\\') .))*> '"' Action43))> */
+		/* 61 lBool <- <(((('t' 'r' 'u' 'e') / ('T' 'r' 'u' 'e') / ('T' 'R' 'U' 'E')) Action55) / ((('f' 'a' 'l' 's' 'e') / ('F' 'a' 'l' 's' 'e') / ('F' 'A' 'L' 'S' 'E')) Action56))> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{51, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{61, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position349, tokenIndex349 := position, tokenIndex
+			{
+				position350 := position
+				{
+					position351, tokenIndex351 := position, tokenIndex
+					{
+						position353, tokenIndex353 := position, tokenIndex
+						if buffer[position] != 't' {
+							goto l354
+						}
+						position++
+						if buffer[position] != 'r' {
+							goto l354
+						}
+						position++
+						if buffer[position] != 'u' {
+							goto l354
+						}
+						position++
+						if buffer[position] != 'e' {
+							goto l354
+						}
+						position++
+						goto l353
+					l354:
+						position, tokenIndex = position353, tokenIndex353
+						if buffer[position] != 'T' {
+							goto l355
+						}
+						position++
+						if buffer[position] != 'r' {
+							goto l355
+						}
+						position++
+						if buffer[position] != 'u' {
+							goto l355
+						}
+						position++
+						if buffer[position] != 'e' {
+							goto l355
+						}
+						position++
+						goto l353
+					l355:
+						position, tokenIndex = position353, tokenIndex353
+						if buffer[position] != 'T' {
+							goto l352
+						}
+						position++
+						if buffer[position] != 'R' {
+							goto l352
+						}
+						position++
+						if buffer[position] != 'U' {
+							goto l352
+						}
+						position++
+						if buffer[position] != 'E' {
+							goto l352
+						}
+						position++
+					}
+				l353:
+					{
+						add(ruleAction55, position)
+					}
+					goto l351
+				l352:
+					position, tokenIndex = position351, tokenIndex351
+					{
+						position357, tokenIndex357 := position, tokenIndex
+						if buffer[position] != 'f' {
+							goto l358
+						}
+						position++
+						if buffer[position] != 'a' {
+							goto l358
+						}
+						position++
+						if buffer[position] != 'l' {
+							goto l358
+						}
+						position++
+						if buffer[position] != 's' {
+							goto l358
+						}
+						position++
+						if buffer[position] != 'e' {
+							goto l358
+						}
+						position++
+						goto l357
+					l358:
+						position, tokenIndex = position357, tokenIndex357
+						if buffer[position] != 'F' {
+							goto l359
+						}
+						position++
+						if buffer[position] != 'a' {
+							goto l359
+						}
+						position++
+						if buffer[position] != 'l' {
+							goto l359
+						}
+						position++
+						if buffer[position] != 's' {
+							goto l359
+						}
+						position++
+						if buffer[position] != 'e' {
+							goto l359
+						}
+						position++
+						goto l357
+					l359:
+						position, tokenIndex = position357, tokenIndex357
+						if buffer[position] != 'F' {
+							goto l349
+						}
+						position++
+						if buffer[position] != 'A' {
+							goto l349
+						}
+						position++
+						if buffer[position] != 'L' {
+							goto l349
+						}
+						position++
+						if buffer[position] != 'S' {
+							goto l349
+						}
+						position++
+						if buffer[position] != 'E' {
+							goto l349
+						}
+						position++
+					}
+				l357:
+					{
+						add(ruleAction56, position)
+					}
+				}
+			l351:
+				add(rulelBool, position350)
+			}
+			memoize(61, position349, tokenIndex349, true)
+			return true
+		l349:
+			memoize(61, position349, tokenIndex349, false)
+			position, tokenIndex = position349, tokenIndex349
+			return false
+		},
+		/* 62 lString <- <(('\'' <(('\\' ((&('u') hexDigits) | (&('t') 't') | (&('r') 'r') | (&('n') 'n') | (&('f') 'f') | (&('b') 'b') | (&('\\') '\\') | (&('/') '/') | (&('\'') '\''))) / (!('\'' / '\\') .))*> '\'' Action57) / ('"' <(('\\' ((&('u') hexDigits) | (&('t') 't') | (&('r') 'r') | (&('n') 'n') | (&('f') 'f') | (&('b') 'b') | (&('\\') '\\') | (&('/') '/') | (&('"') '"'))) / (!('"' / '\\') .))*> '"' Action58))> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{62, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position296, tokenIndex296 := position, tokenIndex
+			position361, tokenIndex361 := position, tokenIndex
 			{
-				position297 := position
+				position362 := position
 				{
-					position298, tokenIndex298 := position, tokenIndex
+					position363, tokenIndex363 := position, tokenIndex
 					if buffer[position] != '\'' {
-						goto l299
+						goto l364
 					}
 					position++
 					{
-						position300 := position
-					l301:
+						position365 := position
+					l366:
 						{
-							position302, tokenIndex302 := position, tokenIndex
+							position367, tokenIndex367 := position, tokenIndex
 							{
-								position303, tokenIndex303 := position, tokenIndex
+								position368, tokenIndex368 := position, tokenIndex
 								if buffer[position] != '\\' {
-									goto l304
+									goto l369
 								}
 								position++
 								{
 									switch buffer[position] {
 									case 'u':
 										if !_rules[rulehexDigits]() {
-											goto l304
+											goto l369
 										}
 									case 't':
 										position++
@@ -2883,77 +3482,77 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 										position++
 									default:
 										if buffer[position] != '\'' {
-											goto l304
+											goto l369
 										}
 										position++
 									}
 								}
 
-								goto l303
-							l304:
-								position, tokenIndex = position303, tokenIndex303
+								goto l368
+							l369:
+								position, tokenIndex = position368, tokenIndex368
 								{
-									position306, tokenIndex306 := position, tokenIndex
+									position371, tokenIndex371 := position, tokenIndex
 									{
-										position307, tokenIndex307 := position, tokenIndex
+										position372, tokenIndex372 := position, tokenIndex
 										if buffer[position] != '\'' {
-											goto l308
+											goto l373
 										}
 										position++
-										goto l307
-									l308:
-										position, tokenIndex = position307, tokenIndex307
+										goto l372
+									l373:
+										position, tokenIndex = position372, tokenIndex372
 										if buffer[position] != '\\' {
-											goto l306
+											goto l371
 										}
 										position++
 									}
-								l307:
-									goto l302
-								l306:
-									position, tokenIndex = position306, tokenIndex306
+								l372:
+									goto l367
+								l371:
+									position, tokenIndex = position371, tokenIndex371
 								}
 								if !matchDot() {
-									goto l302
+									goto l367
 								}
 							}
-						l303:
-							goto l301
-						l302:
-							position, tokenIndex = position302, tokenIndex302
+						l368:
+							goto l366
+						l367:
+							position, tokenIndex = position367, tokenIndex367
 						}
-						add(rulePegText, position300)
+						add(rulePegText, position365)
 					}
 					if buffer[position] != '\'' {
-						goto l299
+						goto l364
 					}
 					position++
 					{
-						add(ruleAction42, position)
+						add(ruleAction57, position)
 					}
-					goto l298
-				l299:
-					position, tokenIndex = position298, tokenIndex298
+					goto l363
+				l364:
+					position, tokenIndex = position363, tokenIndex363
 					if buffer[position] != '"' {
-						goto l296
+						goto l361
 					}
 					position++
 					{
-						position310 := position
-					l311:
+						position375 := position
+					l376:
 						{
-							position312, tokenIndex312 := position, tokenIndex
+							position377, tokenIndex377 := position, tokenIndex
 							{
-								position313, tokenIndex313 := position, tokenIndex
+								position378, tokenIndex378 := position, tokenIndex
 								if buffer[position] != '\\' {
-									goto l314
+									goto l379
 								}
 								position++
 								{
 									switch buffer[position] {
 									case 'u':
 										if !_rules[rulehexDigits]() {
-											goto l314
+											goto l379
 										}
 									case 't':
 										position++
@@ -2971,106 +3570,106 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 										position++
 									default:
 										if buffer[position] != '"' {
-											goto l314
+											goto l379
 										}
 										position++
 									}
 								}
 
-								goto l313
-							l314:
-								position, tokenIndex = position313, tokenIndex313
+								goto l378
+							l379:
+								position, tokenIndex = position378, tokenIndex378
 								{
-									position316, tokenIndex316 := position, tokenIndex
+									position381, tokenIndex381 := position, tokenIndex
 									{
-										position317, tokenIndex317 := position, tokenIndex
+										position382, tokenIndex382 := position, tokenIndex
 										if buffer[position] != '"' {
-											goto l318
+											goto l383
 										}
 										position++
-										goto l317
-									l318:
-										position, tokenIndex = position317, tokenIndex317
+										goto l382
+									l383:
+										position, tokenIndex = position382, tokenIndex382
 										if buffer[position] != '\\' {
-											goto l316
+											goto l381
 										}
 										position++
 									}
-								l317:
-									goto l312
-								l316:
-									position, tokenIndex = position316, tokenIndex316
+								l382:
+									goto l377
+								l381:
+									position, tokenIndex = position381, tokenIndex381
 								}
 								if !matchDot() {
-									goto l312
+									goto l377
 								}
 							}
-						l313:
-							goto l311
-						l312:
-							position, tokenIndex = position312, tokenIndex312
+						l378:
+							goto l376
+						l377:
+							position, tokenIndex = position377, tokenIndex377
 						}
-						add(rulePegText, position310)
+						add(rulePegText, position375)
 					}
 					if buffer[position] != '"' {
-						goto l296
+						goto l361
 					}
 					position++
 					{
-						add(ruleAction43, position)
+						add(ruleAction58, position)
 					}
 				}
-			l298:
-				add(rulelString, position297)
+			l363:
+				add(rulelString, position362)
 			}
-			memoize(51, position296, tokenIndex296, true)
+			memoize(62, position361, tokenIndex361, true)
 			return true
-		l296:
-			memoize(51, position296, tokenIndex296, false)
-			position, tokenIndex = position296, tokenIndex296
+		l361:
+			memoize(62, position361, tokenIndex361, false)
+			position, tokenIndex = position361, tokenIndex361
 			return false
 		},
-		/* 52 hexDigits <- <('u' hexDigit hexDigit hexDigit hexDigit)> */
+		/* 63 hexDigits <- <('u' hexDigit hexDigit hexDigit hexDigit)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{52, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{63, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position320, tokenIndex320 := position, tokenIndex
+			position385, tokenIndex385 := position, tokenIndex
 			{
-				position321 := position
+				position386 := position
 				if buffer[position] != 'u' {
-					goto l320
+					goto l385
 				}
 				position++
 				if !_rules[rulehexDigit]() {
-					goto l320
+					goto l385
 				}
 				if !_rules[rulehexDigit]() {
-					goto l320
+					goto l385
 				}
 				if !_rules[rulehexDigit]() {
-					goto l320
+					goto l385
 				}
 				if !_rules[rulehexDigit]() {
-					goto l320
+					goto l385
 				}
-				add(rulehexDigits, position321)
+				add(rulehexDigits, position386)
 			}
-			memoize(52, position320, tokenIndex320, true)
+			memoize(63, position385, tokenIndex385, true)
 			return true
-		l320:
-			memoize(52, position320, tokenIndex320, false)
-			position, tokenIndex = position320, tokenIndex320
+		l385:
+			memoize(63, position385, tokenIndex385, false)
+			position, tokenIndex = position385, tokenIndex385
 			return false
 		},
-		/* 53 hexDigit <- <((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]))> */
+		/* 64 hexDigit <- <((&('A' | 'B' | 'C' | 'D' | 'E' | 'F') [A-F]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f') [a-f]) | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]))> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{53, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{64, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position322, tokenIndex322 := position, tokenIndex
+			position387, tokenIndex387 := position, tokenIndex
 			{
-				position323 := position
+				position388 := position
 				{
 					switch buffer[position] {
 					case 'A', 'B', 'C', 'D', 'E', 'F':
@@ -3079,152 +3678,308 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 						position++
 					default:
 						if c := buffer[position]; c < '0' || c > '9' {
-							goto l322
+							goto l387
 						}
 						position++
 					}
 				}
 
-				add(rulehexDigit, position323)
+				add(rulehexDigit, position388)
 			}
-			memoize(53, position322, tokenIndex322, true)
+			memoize(64, position387, tokenIndex387, true)
 			return true
-		l322:
-			memoize(53, position322, tokenIndex322, false)
-			position, tokenIndex = position322, tokenIndex322
+		l387:
+			memoize(64, position387, tokenIndex387, false)
+			position, tokenIndex = position387, tokenIndex387
 			return false
 		},
-		/* 54 lNull <- <((('n' 'u' 'l' 'l') / ('N' 'u' 'l' 'l') / ('N' 'U' 'L' 'L')) Action44)> */
-		nil,
-		/* 55 regex <- <((!('/' / '\\') .) / ('\\' .))*> */
+		/* 65 lNull <- <((('n' 'u' 'l' 'l') / ('N' 'u' 'l' 'l') / ('N' 'U' 'L' 'L')) Action59)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{65, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position390, tokenIndex390 := position, tokenIndex
+			{
+				position391 := position
+				{
+					position392, tokenIndex392 := position, tokenIndex
+					if buffer[position] != 'n' {
+						goto l393
+					}
+					position++
+					if buffer[position] != 'u' {
+						goto l393
+					}
+					position++
+					if buffer[position] != 'l' {
+						goto l393
+					}
+					position++
+					if buffer[position] != 'l' {
+						goto l393
+					}
+					position++
+					goto l392
+				l393:
+					position, tokenIndex = position392, tokenIndex392
+					if buffer[position] != 'N' {
+						goto l394
+					}
+					position++
+					if buffer[position] != 'u' {
+						goto l394
+					}
+					position++
+					if buffer[position] != 'l' {
+						goto l394
+					}
+					position++
+					if buffer[position] != 'l' {
+						goto l394
+					}
+					position++
+					goto l392
+				l394:
+					position, tokenIndex = position392, tokenIndex392
+					if buffer[position] != 'N' {
+						goto l390
+					}
+					position++
+					if buffer[position] != 'U' {
+						goto l390
+					}
+					position++
+					if buffer[position] != 'L' {
+						goto l390
+					}
+					position++
+					if buffer[position] != 'L' {
+						goto l390
+					}
+					position++
+				}
+			l392:
+				{
+					add(ruleAction59, position)
+				}
+				add(rulelNull, position391)
+			}
+			memoize(65, position390, tokenIndex390, true)
+			return true
+		l390:
+			memoize(65, position390, tokenIndex390, false)
+			position, tokenIndex = position390, tokenIndex390
+			return false
+		},
+		/* 66 regex <- <((!('/' / '\\') .) / ('\\' .))*> */
 		nil,
-		/* 56 squareBracketStart <- <('[' space)> */
+		/* 67 squareBracketStart <- <('[' space)> */
 		nil,
-		/* 57 squareBracketEnd <- <(space ']')> */
+		/* 68 squareBracketEnd <- <(space ']')> */
 		nil,
-		/* 58 scriptSelectorStart <- <('(' space)> */
+		/* 69 scriptSelectorStart <- <('(' space)> */
 		nil,
-		/* 59 scriptSelectorEnd <- <(space ')')> */
+		/* 70 scriptSelectorEnd <- <(space ')')> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{59, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{70, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position330, tokenIndex330 := position, tokenIndex
+			position400, tokenIndex400 := position, tokenIndex
 			{
-				position331 := position
+				position401 := position
 				_rules[rulespace]()
 				if buffer[position] != ')' {
-					goto l330
+					goto l400
 				}
 				position++
-				add(rulescriptSelectorEnd, position331)
+				add(rulescriptSelectorEnd, position401)
 			}
-			memoize(59, position330, tokenIndex330, true)
+			memoize(70, position400, tokenIndex400, true)
 			return true
-		l330:
-			memoize(59, position330, tokenIndex330, false)
-			position, tokenIndex = position330, tokenIndex330
+		l400:
+			memoize(70, position400, tokenIndex400, false)
+			position, tokenIndex = position400, tokenIndex400
 			return false
 		},
-		/* 60 filterSelectorStart <- <('?' '(' space)> */
+		/* 71 filterSelectorStart <- <('?' '(' space)> */
 		nil,
-		/* 61 filterSelectorEnd <- <(space ')')> */
+		/* 72 filterSelectorEnd <- <(space ')')> */
 		nil,
-		/* 62 subQueryStart <- <('(' space)> */
+		/* 73 subQueryStart <- <('(' space)> */
 		nil,
-		/* 63 subQueryEnd <- <(space ')')> */
+		/* 74 subQueryEnd <- <(space ')')> */
 		nil,
-		/* 64 space <- <' '*> */
+		/* 75 functionArgumentsStart <- <('(' space)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{64, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{75, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position406, tokenIndex406 := position, tokenIndex
+			{
+				position407 := position
+				if buffer[position] != '(' {
+					goto l406
+				}
+				position++
+				_rules[rulespace]()
+				add(rulefunctionArgumentsStart, position407)
+			}
+			memoize(75, position406, tokenIndex406, true)
+			return true
+		l406:
+			memoize(75, position406, tokenIndex406, false)
+			position, tokenIndex = position406, tokenIndex406
+			return false
+		},
+		/* 76 functionArgumentsEnd <- <(space ')')> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{76, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position408, tokenIndex408 := position, tokenIndex
+			{
+				position409 := position
+				_rules[rulespace]()
+				if buffer[position] != ')' {
+					goto l408
+				}
+				position++
+				add(rulefunctionArgumentsEnd, position409)
+			}
+			memoize(76, position408, tokenIndex408, true)
+			return true
+		l408:
+			memoize(76, position408, tokenIndex408, false)
+			position, tokenIndex = position408, tokenIndex408
+			return false
+		},
+		/* 77 space <- <' '*> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{77, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position336, tokenIndex336 := position, tokenIndex
+			position410, tokenIndex410 := position, tokenIndex
 			{
-				position337 := position
-			l338:
+				position411 := position
+			l412:
 				{
-					position339, tokenIndex339 := position, tokenIndex
+					position413, tokenIndex413 := position, tokenIndex
 					if buffer[position] != ' ' {
-						goto l339
+						goto l413
 					}
 					position++
-					goto l338
-				l339:
-					position, tokenIndex = position339, tokenIndex339
+					goto l412
+				l413:
+					position, tokenIndex = position413, tokenIndex413
 				}
-				add(rulespace, position337)
+				add(rulespace, position411)
 			}
-			memoize(64, position336, tokenIndex336, true)
+			memoize(77, position410, tokenIndex410, true)
 			return true
 		},
-		/* 66 Action0 <- <{
+		/* 79 Action0 <- <{
 		    p.root = p.deleteRootNodeIdentifier(p.pop().(syntaxNode))
 		    p.setConnectedPath(p.root)
 		}> */
 		nil,
 		nil,
-		/* 68 Action1 <- <{
-		    panic(p.syntaxErr(
-		        begin, msgErrorInvalidSyntaxUnrecognizedInput, buffer))
+		/* 81 Action1 <- <{
+		    panic(p.unrecognizedInputErr(begin, buffer))
 		}> */
 		nil,
-		/* 69 Action2 <- <{
+		/* 82 Action2 <- <{
 		    p.pushRootNodeIdentifier()
 		}> */
 		nil,
-		/* 70 Action3 <- <{
+		/* 83 Action3 <- <{
 		    p.pushRootNodeIdentifier()
 		}> */
 		nil,
-		/* 71 Action4 <- <{
+		/* 84 Action4 <- <{
 		    p.pushCurrentNodeIdentifier()
 		}> */
 		nil,
-		/* 72 Action5 <- <{
+		/* 85 Action5 <- <{
 		    p.setNodeChain()
 		    p.updateRootValueGroup()
 		}> */
 		nil,
-		/* 73 Action6 <- <{
+		/* 86 Action6 <- <{
 		    p.pushRecursiveChildIdentifier(p.pop().(syntaxNode))
 		}> */
 		nil,
-		/* 74 Action7 <- <{
+		/* 87 Action7 <- <{
 		    p.setLastNodePath(text)
 		}> */
 		nil,
-		/* 75 Action8 <- <{
+		/* 88 Action8 <- <{
 		    p.setLastNodePath(text)
 		}> */
 		nil,
-		/* 76 Action9 <- <{
-		    p.pushFunction(text, p.pop().(string))
+		/* 89 Action9 <- <{
+		    p.pushFunction(begin, buffer, text, p.pop().(string), nil)
+		}> */
+		nil,
+		/* 90 Action10 <- <{
+		    arguments := p.pop().(syntaxFunctionArguments)
+		    p.pushFunction(begin, buffer, text, p.pop().(string), arguments)
 		}> */
 		nil,
-		/* 77 Action10 <- <{
+		/* 91 Action11 <- <{
+		    p.push(syntaxFunctionArguments{p.pop().(syntaxFunctionArgument)})
+		}> */
+		nil,
+		/* 92 Action12 <- <{
+		    argument := p.pop().(syntaxFunctionArgument)
+		    arguments := p.pop().(syntaxFunctionArguments)
+		    p.push(append(arguments, argument))
+		}> */
+		nil,
+		/* 93 Action13 <- <{
+		    p.pushFunctionArgumentLiteral(p.pop())
+		}> */
+		nil,
+		/* 94 Action14 <- <{
+		    argument := p.pop().(*syntaxFunctionArgumentPath)
+		    if argument.param.isValueGroup() {
+		        panic(p.syntaxErr(
+		            begin, msgErrorInvalidSyntaxFunctionArgumentValueGroup, buffer))
+		    }
+		    p.push(argument)
+		}> */
+		nil,
+		/* 95 Action15 <- <{
+		    p.saveParams()
+		}> */
+		nil,
+		/* 96 Action16 <- <{
+		    p.loadParams()
+		    p.pushFunctionArgumentPath(p.pop().(syntaxNode))
+		}> */
+		nil,
+		/* 97 Action17 <- <{
 		    p.push(text)
 		}> */
 		nil,
-		/* 78 Action11 <- <{
+		/* 98 Action18 <- <{
 		    p.pushChildSingleIdentifier(p.unescape(text))
 		}> */
 		nil,
-		/* 79 Action12 <- <{
+		/* 99 Action19 <- <{
 		    identifier2 := p.pop().(syntaxNode)
 		    identifier1 := p.pop().(syntaxNode)
 		    p.pushChildMultiIdentifier(identifier1, identifier2)
 		}> */
 		nil,
-		/* 80 Action13 <- <{
+		/* 100 Action20 <- <{
 		    p.pushChildWildcardIdentifier()
 		}> */
 		nil,
-		/* 81 Action14 <- <{
+		/* 101 Action21 <- <{
 		    p.pushChildSingleIdentifier(p.pop().(string))
 		}> */
 		nil,
-		/* 82 Action15 <- <{
+		/* 102 Action22 <- <{
 		    childIndexUnion := p.pop().(*syntaxUnionQualifier)
 		    parentIndexUnion := p.pop().(*syntaxUnionQualifier)
 		    parentIndexUnion.merge(childIndexUnion)
@@ -3232,7 +3987,7 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 		    p.push(parentIndexUnion)
 		}> */
 		nil,
-		/* 83 Action16 <- <{
+		/* 103 Action23 <- <{
 		    step  := p.pop().(*syntaxIndexSubscript)
 		    end   := p.pop().(*syntaxIndexSubscript)
 		    start := p.pop().(*syntaxIndexSubscript)
@@ -3248,43 +4003,43 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 		    }
 		}> */
 		nil,
-		/* 84 Action17 <- <{
+		/* 104 Action24 <- <{
 		    p.pushWildcardSubscript()
 		}> */
 		nil,
-		/* 85 Action18 <- <{
+		/* 105 Action25 <- <{
 		    p.pushUnionQualifier(p.pop().(syntaxSubscript))
 		}> */
 		nil,
-		/* 86 Action19 <- <{
+		/* 106 Action26 <- <{
 		    p.pushOmittedIndexSubscript()
 		}> */
 		nil,
-		/* 87 Action20 <- <{
+		/* 107 Action27 <- <{
 		    p.pushIndexSubscript(text)
 		}> */
 		nil,
-		/* 88 Action21 <- <{
+		/* 108 Action28 <- <{
 		    p.pushScriptQualifier(text)
 		}> */
 		nil,
-		/* 89 Action22 <- <{
+		/* 109 Action29 <- <{
 		    p.pushFilterQualifier(p.pop().(syntaxQuery))
 		}> */
 		nil,
-		/* 90 Action23 <- <{
+		/* 110 Action30 <- <{
 		    rightQuery := p.pop().(syntaxQuery)
 		    leftQuery := p.pop().(syntaxQuery)
 		    p.pushLogicalOr(leftQuery, rightQuery)
 		}> */
 		nil,
-		/* 91 Action24 <- <{
+		/* 111 Action31 <- <{
 		    rightQuery := p.pop().(syntaxQuery)
 		    leftQuery := p.pop().(syntaxQuery)
 		    p.pushLogicalAnd(leftQuery, rightQuery)
 		}> */
 		nil,
-		/* 92 Action25 <- <{
+		/* 112 Action32 <- <{
 		    query := p.pop()
 		    p.push(query)
 
@@ -3293,15 +4048,19 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 		    }
 		    if checkQuery, ok := query.(*syntaxCompareQuery); ok {
 		        leftIsCurrentNode := false
-		        switch checkQuery.leftParam.(type) {
+		        switch leftParam := checkQuery.leftParam.(type) {
 		        case *syntaxQueryParamCurrentNode, *syntaxQueryParamCurrentNodePath:
 		            leftIsCurrentNode = true
+		        case *syntaxQueryParamFunction:
+		            leftIsCurrentNode = leftParam.isCurrentNodeDependent
 		        }
 
 		        rightIsCurrentNode := false
-		        switch checkQuery.rightParam.(type) {
+		        switch rightParam := checkQuery.rightParam.(type) {
 		        case *syntaxQueryParamCurrentNode, *syntaxQueryParamCurrentNodePath:
 		            rightIsCurrentNode = true
+		        case *syntaxQueryParamFunction:
+		            rightIsCurrentNode = rightParam.isCurrentNodeDependent
 		        }
 		        if leftIsCurrentNode && rightIsCurrentNode {
 		            panic(p.syntaxErr(
@@ -3310,61 +4069,101 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 		    }
 		}> */
 		nil,
-		/* 93 Action26 <- <{
+		/* 113 Action33 <- <{
+		    functionTest := p.pop().(syntaxQuery)
+		    p.pushLogicalNot(functionTest)
+		}> */
+		nil,
+		/* 114 Action34 <- <{
 		    jsonpathFilter := p.pop().(syntaxQuery)
 		    p.pushLogicalNot(jsonpathFilter)
 		}> */
 		nil,
-		/* 94 Action27 <- <{
+		/* 115 Action35 <- <{
 		    rightParam := p.pop().(syntaxCompareParameter)
 		    leftParam := p.pop().(syntaxCompareParameter)
 		    p.pushCompareEQ(leftParam, rightParam)
 		}> */
 		nil,
-		/* 95 Action28 <- <{
+		/* 116 Action36 <- <{
 		    rightParam := p.pop().(syntaxCompareParameter)
 		    leftParam := p.pop().(syntaxCompareParameter)
 		    p.pushCompareNE(leftParam, rightParam)
 		}> */
 		nil,
-		/* 96 Action29 <- <{
+		/* 117 Action37 <- <{
 		    rightParam := p.pop().(syntaxCompareParameter)
 		    leftParam := p.pop().(syntaxCompareParameter)
 		    p.pushCompareLE(leftParam, rightParam)
 		}> */
 		nil,
-		/* 97 Action30 <- <{
+		/* 118 Action38 <- <{
 		    rightParam := p.pop().(syntaxCompareParameter)
 		    leftParam := p.pop().(syntaxCompareParameter)
 		    p.pushCompareLT(leftParam, rightParam)
 		}> */
 		nil,
-		/* 98 Action31 <- <{
+		/* 119 Action39 <- <{
 		    rightParam := p.pop().(syntaxCompareParameter)
 		    leftParam := p.pop().(syntaxCompareParameter)
 		    p.pushCompareGE(leftParam, rightParam)
 		}> */
 		nil,
-		/* 99 Action32 <- <{
+		/* 120 Action40 <- <{
 		    rightParam := p.pop().(syntaxCompareParameter)
 		    leftParam := p.pop().(syntaxCompareParameter)
 		    p.pushCompareGT(leftParam, rightParam)
 		}> */
 		nil,
-		/* 100 Action33 <- <{
+		/* 121 Action41 <- <{
 		    leftParam := p.pop().(syntaxCompareParameter)
 		    p.pushCompareRegex(leftParam, text)
 		}> */
 		nil,
-		/* 101 Action34 <- <{
+		/* 122 Action42 <- <{
 		    p.pushCompareParameterLiteral(p.pop())
 		}> */
 		nil,
-		/* 102 Action35 <- <{
+		/* 123 Action43 <- <{
 		    p.pushCompareParameterLiteral(p.pop())
 		}> */
 		nil,
-		/* 103 Action36 <- <{
+		/* 124 Action44 <- <{
+		    function := p.pop().(*syntaxQueryParamFunction)
+		    p.checkFunctionResultType(begin, buffer, function, false)
+		    p.push(function)
+		}> */
+		nil,
+		/* 125 Action45 <- <{
+		    function := p.pop().(*syntaxQueryParamFunction)
+		    p.checkFunctionResultType(begin, buffer, function, true)
+		    p.pushFunctionTest(function)
+		}> */
+		nil,
+		/* 126 Action46 <- <{
+		    arguments := p.pop().([]any)
+		    p.pushFunctionExpression(begin, buffer, text, p.pop().(string), arguments)
+		}> */
+		nil,
+		/* 127 Action47 <- <{
+		    p.push([]any{p.pop()})
+		}> */
+		nil,
+		/* 128 Action48 <- <{
+		    argument := p.pop()
+		    arguments := p.pop().([]any)
+		    p.push(append(arguments, argument))
+		}> */
+		nil,
+		/* 129 Action49 <- <{
+		    p.push([]any{})
+		}> */
+		nil,
+		/* 130 Action50 <- <{
+		    p.pushFunctionArgumentLiteral(p.pop())
+		}> */
+		nil,
+		/* 131 Action51 <- <{
 		    param := p.pop().(syntaxQueryJSONPathParameter)
 		    if param.isValueGroupParameter() {
 		        panic(p.syntaxErr(
@@ -3373,11 +4172,11 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 		    p.push(param)
 		}> */
 		nil,
-		/* 104 Action37 <- <{
+		/* 132 Action52 <- <{
 		    p.saveParams()
 		}> */
 		nil,
-		/* 105 Action38 <- <{
+		/* 133 Action53 <- <{
 		    p.loadParams()
 
 		    node := p.pop().(syntaxNode)
@@ -3394,27 +4193,27 @@ func (p *pegJSONPathParser[U]) Init(options ...func(*pegJSONPathParser[U]) error
 		    }
 		}> */
 		nil,
-		/* 106 Action39 <- <{
+		/* 134 Action54 <- <{
 		    p.push(p.toFloat(text))
 		}> */
 		nil,
-		/* 107 Action40 <- <{
+		/* 135 Action55 <- <{
 		    p.push(true)
 		}> */
 		nil,
-		/* 108 Action41 <- <{
+		/* 136 Action56 <- <{
 		    p.push(false)
 		}> */
 		nil,
-		/* 109 Action42 <- <{
+		/* 137 Action57 <- <{
 		    p.push(p.unescapeSingleQuotedString(text))
 		}> */
 		nil,
-		/* 110 Action43 <- <{
+		/* 138 Action58 <- <{
 		    p.push(p.unescapeDoubleQuotedString(text))
 		}> */
 		nil,
-		/* 111 Action44 <- <{
+		/* 139 Action59 <- <{
 		    p.push(nil)
 		}> */
 		nil,
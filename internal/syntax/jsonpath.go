@@ -1,9 +1,13 @@
 package syntax
 
 import (
+	"context"
+	"slices"
 	"sync"
 
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
 	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
 )
 
 var parseMutex sync.Mutex
@@ -18,13 +22,145 @@ func Retrieve(jsonPath string, src any, config ...config.Config) ([]any, error)
 	return jsonPathFunc(src)
 }
 
+// RetrieveContext returns the retrieved JSON using the given JSONPath.
+func RetrieveContext(ctx context.Context, jsonPath string, src any, config ...config.Config) ([]any, error) {
+	jsonPathFunc, err := ParseContext(jsonPath, config...)
+	if err != nil {
+		return nil, err
+	}
+	return jsonPathFunc(ctx, src)
+}
+
 // Parse returns the parser function using the given JSONPath.
-func Parse(jsonPath string, config ...config.Config) (f func(src any, dst ...*[]any) ([]any, error), err error) {
+func Parse(jsonPath string, config ...config.Config) (func(src any, dst ...*[]any) ([]any, error), error) {
+	executor, err := parse(jsonPath, config...)
+	if err != nil {
+		return nil, err
+	}
+	if executor.isPlain() {
+		return newPlainRetriever(executor.root), nil
+	}
+	return func(src any, dst ...*[]any) ([]any, error) {
+		return executor.execute(context.Background(), src, dst)
+	}, nil
+}
+
+// ParseContext returns the parser function that receives the context using the given JSONPath.
+func ParseContext(jsonPath string, config ...config.Config) (
+	func(ctx context.Context, src any, dst ...*[]any) ([]any, error), error) {
+	executor, err := parse(jsonPath, config...)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, src any, dst ...*[]any) ([]any, error) {
+		return executor.execute(ctx, src, dst)
+	}, nil
+}
+
+// Query represents the compiled JSONPath.
+type Query struct {
+	executor *jsonPathExecutor
+
+	jsonPath      string
+	config        []config.Config
+	traceOnce     sync.Once
+	traceExecutor *jsonPathExecutor
+	traceErr      error
+}
+
+// Compile returns the compiled JSONPath.
+func Compile(jsonPath string, config ...config.Config) (*Query, error) {
+	executor, err := parse(jsonPath, config...)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{executor: executor, jsonPath: jsonPath, config: config}, nil
+}
+
+// Execute returns the retrieved JSON.
+func (q *Query) Execute(ctx context.Context, src any, dst ...*[]any) ([]any, error) {
+	return q.executor.execute(ctx, src, dst)
+}
+
+// ExecuteTrace returns the retrieved JSON together with the records of the filters.
+// The records are returned even if the retrieval fails.
+func (q *Query) ExecuteTrace(ctx context.Context, src any) ([]any, []TraceFilter, error) {
+	q.traceOnce.Do(func() {
+		q.traceExecutor, q.traceErr = parseTrace(q.jsonPath, q.config...)
+	})
+	if q.traceErr != nil {
+		return nil, nil, q.traceErr
+	}
+	return q.traceExecutor.executeTrace(ctx, src)
+}
+
+// ExecutePaths returns the retrieved JSON together with the normalized paths of the values.
+func (q *Query) ExecutePaths(ctx context.Context, src any) ([]any, []string, error) {
+	if q.executor.observer == nil {
+		return q.executor.retrievePaths(ctx, src)
+	}
+
+	ctx = q.executor.observer.OnQueryStart(ctx, q.executor.jsonPath)
+	results, paths, err := q.executor.retrievePaths(ctx, src)
+	q.executor.observer.OnQueryEnd(ctx, q.executor.jsonPath, len(results), err)
+	return results, paths, err
+}
+
+// AST returns the syntax tree of the JSONPath.
+func (q *Query) AST() *ast.Query {
+	return buildASTQuery(ast.RootIdentifier, q.executor.source)
+}
+
+// Plan returns the text representation of the syntax tree that is executed.
+func (q *Query) Plan() string {
+	return writePlan(q.executor.root)
+}
+
+// IsSingular reports whether the JSONPath returns at most one value.
+func (q *Query) IsSingular() bool {
+	return !q.executor.root.isValueGroup()
+}
+
+// HasRootReference reports whether the filters or the function arguments refer to the root node.
+func (q *Query) HasRootReference() bool {
+	return q.executor.hasRootReference
+}
+
+// MemberNames returns the member names selected by the JSONPath in order of appearance.
+func (q *Query) MemberNames() []string {
+	var names []string
+	ast.Inspect(q.AST(), func(node ast.Node) bool {
+		if selector, ok := node.(*ast.NameSelector); ok && !slices.Contains(names, selector.Name) {
+			names = append(names, selector.Name)
+		}
+		return true
+	})
+	return names
+}
+
+type jsonPathExecutor struct {
+	root               syntaxNode
+	source             syntaxNode
+	hasContextFunction bool
+	hasRootReference   bool
+	hoistedCount       int
+	userData           any
+	jsonPath           string
+	observer           config.Observer
+	collectsErrors     bool
+}
+
+func parse(jsonPath string, config ...config.Config) (executor *jsonPathExecutor, err error) {
 	parseMutex.Lock()
+	defer func() {
+		if syntaxErr, ok := err.(errors.ErrorInvalidSyntax); ok {
+			err = locateSyntaxErr(jsonPath, syntaxErr)
+		}
+	}()
 	defer func() {
 		if exception := recover(); exception != nil {
 			if _err, ok := exception.(error); ok {
-				f = nil
+				executor = nil
 				err = _err
 			}
 		}
@@ -40,16 +176,122 @@ func Parse(jsonPath string, config ...config.Config) (f func(src any, dst ...*[]
 		parser.Reset()
 	}
 
+	executor = &jsonPathExecutor{}
+
 	if len(config) > 0 {
 		parser.jsonPathParser.filterFunctions = config[0].FilterFunctions
 		parser.jsonPathParser.aggregateFunctions = config[0].AggregateFunctions
+		parser.jsonPathParser.functions = config[0].Functions
 		parser.jsonPathParser.accessorMode = config[0].AccessorMode
+		switch {
+		case config[0].Unordered:
+			parser.jsonPathParser.keyOrder = keyOrderNone
+		case config[0].DocumentOrder:
+			parser.jsonPathParser.keyOrder = keyOrderDocument
+		}
+		parser.jsonPathParser.observer = config[0].Observer
+		executor.userData = config[0].UserData
 	}
 
+	configuredParser := parser.jsonPathParser
+
 	parser.Parse()
 	parser.Execute()
 
-	root := parser.jsonPathParser.root
+	executor.root = parser.jsonPathParser.root
+	executor.source = parser.jsonPathParser.root
+	executor.hasContextFunction = parser.jsonPathParser.hasContextFunction
+	executor.hasRootReference = parser.jsonPathParser.hasRootReference
+
+	if len(config) > 0 && (config[0].Optimization || config[0].Observer != nil ||
+		config[0].CollectAllErrors || config[0].ContinueOnFunctionError) {
+		// The passes below rewrite the same tree built again, so that the source tree remains for AST.
+		executor.root = rebuildTree(configuredParser)
+	}
+
+	if len(config) > 0 && config[0].Optimization {
+		optimizer := jsonPathOptimizer{keepsWildcards: config[0].CollectAllErrors}
+		executor.root = optimizer.optimize(executor.root)
+		executor.hoistedCount = optimizer.hoistedCount
+	}
+
+	if len(config) > 0 && config[0].Observer != nil {
+		builder := jsonPathObserverBuilder{observer: config[0].Observer}
+		executor.root = builder.build(executor.root)
+		executor.observer = config[0].Observer
+		executor.jsonPath = jsonPath
+	}
+
+	if len(config) > 0 && (config[0].CollectAllErrors || config[0].ContinueOnFunctionError) {
+		builder := jsonPathCollectorBuilder{isFunctionOnly: !config[0].CollectAllErrors}
+		executor.root = builder.build(executor.root)
+		executor.collectsErrors = true
+	}
+
+	return executor, nil
+}
+
+// rebuildTree builds the syntax tree again by the actions on the tokens of the last parse,
+// starting with the parser configured before the parse.
+func rebuildTree(configuredParser jsonPathParser) syntaxNode {
+	parser.jsonPathParser = configuredParser
+	parser.Execute()
+	return parser.jsonPathParser.root
+}
+
+// parseTrace returns the executor that records the filters.
+// The tree is neither optimized, observed nor collecting the errors, so that the records follow the JSONPath as written.
+func parseTrace(jsonPath string, config ...config.Config) (*jsonPathExecutor, error) {
+	if len(config) > 0 {
+		config = slices.Clone(config)
+		config[0].Optimization = false
+		config[0].Observer = nil
+		config[0].CollectAllErrors = false
+		config[0].ContinueOnFunctionError = false
+	}
+	executor, err := parse(jsonPath, config...)
+	if err != nil {
+		return nil, err
+	}
+	builder := jsonPathTraceBuilder{}
+	executor.root = builder.build(executor.root)
+	return executor, nil
+}
+
+func (e *jsonPathExecutor) executeTrace(ctx context.Context, src any) ([]any, []TraceFilter, error) {
+	tracer := &syntaxTracer{}
+	rt := getRuntime(ctx, src, e.userData)
+	rt.trackPath = true
+	rt.tracer = tracer
+	results := []any{}
+	err := e.root.retrieve(rt, src, src, &results)
+	putRuntime(rt)
+
+	if err != nil {
+		return nil, tracer.filters, err
+	}
+	return results, tracer.filters, nil
+}
+
+func (e *jsonPathExecutor) execute(ctx context.Context, src any, dst []*[]any) ([]any, error) {
+	if e.observer == nil {
+		return e.retrieve(ctx, src, dst)
+	}
+
+	ctx = e.observer.OnQueryStart(ctx, e.jsonPath)
+	results, err := e.retrieve(ctx, src, dst)
+	e.observer.OnQueryEnd(ctx, e.jsonPath, len(results), err)
+	return results, err
+}
+
+// isPlain reports whether the retrieval needs neither the runtime nor the observer.
+func (e *jsonPathExecutor) isPlain() bool {
+	return !e.hasContextFunction && e.hoistedCount == 0 && e.observer == nil && !e.collectsErrors
+}
+
+// newPlainRetriever returns the parser function that retrieves without the runtime,
+// which the most of the JSONPaths do not need.
+func newPlainRetriever(root syntaxNode) func(src any, dst ...*[]any) ([]any, error) {
 	return func(src any, dst ...*[]any) ([]any, error) {
 		var buf *[]any
 		usePool := true
@@ -61,7 +303,7 @@ func Parse(jsonPath string, config ...config.Config) (f func(src any, dst ...*[]
 			buf = getNodeSlice()
 		}
 
-		if err := root.retrieve(src, src, buf); err != nil {
+		if err := root.retrieve(nil, src, src, buf); err != nil {
 			if usePool {
 				putNodeSlice(buf)
 			}
@@ -77,5 +319,107 @@ func Parse(jsonPath string, config ...config.Config) (f func(src any, dst ...*[]
 		}
 
 		return *buf, nil
-	}, nil
+	}
+}
+
+func (e *jsonPathExecutor) retrieve(ctx context.Context, src any, dst []*[]any) ([]any, error) {
+	if e.collectsErrors {
+		return e.retrieveAll(ctx, src, dst)
+	}
+
+	var buf *[]any
+	usePool := true
+	if len(dst) > 0 && dst[0] != nil {
+		*(dst[0]) = (*(dst[0]))[:0]
+		buf = dst[0]
+		usePool = false
+	} else {
+		buf = getNodeSlice()
+	}
+
+	var err errors.ErrorRuntime
+	if e.hasContextFunction || e.hoistedCount > 0 || e.observer != nil {
+		rt := getRuntime(ctx, src, e.userData)
+		rt.trackPath = e.hasContextFunction
+		rt.prepareHoisted(e.hoistedCount)
+		err = e.root.retrieve(rt, src, src, buf)
+		putRuntime(rt)
+	} else {
+		err = e.root.retrieve(nil, src, src, buf)
+	}
+
+	if err != nil {
+		if usePool {
+			putNodeSlice(buf)
+		}
+		return nil, err
+	}
+
+	if usePool {
+		res := *buf
+		out := make([]any, len(res))
+		copy(out, res)
+		putNodeSlice(buf)
+		return out, nil
+	}
+
+	return *buf, nil
+}
+
+// retrieveAll returns the partial results together with the errors of the failed branches.
+func (e *jsonPathExecutor) retrieveAll(ctx context.Context, src any, dst []*[]any) ([]any, error) {
+	results := []any{}
+	if len(dst) > 0 && dst[0] != nil {
+		results = (*(dst[0]))[:0]
+	}
+
+	rt := getRuntime(ctx, src, e.userData)
+	rt.trackPath = true
+	rt.prepareHoisted(e.hoistedCount)
+	err := e.root.retrieve(rt, src, src, &results)
+	failures := slices.Clone(rt.failures)
+	putRuntime(rt)
+
+	if len(dst) > 0 && dst[0] != nil {
+		*(dst[0]) = results
+	}
+
+	return e.getPartialResults(results, failures, err)
+}
+
+// retrievePaths returns the retrieved JSON together with the normalized paths recorded in the same order.
+func (e *jsonPathExecutor) retrievePaths(ctx context.Context, src any) ([]any, []string, error) {
+	results := []any{}
+
+	rt := getRuntime(ctx, src, e.userData)
+	rt.trackPath = true
+	rt.pathResults = &results
+	rt.prepareHoisted(e.hoistedCount)
+	err := e.root.retrieve(rt, src, src, &results)
+	paths := rt.resultPaths
+	failures := slices.Clone(rt.failures)
+	putRuntime(rt)
+
+	results, resultErr := e.getPartialResults(results, failures, err)
+	if results == nil {
+		return nil, nil, resultErr
+	}
+	return results, paths, resultErr
+}
+
+// getPartialResults returns the results with the errors of the failed branches if any of them were collected.
+func (e *jsonPathExecutor) getPartialResults(
+	results []any, failures []errors.ErrorBranchFailed, err errors.ErrorRuntime) ([]any, error) {
+
+	if len(failures) == 0 {
+		if err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+
+	if len(results) == 0 {
+		results = nil
+	}
+	return results, errors.NewErrorMultiple(failures)
 }
@@ -0,0 +1,294 @@
+package syntax
+
+import (
+	"strings"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+)
+
+func buildASTQuery(identifier ast.Identifier, node syntaxNode) *ast.Query {
+	return &ast.Query{
+		Identifier: identifier,
+		Segments:   buildASTSegments(nil, node),
+	}
+}
+
+func buildASTSegments(segments []ast.Segment, node syntaxNode) []ast.Segment {
+	for node != nil {
+		switch typedNode := unwrapNode(node).(type) {
+		case *syntaxRootNodeIdentifier, *syntaxCurrentNodeIdentifier:
+
+		case *syntaxRecursiveChildIdentifier:
+			segments = append(segments, &ast.DescendantSegment{
+				Selectors: buildASTSelectors(typedNode.next),
+			})
+			node = typedNode.next
+
+		case *syntaxRecursiveChildNameIdentifier:
+			segments = append(segments, &ast.DescendantSegment{
+				Selectors: buildASTSelectors(typedNode.child),
+			})
+			node = typedNode.child
+
+		case *syntaxChildWildcardChain:
+			for range typedNode.levels {
+				segments = append(segments, &ast.ChildSegment{
+					Selectors: []ast.Selector{&ast.WildcardSelector{}},
+				})
+			}
+
+		case *syntaxAggregateFunction:
+			segments = buildASTSegments(segments, typedNode.param)
+			segments = append(segments, &ast.FunctionSegment{
+				Function:  buildASTFunction(typedNode.name, typedNode.arguments),
+				Aggregate: true,
+			})
+
+		case *syntaxFilterFunction:
+			segments = append(segments, &ast.FunctionSegment{
+				Function: buildASTFunction(typedNode.name, typedNode.arguments),
+			})
+
+		default:
+			segments = append(segments, &ast.ChildSegment{
+				Selectors: buildASTSelectors(node),
+			})
+		}
+
+		node = node.getNext()
+	}
+	return segments
+}
+
+func buildASTSelectors(node syntaxNode) []ast.Selector {
+	switch typedNode := unwrapNode(node).(type) {
+	case *syntaxChildSingleIdentifier:
+		return []ast.Selector{&ast.NameSelector{Name: typedNode.identifier}}
+
+	case *syntaxChildWildcardIdentifier:
+		return []ast.Selector{&ast.WildcardSelector{}}
+
+	case *syntaxChildMultiIdentifier:
+		selectors := make([]ast.Selector, 0, len(typedNode.identifiers))
+		for _, identifier := range typedNode.identifiers {
+			selectors = append(selectors, buildASTSelectors(identifier)...)
+		}
+		return selectors
+
+	case *syntaxUnionQualifier:
+		selectors := make([]ast.Selector, len(typedNode.subscripts))
+		for index, subscript := range typedNode.subscripts {
+			selectors[index] = buildASTSubscript(subscript)
+		}
+		return selectors
+
+	case *syntaxFilterQualifier:
+		return []ast.Selector{&ast.FilterSelector{Expression: buildASTExpression(typedNode.query)}}
+	}
+	return nil
+}
+
+func buildASTSubscript(subscript syntaxSubscript) ast.Selector {
+	switch typedSubscript := subscript.(type) {
+	case *syntaxIndexSubscript:
+		return &ast.IndexSelector{Index: typedSubscript.number}
+	case *syntaxSlicePositiveStepSubscript:
+		return buildASTSlice(typedSubscript.start, typedSubscript.end, typedSubscript.step)
+	case *syntaxSliceNegativeStepSubscript:
+		return buildASTSlice(typedSubscript.start, typedSubscript.end, typedSubscript.step)
+	}
+	return &ast.WildcardSelector{}
+}
+
+func buildASTSlice(start, end, step *syntaxIndexSubscript) *ast.SliceSelector {
+	return &ast.SliceSelector{
+		Start: buildASTSliceIndex(start),
+		End:   buildASTSliceIndex(end),
+		Step:  buildASTSliceIndex(step),
+	}
+}
+
+func buildASTSliceIndex(index *syntaxIndexSubscript) *int {
+	if index.isOmitted {
+		return nil
+	}
+	number := index.number
+	return &number
+}
+
+func buildASTExpression(query syntaxQuery) ast.Expression {
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		return &ast.LogicalOr{
+			Left:  buildASTExpression(typedQuery.leftQuery),
+			Right: buildASTExpression(typedQuery.rightQuery),
+		}
+
+	case *syntaxLogicalAnd:
+		return &ast.LogicalAnd{
+			Left:  buildASTExpression(typedQuery.leftQuery),
+			Right: buildASTExpression(typedQuery.rightQuery),
+		}
+
+	case *syntaxLogicalNot:
+		expression := buildASTExpression(typedQuery.query)
+		// The parser converts '!=' to the negation of '=='.
+		if comparison, ok := expression.(*ast.Comparison); ok && comparison.Operator == ast.EQ {
+			comparison.Operator = ast.NE
+			return comparison
+		}
+		return &ast.LogicalNot{Expression: expression}
+
+	case *syntaxCompareQuery:
+		left := buildASTComparable(typedQuery.leftParam)
+		if regex, ok := typedQuery.comparator.(*syntaxCompareRegex); ok {
+			return &ast.RegexMatch{Left: left, Pattern: unescapeASTRegex(regex.regex.String())}
+		}
+		comparison := &ast.Comparison{
+			Left:     left,
+			Operator: buildASTOperator(typedQuery.comparator),
+			Right:    buildASTComparable(typedQuery.rightParam),
+		}
+		// The parser moves the query that does not depend on the current node to the right side.
+		if _, ok := comparison.Left.(*ast.Literal); ok {
+			comparison.Left, comparison.Right = comparison.Right, comparison.Left
+			comparison.Operator = mirrorASTOperator(comparison.Operator)
+		}
+		return comparison
+
+	case *syntaxQueryFunction:
+		return &ast.FunctionTest{Function: buildASTParamFunction(typedQuery.function)}
+
+	case *syntaxQueryConstant:
+		// The optimizer folds the comparison of the literals into the constant.
+		return &ast.Comparison{
+			Left:     &ast.Literal{Value: true},
+			Operator: ast.EQ,
+			Right:    &ast.Literal{Value: typedQuery.result},
+		}
+
+	case *syntaxQueryHoisted:
+		return buildASTExpression(typedQuery.query)
+
+	case *syntaxQueryTraceFilter:
+		return buildASTExpression(typedQuery.query)
+
+	case *syntaxQueryTraceStep:
+		return buildASTExpression(typedQuery.query)
+	}
+
+	switch comparable := buildASTComparable(query).(type) {
+	case *ast.Query:
+		return &ast.ExistenceTest{Query: comparable}
+	case *ast.Function:
+		return &ast.FunctionTest{Function: comparable}
+	}
+	return nil
+}
+
+// unescapeASTRegex removes the escape of the slashes required by the delimiters.
+func unescapeASTRegex(pattern string) string {
+	var builder strings.Builder
+	for index := 0; index < len(pattern); index++ {
+		if pattern[index] == '\\' && index+1 < len(pattern) {
+			index++
+			if pattern[index] != '/' {
+				builder.WriteByte('\\')
+			}
+		}
+		builder.WriteByte(pattern[index])
+	}
+	return builder.String()
+}
+
+func buildASTOperator(comparator syntaxComparator) ast.Operator {
+	switch comparator.(type) {
+	case *syntaxCompareNumberLT, *syntaxCompareStringLT, *syntaxCompareLT:
+		return ast.LT
+	case *syntaxCompareNumberLE, *syntaxCompareStringLE, *syntaxCompareLE:
+		return ast.LE
+	case *syntaxCompareNumberGT, *syntaxCompareStringGT, *syntaxCompareGT:
+		return ast.GT
+	case *syntaxCompareNumberGE, *syntaxCompareStringGE, *syntaxCompareGE:
+		return ast.GE
+	}
+	return ast.EQ
+}
+
+func mirrorASTOperator(operator ast.Operator) ast.Operator {
+	switch operator {
+	case ast.LT:
+		return ast.GT
+	case ast.LE:
+		return ast.GE
+	case ast.GT:
+		return ast.LT
+	case ast.GE:
+		return ast.LE
+	}
+	return operator
+}
+
+func buildASTComparable(param syntaxCompareParameter) ast.Comparable {
+	switch typedParam := param.(type) {
+	case *syntaxQueryParamLiteral:
+		return &ast.Literal{Value: typedParam.literal[0]}
+	case *syntaxQueryParamRootNode:
+		return buildASTQuery(ast.RootIdentifier, typedParam.param)
+	case *syntaxQueryParamRootNodePath:
+		return buildASTQuery(ast.RootIdentifier, typedParam.param)
+	case *syntaxQueryParamCurrentNode:
+		return buildASTQuery(ast.CurrentIdentifier, typedParam.param)
+	case *syntaxQueryParamCurrentNodePath:
+		return buildASTQuery(ast.CurrentIdentifier, typedParam.param)
+	case *syntaxQueryParamFunction:
+		return buildASTParamFunction(typedParam)
+	case *syntaxQueryParamHoisted:
+		return buildASTComparable(typedParam.param)
+	case *syntaxQueryParamTrace:
+		return buildASTComparable(typedParam.param)
+	}
+	return nil
+}
+
+func buildASTParamFunction(function *syntaxQueryParamFunction) *ast.Function {
+	return buildASTFunction(function.name, function.arguments)
+}
+
+func buildASTFunction(name string, arguments syntaxFunctionArguments) *ast.Function {
+	function := &ast.Function{
+		Name:      name,
+		Arguments: make([]ast.Argument, len(arguments)),
+	}
+	for index, argument := range arguments {
+		function.Arguments[index] = buildASTArgument(argument)
+	}
+	return function
+}
+
+func buildASTArgument(argument syntaxFunctionArgument) ast.Argument {
+	switch typedArgument := argument.(type) {
+	case *syntaxFunctionArgumentLiteral:
+		return &ast.Literal{Value: typedArgument.literal}
+	case *syntaxFunctionArgumentPath:
+		return buildASTQuery(getASTIdentifier(typedArgument.param), typedArgument.param)
+	case *syntaxFunctionArgumentNodes:
+		return buildASTQuery(getASTIdentifier(typedArgument.param), typedArgument.param)
+	case *syntaxFunctionArgumentExistence:
+		return buildASTArgument(typedArgument.argument)
+	case *syntaxFunctionArgumentLogical:
+		if expression, ok := buildASTExpression(typedArgument.query).(ast.Argument); ok {
+			return expression
+		}
+	case *syntaxQueryParamFunction:
+		return buildASTParamFunction(typedArgument)
+	}
+	return nil
+}
+
+func getASTIdentifier(node syntaxNode) ast.Identifier {
+	if _, ok := getHeadNode(node).(*syntaxCurrentNodeIdentifier); ok {
+		return ast.CurrentIdentifier
+	}
+	return ast.RootIdentifier
+}
@@ -0,0 +1,101 @@
+package syntax
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+type nopObserver struct{}
+
+func (nopObserver) OnQueryStart(ctx context.Context, _ string) context.Context { return ctx }
+func (nopObserver) OnNodeVisit(context.Context, string)                        {}
+func (nopObserver) OnFunctionCall(context.Context, string, error)              {}
+func (nopObserver) OnQueryEnd(context.Context, string, int, error)             {}
+
+func TestBuildASTQuery_RewrittenTree(t *testing.T) {
+	testcases := []string{
+		`$.a.b`,
+		`$..a`,
+		`$..a.b`,
+		`$[*][*].a`,
+		`$['a','b'][0,1:2]`,
+		`$[?(@.a == $.b)].c`,
+		`$[?($.b && @.a > 1)].c`,
+		`$[?(@.a == 1 || @.b == 'x')]`,
+		`$[?(!@.a)]`,
+		`$[?(@.a =~ /a\/b/)]`,
+		`$[?(length(@.a) == $.n)]`,
+		`$.a.max().length()`,
+	}
+
+	for _, optimization := range []bool{false, true} {
+		cfg := config.Config{}
+		cfg.SetStandardFunctions()
+		cfg.Optimization = optimization
+		cfg.SetObserver(nopObserver{})
+		cfg.SetCollectAllErrors()
+
+		for _, jsonPath := range testcases {
+			executor, err := parse(jsonPath, cfg)
+			if err != nil {
+				t.Errorf("jsonpath=%s, error=%s\n", jsonPath, err)
+				continue
+			}
+			expected := buildASTQuery(ast.RootIdentifier, executor.source)
+			actual := buildASTQuery(ast.RootIdentifier, executor.root)
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("jsonpath=%s, optimization=%t: expect<%#v> != actual<%#v>\n",
+					jsonPath, optimization, expected, actual)
+			}
+		}
+	}
+}
+
+func TestBuildASTQuery_TracedTree(t *testing.T) {
+	testcases := []string{
+		`$[?(@.a == $.b)].c`,
+		`$[?(1 < @.a && !@.b)]`,
+		`$[?(@.a =~ /a/ || @.b)]`,
+	}
+
+	for _, jsonPath := range testcases {
+		executor, err := parseTrace(jsonPath)
+		if err != nil {
+			t.Errorf("jsonpath=%s, error=%s\n", jsonPath, err)
+			continue
+		}
+		expected := buildASTQuery(ast.RootIdentifier, executor.source)
+		actual := buildASTQuery(ast.RootIdentifier, executor.root)
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("jsonpath=%s: expect<%#v> != actual<%#v>\n", jsonPath, expected, actual)
+		}
+	}
+}
+
+func TestBuildASTQuery_Constant(t *testing.T) {
+	cfg := config.Config{}
+	cfg.SetOptimization()
+
+	executor, err := parse(`$[?(1 == 2)]`, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &ast.Query{
+		Identifier: ast.RootIdentifier,
+		Segments: []ast.Segment{
+			&ast.ChildSegment{Selectors: []ast.Selector{&ast.FilterSelector{Expression: &ast.Comparison{
+				Left:     &ast.Literal{Value: true},
+				Operator: ast.EQ,
+				Right:    &ast.Literal{Value: false},
+			}}}},
+		},
+	}
+	if actual := buildASTQuery(ast.RootIdentifier, executor.root); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expect<%#v> != actual<%#v>\n", expected, actual)
+	}
+}
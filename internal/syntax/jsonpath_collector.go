@@ -0,0 +1,68 @@
+package syntax
+
+// jsonPathCollectorBuilder wraps each node of the syntax tree that selects the results,
+// so that the errors of all the failed branches are recorded in the runtime.
+// The nodes in the filters are not wrapped, because their errors only mean that the candidates do not match.
+// If isFunctionOnly is set, only the functions are wrapped to record their failures.
+type jsonPathCollectorBuilder struct {
+	isFunctionOnly bool
+}
+
+func (b *jsonPathCollectorBuilder) build(root syntaxNode) syntaxNode {
+	return b.collectNode(root)
+}
+
+func (b *jsonPathCollectorBuilder) collectNode(node syntaxNode) syntaxNode {
+	if node == nil {
+		return nil
+	}
+
+	b.collectChildren(node)
+	if b.isFunctionOnly {
+		switch unwrapNode(node).(type) {
+		case *syntaxFilterFunction, *syntaxAggregateFunction:
+		default:
+			return node
+		}
+	}
+	return &syntaxCollectedNode{syntaxNode: node, isFunctionOnly: b.isFunctionOnly}
+}
+
+func (b *jsonPathCollectorBuilder) collectChildren(node syntaxNode) {
+	switch typedNode := unwrapNode(node).(type) {
+	case *syntaxAggregateFunction:
+		typedNode.param = b.collectNode(typedNode.param)
+	case *syntaxRecursiveChildIdentifier:
+		// The node applied to each descendant only searches for the matches,
+		// so its own errors are not the failures.
+		if next := typedNode.getNext(); next != nil {
+			b.collectChildren(next)
+		}
+		return
+	case *syntaxRecursiveChildNameIdentifier:
+		// The child identifier is retrieved by the recursive node itself.
+		b.collectNext(typedNode.child)
+		return
+	}
+
+	b.collectNext(node)
+}
+
+// collectNext wraps the rest of the chain and links it to the node.
+func (b *jsonPathCollectorBuilder) collectNext(node syntaxNode) {
+	next := node.getNext()
+	if next == nil {
+		return
+	}
+
+	collectedNext := b.collectNode(next)
+	node.replaceNext(collectedNext)
+	if multiIdentifier, ok := unwrapNode(node).(*syntaxChildMultiIdentifier); ok {
+		for _, identifier := range multiIdentifier.identifiers {
+			identifier.replaceNext(collectedNext)
+		}
+		if multiIdentifier.isAllWildcard {
+			multiIdentifier.unionQualifier.replaceNext(collectedNext)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package syntax
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// expectedTokenOrder is the order in which the expected tokens are listed.
+var expectedTokenOrder = []string{
+	`end of input`,
+	`'.'`, `'..'`, `'['`, `'?'`, `'('`, `')'`, `','`, `':'`, `'*'`, `'$'`, `'@'`,
+	`'=='`, `'!='`, `'<'`, `'<='`, `'>'`, `'>='`, `'=~'`, `'&&'`, `'||'`, `'!'`,
+	`member name`, `number`, `string`,
+}
+
+// expectedByFailedRule maps the rule that failed at the farthest position to the tokens that it starts with.
+var expectedByFailedRule = map[pegRule][]string{
+	rulejsonpathQuery:         {`'$'`},
+	rulesegment:               {`'.'`, `'..'`, `'['`},
+	rulebracketedSelection:    {`'['`},
+	rulefunction:              {`'.'`},
+	rulememberNameShorthand:   {`member name`},
+	rulewildcardSelector:      {`'*'`},
+	ruleindexNumber:           {`number`},
+	rulesep:                   {`','`},
+	rulesepArraySlice:         {`':'`},
+	rulebasicQuery:            {`'('`},
+	rulelogicNot:              {`'!'`},
+	rulerootIdentifier:        {`'$'`},
+	rulecurrentNodeIdentifier: {`'@'`},
+	rulelNumber:               {`number`},
+	rulelString:               {`string`},
+}
+
+// expectedByMatchedRule maps the rule that matched up to the farthest position to the tokens that may follow it.
+var expectedByMatchedRule = map[pegRule][]string{
+	rulejsonpathQuery:      {`end of input`},
+	rulerootlessQuery:      {`end of input`},
+	rulebracketedSelection: {`'.'`, `'..'`, `'['`},
+	rulequery:              {`')'`},
+	ruleandQuery:           {`'||'`},
+	rulebasicQuery:         {`'&&'`},
+	rulejsonpathFilter:     {`'=='`, `'!='`, `'<'`, `'<='`, `'>'`, `'>='`, `'=~'`},
+}
+
+// getExpectedTokens returns the tokens acceptable at the position, separated by ", ".
+// They are taken from the memoized results of the rules that the parser tried,
+// which are keyed by the index of the rule without ruleUnknown.
+func getExpectedTokens[U Uint](memoization map[memoKey[U]]memo[U], position U) string {
+	var expected []string
+	for key, result := range memoization {
+		rule := pegRule(key.Rule + 1)
+		switch {
+		case !result.Matched && key.Position == position:
+			expected = append(expected, expectedByFailedRule[rule]...)
+		case result.Matched && result.Partial[len(result.Partial)-1].end == position:
+			expected = append(expected, expectedByMatchedRule[rule]...)
+		}
+	}
+
+	var tokens []string
+	for _, token := range expectedTokenOrder {
+		if slices.Contains(expected, token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return strings.Join(tokens, `, `)
+}
+
+func (p *jsonPathParser) setFarthestFailure(position int, expected string) {
+	p.farthestPosition = position
+	p.expectedTokens = expected
+}
+
+// unrecognizedInputErr returns the syntax error for the input from the position that the JSONPath does not match.
+// It is located at the farthest position that the parser reached, which may be after the position.
+func (p *jsonPathParser) unrecognizedInputErr(pos int, buffer string) error {
+	runes := []rune(buffer)
+	position := max(pos, p.farthestPosition)
+
+	err := errors.NewErrorInvalidSyntax(pos, msgErrorInvalidSyntaxUnrecognizedInput, string(runes[pos:]))
+	err.Line, err.Column = getLineColumn(runes, position)
+	err.Hint = getHint(runes, position)
+	// Any token is acceptable in the string literal that is not closed.
+	if _, ok := getUnclosedQuotePosition(runes); !ok {
+		err.Expected = p.expectedTokens
+	}
+	return err
+}
+
+// locateSyntaxErr sets the JSONPath to the syntax error and, unless it is located already, locates it at the position.
+func locateSyntaxErr(jsonPath string, err errors.ErrorInvalidSyntax) errors.ErrorInvalidSyntax {
+	err.JSONPath = jsonPath
+	if err.Line == 0 {
+		runes := []rune(jsonPath)
+		err.Line, err.Column = getLineColumn(runes, min(err.Position, len(runes)))
+	}
+	return err
+}
+
+// getLineColumn returns the line and the column, in runes from 1, of the position.
+func getLineColumn(runes []rune, position int) (int, int) {
+	line, column := 1, 1
+	for _, character := range runes[:position] {
+		if character == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+func getHint(runes []rune, position int) string {
+	if quotePosition, ok := getUnclosedQuotePosition(runes); ok {
+		return fmt.Sprintf(msgHintUnclosedQuote, quotePosition+1)
+	}
+
+	rest := string(runes[position:])
+	if strings.HasPrefix(rest, `=`) && !strings.HasPrefix(rest, `==`) && !strings.HasPrefix(rest, `=~`) {
+		return msgHintAssignment
+	}
+	if strings.HasSuffix(strings.TrimRight(string(runes), ` `), `..`) &&
+		(strings.TrimSpace(rest) == `` || strings.TrimSpace(rest) == `..`) {
+		return msgHintTrailingDescendant
+	}
+	if strings.HasSuffix(strings.TrimRight(string(runes[:position]), ` `), `[`) &&
+		(strings.HasPrefix(rest, `@`) || strings.HasPrefix(rest, `(@`)) {
+		return msgHintMissingFilterMark
+	}
+	return ``
+}
+
+// getUnclosedQuotePosition returns the position of the quote that starts the string literal without the end.
+func getUnclosedQuotePosition(runes []rune) (int, bool) {
+	quote, quotePosition := rune(0), 0
+	for index := 0; index < len(runes); index++ {
+		switch character := runes[index]; {
+		case quote != 0:
+			if character == '\\' {
+				index++
+			} else if character == quote {
+				quote = 0
+			}
+		case character == '\'' || character == '"':
+			quote, quotePosition = character, index
+		case character == '/' && strings.HasSuffix(strings.TrimRight(string(runes[:index]), ` `), `=~`):
+			// The quote in the regular expression does not start the string literal.
+			for index++; index < len(runes) && runes[index] != '/'; index++ {
+				if runes[index] == '\\' {
+					index++
+				}
+			}
+		}
+	}
+	return quotePosition, quote != 0
+}
@@ -0,0 +1,105 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/config"
+
+// jsonPathObserverBuilder wraps each node of the syntax tree, including the nodes in the filters
+// and the function arguments, so that the observer is notified of the retrieval of the node.
+type jsonPathObserverBuilder struct {
+	observer config.Observer
+}
+
+func (b *jsonPathObserverBuilder) build(root syntaxNode) syntaxNode {
+	return b.observeNode(root)
+}
+
+func (b *jsonPathObserverBuilder) observeNode(node syntaxNode) syntaxNode {
+	if node == nil {
+		return nil
+	}
+
+	switch typedNode := node.(type) {
+	case *syntaxFilterQualifier:
+		b.observeQuery(typedNode.query)
+	case *syntaxFilterFunction:
+		b.observeArguments(typedNode.arguments)
+	case *syntaxAggregateFunction:
+		typedNode.param = b.observeNode(typedNode.param)
+		b.observeArguments(typedNode.arguments)
+	}
+
+	b.observeNext(node)
+	return &syntaxObservedNode{syntaxNode: node, observer: b.observer}
+}
+
+// observeNext wraps the rest of the chain and links it to the node.
+func (b *jsonPathObserverBuilder) observeNext(node syntaxNode) {
+	if recursive, ok := node.(*syntaxRecursiveChildNameIdentifier); ok {
+		// The child identifier is retrieved by the recursive node itself.
+		node = recursive.child
+	}
+
+	next := node.getNext()
+	if next == nil {
+		return
+	}
+
+	observedNext := b.observeNode(next)
+	node.replaceNext(observedNext)
+	if multiIdentifier, ok := node.(*syntaxChildMultiIdentifier); ok {
+		for _, identifier := range multiIdentifier.identifiers {
+			identifier.replaceNext(observedNext)
+		}
+		if multiIdentifier.isAllWildcard {
+			multiIdentifier.unionQualifier.replaceNext(observedNext)
+		}
+	}
+}
+
+func (b *jsonPathObserverBuilder) observeArguments(arguments syntaxFunctionArguments) {
+	for _, argument := range arguments {
+		b.observeArgument(argument)
+	}
+}
+
+func (b *jsonPathObserverBuilder) observeArgument(argument syntaxFunctionArgument) {
+	switch typedArgument := argument.(type) {
+	case *syntaxFunctionArgumentPath:
+		typedArgument.param = b.observeNode(typedArgument.param)
+	case *syntaxFunctionArgumentNodes:
+		typedArgument.param = b.observeNode(typedArgument.param)
+	case *syntaxFunctionArgumentExistence:
+		b.observeArgument(typedArgument.argument)
+	case *syntaxFunctionArgumentLogical:
+		b.observeQuery(typedArgument.query)
+	case *syntaxQueryParamFunction:
+		b.observeArguments(typedArgument.arguments)
+	}
+}
+
+func (b *jsonPathObserverBuilder) observeQuery(query syntaxQuery) {
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		b.observeQuery(typedQuery.leftQuery)
+		b.observeQuery(typedQuery.rightQuery)
+	case *syntaxLogicalAnd:
+		b.observeQuery(typedQuery.leftQuery)
+		b.observeQuery(typedQuery.rightQuery)
+	case *syntaxLogicalNot:
+		b.observeQuery(typedQuery.query)
+	case *syntaxCompareQuery:
+		b.observeQuery(typedQuery.leftParam)
+		b.observeQuery(typedQuery.rightParam)
+	case *syntaxQueryHoisted:
+		b.observeQuery(typedQuery.query)
+	case *syntaxQueryParamHoisted:
+		b.observeQuery(typedQuery.param)
+	case *syntaxQueryFunction:
+		b.observeArguments(typedQuery.function.arguments)
+	case *syntaxQueryParamFunction:
+		b.observeArguments(typedQuery.arguments)
+	case *syntaxQueryParamCurrentNodePath:
+		typedQuery.param = b.observeNode(typedQuery.param)
+	case *syntaxQueryParamRootNodePath:
+		typedQuery.param = b.observeNode(typedQuery.param)
+	}
+}
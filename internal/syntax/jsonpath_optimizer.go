@@ -0,0 +1,378 @@
+package syntax
+
+// jsonPathOptimizer rewrites the syntax tree built by the parser into the tree that returns
+// the same results with less work.
+//   - The filter queries on the literals only are folded into the constants.
+//   - The filter queries and the parameters that refer to the root node only are hoisted,
+//     so that they are computed once per retrieval.
+//   - The consecutive wildcards such as `[*][*]` are collapsed into one node.
+//   - The recursive descent of a member name such as `..name` looks up the key in each object.
+//   - The operands of `&&` and `||` are ordered by the estimated cost,
+//     so that the cheaper operand decides the result first.
+//
+// If keepsWildcards is set, the wildcards are not collapsed,
+// so that the collector can record the failure of each of them.
+type jsonPathOptimizer struct {
+	hoistedCount   int
+	keepsWildcards bool
+}
+
+func (o *jsonPathOptimizer) optimize(root syntaxNode) syntaxNode {
+	return o.optimizeNode(root)
+}
+
+func (o *jsonPathOptimizer) optimizeNode(node syntaxNode) syntaxNode {
+	if node == nil {
+		return nil
+	}
+
+	switch typedNode := node.(type) {
+	case *syntaxChildWildcardIdentifier:
+		if o.keepsWildcards {
+			break
+		}
+		if chain := o.collapseWildcards(typedNode); chain != nil {
+			node = chain
+		}
+	case *syntaxRecursiveChildIdentifier:
+		if child, ok := typedNode.next.(*syntaxChildSingleIdentifier); ok {
+			node = &syntaxRecursiveChildNameIdentifier{
+				syntaxBasicNode: typedNode.syntaxBasicNode,
+				child:           child,
+			}
+		}
+	case *syntaxFilterQualifier:
+		typedNode.query = o.optimizeQuery(typedNode.query)
+	case *syntaxFilterFunction:
+		o.optimizeArguments(typedNode.arguments)
+	case *syntaxAggregateFunction:
+		typedNode.param = o.optimizeNode(typedNode.param)
+		o.optimizeArguments(typedNode.arguments)
+	}
+
+	o.optimizeNext(node)
+	return node
+}
+
+// optimizeNext optimizes the rest of the chain and links it to the node.
+func (o *jsonPathOptimizer) optimizeNext(node syntaxNode) {
+	if recursive, ok := node.(*syntaxRecursiveChildNameIdentifier); ok {
+		// The child identifier is retrieved by the recursive node itself.
+		node = recursive.child
+	}
+
+	next := node.getNext()
+	if next == nil {
+		return
+	}
+
+	optimizedNext := o.optimizeNode(next)
+	if optimizedNext == next {
+		return
+	}
+
+	node.replaceNext(optimizedNext)
+	if multiIdentifier, ok := node.(*syntaxChildMultiIdentifier); ok {
+		for _, identifier := range multiIdentifier.identifiers {
+			identifier.replaceNext(optimizedNext)
+		}
+		if multiIdentifier.isAllWildcard {
+			multiIdentifier.unionQualifier.replaceNext(optimizedNext)
+		}
+	}
+}
+
+// collapseWildcards returns the chain of the consecutive wildcards, or nil if there is only one.
+func (o *jsonPathOptimizer) collapseWildcards(wildcard *syntaxChildWildcardIdentifier) syntaxNode {
+	levels := []*syntaxChildWildcardIdentifier{wildcard}
+	for {
+		next, ok := levels[len(levels)-1].next.(*syntaxChildWildcardIdentifier)
+		if !ok {
+			break
+		}
+		levels = append(levels, next)
+	}
+
+	if len(levels) == 1 {
+		return nil
+	}
+
+	return &syntaxChildWildcardChain{
+		syntaxBasicNode: levels[len(levels)-1].syntaxBasicNode,
+		levels:          levels,
+	}
+}
+
+func (o *jsonPathOptimizer) optimizeArguments(arguments syntaxFunctionArguments) {
+	for _, argument := range arguments {
+		o.optimizeArgument(argument)
+	}
+}
+
+func (o *jsonPathOptimizer) optimizeArgument(argument syntaxFunctionArgument) {
+	switch typedArgument := argument.(type) {
+	case *syntaxFunctionArgumentPath:
+		typedArgument.param = o.optimizeNode(typedArgument.param)
+	case *syntaxFunctionArgumentNodes:
+		typedArgument.param = o.optimizeNode(typedArgument.param)
+	case *syntaxFunctionArgumentExistence:
+		o.optimizeArgument(typedArgument.argument)
+	case *syntaxFunctionArgumentLogical:
+		typedArgument.query = o.optimizeQuery(typedArgument.query)
+	case *syntaxQueryParamFunction:
+		o.optimizeArguments(typedArgument.arguments)
+	}
+}
+
+func (o *jsonPathOptimizer) optimizeQuery(query syntaxQuery) syntaxQuery {
+	if o.isLiteralQuery(query) {
+		return &syntaxQueryConstant{result: o.evaluateLiteralQuery(query)}
+	}
+
+	if o.isRootQuery(query) {
+		return o.hoistQuery(o.normalizeRootQuery(query))
+	}
+
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		return o.optimizeLogicalOr(typedQuery)
+	case *syntaxLogicalAnd:
+		return o.optimizeLogicalAnd(typedQuery)
+	case *syntaxLogicalNot:
+		typedQuery.query = o.optimizeQuery(typedQuery.query)
+		if constant, ok := typedQuery.query.(*syntaxQueryConstant); ok {
+			return &syntaxQueryConstant{result: !constant.result}
+		}
+	case *syntaxCompareQuery:
+		typedQuery.leftParam = o.optimizeParam(typedQuery.leftParam)
+		if o.isRootParam(typedQuery.rightParam) {
+			typedQuery.rightParam = &syntaxQueryParamHoisted{param: typedQuery.rightParam, slot: o.nextSlot()}
+		}
+	case *syntaxQueryFunction:
+		o.optimizeArguments(typedQuery.function.arguments)
+	case *syntaxQueryParamCurrentNodePath:
+		typedQuery.param = o.optimizeNode(typedQuery.param)
+	}
+
+	return query
+}
+
+func (o *jsonPathOptimizer) optimizeParam(param syntaxCompareParameter) syntaxCompareParameter {
+	switch typedParam := param.(type) {
+	case *syntaxQueryParamCurrentNodePath:
+		typedParam.param = o.optimizeNode(typedParam.param)
+	case *syntaxQueryParamFunction:
+		o.optimizeArguments(typedParam.arguments)
+	}
+	return param
+}
+
+func (o *jsonPathOptimizer) optimizeLogicalOr(query *syntaxLogicalOr) syntaxQuery {
+	query.leftQuery = o.optimizeQuery(query.leftQuery)
+	query.rightQuery = o.optimizeQuery(query.rightQuery)
+
+	for _, operands := range [][2]syntaxQuery{
+		{query.leftQuery, query.rightQuery}, {query.rightQuery, query.leftQuery}} {
+		if constant, ok := operands[0].(*syntaxQueryConstant); ok {
+			if constant.result {
+				return constant
+			}
+			return operands[1]
+		}
+	}
+
+	if o.estimateCost(query.rightQuery) < o.estimateCost(query.leftQuery) {
+		query.leftQuery, query.rightQuery = query.rightQuery, query.leftQuery
+	}
+	return query
+}
+
+func (o *jsonPathOptimizer) optimizeLogicalAnd(query *syntaxLogicalAnd) syntaxQuery {
+	query.leftQuery = o.optimizeQuery(query.leftQuery)
+	query.rightQuery = o.optimizeQuery(query.rightQuery)
+
+	for _, operands := range [][2]syntaxQuery{
+		{query.leftQuery, query.rightQuery}, {query.rightQuery, query.leftQuery}} {
+		if constant, ok := operands[0].(*syntaxQueryConstant); ok {
+			if !constant.result {
+				return constant
+			}
+			return operands[1]
+		}
+	}
+
+	if o.estimateCost(query.rightQuery) < o.estimateCost(query.leftQuery) {
+		query.leftQuery, query.rightQuery = query.rightQuery, query.leftQuery
+	}
+	return query
+}
+
+func (o *jsonPathOptimizer) nextSlot() int {
+	slot := o.hoistedCount
+	o.hoistedCount++
+	return slot
+}
+
+func (o *jsonPathOptimizer) hoistQuery(query syntaxQuery) syntaxQuery {
+	return &syntaxQueryHoisted{query: query, slot: o.nextSlot()}
+}
+
+// isLiteralQuery reports whether the query consists only of the comparisons between the literals.
+func (o *jsonPathOptimizer) isLiteralQuery(query syntaxQuery) bool {
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		return o.isLiteralQuery(typedQuery.leftQuery) && o.isLiteralQuery(typedQuery.rightQuery)
+	case *syntaxLogicalAnd:
+		return o.isLiteralQuery(typedQuery.leftQuery) && o.isLiteralQuery(typedQuery.rightQuery)
+	case *syntaxLogicalNot:
+		return o.isLiteralQuery(typedQuery.query)
+	case *syntaxCompareQuery:
+		_, isLeftLiteral := typedQuery.leftParam.(*syntaxQueryParamLiteral)
+		_, isRightLiteral := typedQuery.rightParam.(*syntaxQueryParamLiteral)
+		return isLeftLiteral && isRightLiteral
+	}
+	return false
+}
+
+func (o *jsonPathOptimizer) evaluateLiteralQuery(query syntaxQuery) bool {
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		return o.evaluateLiteralQuery(typedQuery.leftQuery) || o.evaluateLiteralQuery(typedQuery.rightQuery)
+	case *syntaxLogicalAnd:
+		return o.evaluateLiteralQuery(typedQuery.leftQuery) && o.evaluateLiteralQuery(typedQuery.rightQuery)
+	case *syntaxLogicalNot:
+		return !o.evaluateLiteralQuery(typedQuery.query)
+	case *syntaxCompareQuery:
+		// The comparator marks the left values, so the literal of the parser is copied.
+		compareQuery := syntaxCompareQuery{
+			leftParam:  &syntaxQueryParamLiteral{literal: []any{typedQuery.leftParam.(*syntaxQueryParamLiteral).literal[0]}},
+			rightParam: typedQuery.rightParam,
+			comparator: typedQuery.comparator,
+		}
+		computedList := compareQuery.compute(nil, nil, fullList)
+		return len(computedList) != 1 || computedList[0] != emptyEntity
+	}
+	return false
+}
+
+// isRootQuery reports whether the query refers only to the root node and the literals.
+func (o *jsonPathOptimizer) isRootQuery(query syntaxQuery) bool {
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		return o.isRootQuery(typedQuery.leftQuery) && o.isRootQuery(typedQuery.rightQuery)
+	case *syntaxLogicalAnd:
+		return o.isRootQuery(typedQuery.leftQuery) && o.isRootQuery(typedQuery.rightQuery)
+	case *syntaxLogicalNot:
+		return o.isRootQuery(typedQuery.query)
+	case *syntaxCompareQuery:
+		if _, ok := typedQuery.comparator.(*syntaxCompareRegex); ok {
+			return o.isRootParam(typedQuery.leftParam)
+		}
+		return o.isRootOrLiteralParam(typedQuery.leftParam) && o.isRootOrLiteralParam(typedQuery.rightParam)
+	case *syntaxQueryParamRootNodePath:
+		return true
+	}
+	return false
+}
+
+func (o *jsonPathOptimizer) isRootParam(param syntaxCompareParameter) bool {
+	_, ok := param.(*syntaxQueryParamRootNodePath)
+	return ok
+}
+
+func (o *jsonPathOptimizer) isRootOrLiteralParam(param syntaxCompareParameter) bool {
+	_, isLiteral := param.(*syntaxQueryParamLiteral)
+	return isLiteral || o.isRootParam(param)
+}
+
+// normalizeRootQuery moves the literals to the right side of the comparisons,
+// because the comparator marks the values on the left side.
+func (o *jsonPathOptimizer) normalizeRootQuery(query syntaxQuery) syntaxQuery {
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		typedQuery.leftQuery = o.normalizeRootQuery(typedQuery.leftQuery)
+		typedQuery.rightQuery = o.normalizeRootQuery(typedQuery.rightQuery)
+	case *syntaxLogicalAnd:
+		typedQuery.leftQuery = o.normalizeRootQuery(typedQuery.leftQuery)
+		typedQuery.rightQuery = o.normalizeRootQuery(typedQuery.rightQuery)
+	case *syntaxLogicalNot:
+		typedQuery.query = o.normalizeRootQuery(typedQuery.query)
+	case *syntaxCompareQuery:
+		if _, ok := typedQuery.leftParam.(*syntaxQueryParamLiteral); ok {
+			return o.mirrorCompareQuery(typedQuery)
+		}
+	}
+	return query
+}
+
+func (o *jsonPathOptimizer) mirrorCompareQuery(query *syntaxCompareQuery) syntaxQuery {
+	leftParam, rightParam := query.rightParam, query.leftParam
+
+	switch query.comparator.(type) {
+	case *syntaxCompareDeepEQ, *syntaxCompareDirectEQ:
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{})
+	case *syntaxCompareGE, *syntaxCompareNumberGE, *syntaxCompareStringGE:
+		return createCompareLE(leftParam, rightParam)
+	case *syntaxCompareGT, *syntaxCompareNumberGT, *syntaxCompareStringGT:
+		return createCompareLT(leftParam, rightParam)
+	case *syntaxCompareLE, *syntaxCompareNumberLE, *syntaxCompareStringLE:
+		return createCompareGE(leftParam, rightParam)
+	case *syntaxCompareLT, *syntaxCompareNumberLT, *syntaxCompareStringLT:
+		return createCompareGT(leftParam, rightParam)
+	}
+	return query
+}
+
+// estimateCost returns the relative cost to compute the query.
+func (o *jsonPathOptimizer) estimateCost(query syntaxQuery) int {
+	switch typedQuery := query.(type) {
+	case *syntaxQueryConstant, *syntaxQueryHoisted:
+		return 0
+	case *syntaxLogicalOr:
+		return o.estimateCost(typedQuery.leftQuery) + o.estimateCost(typedQuery.rightQuery)
+	case *syntaxLogicalAnd:
+		return o.estimateCost(typedQuery.leftQuery) + o.estimateCost(typedQuery.rightQuery)
+	case *syntaxLogicalNot:
+		return o.estimateCost(typedQuery.query)
+	case *syntaxCompareQuery:
+		return o.estimateComparatorCost(typedQuery.comparator) +
+			o.estimateCost(typedQuery.leftParam) + o.estimateCost(typedQuery.rightParam)
+	case *syntaxQueryParamCurrentNodePath:
+		return o.estimateNodeCost(typedQuery.param)
+	case *syntaxQueryParamRootNodePath:
+		return o.estimateNodeCost(typedQuery.param)
+	case *syntaxQueryFunction, *syntaxQueryParamFunction:
+		return 10
+	}
+	return 0
+}
+
+func (o *jsonPathOptimizer) estimateComparatorCost(comparator syntaxComparator) int {
+	switch comparator.(type) {
+	case *syntaxCompareDirectEQ:
+		return 1
+	case *syntaxCompareNumberGE, *syntaxCompareNumberGT, *syntaxCompareNumberLE, *syntaxCompareNumberLT,
+		*syntaxCompareStringGE, *syntaxCompareStringGT, *syntaxCompareStringLE, *syntaxCompareStringLT:
+		return 2
+	case *syntaxCompareGE, *syntaxCompareGT, *syntaxCompareLE, *syntaxCompareLT:
+		return 3
+	case *syntaxCompareDeepEQ:
+		return 4
+	}
+	return 8
+}
+
+func (o *jsonPathOptimizer) estimateNodeCost(node syntaxNode) int {
+	var cost int
+	for ; node != nil; node = node.getNext() {
+		switch node.(type) {
+		case *syntaxChildSingleIdentifier, *syntaxUnionQualifier:
+			cost++
+		case *syntaxFilterFunction, *syntaxAggregateFunction:
+			cost += 10
+		default:
+			cost += 5
+		}
+	}
+	return cost
+}
@@ -2,9 +2,12 @@ package syntax
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 
+	"github.com/AsaiYusuke/jsonpath/v2/config"
 	"github.com/AsaiYusuke/jsonpath/v2/errors"
 )
 
@@ -16,7 +19,14 @@ type jsonPathParser struct {
 	params             []any
 	filterFunctions    map[string]func(any) (any, error)
 	aggregateFunctions map[string]func([]any) (any, error)
+	functions          map[string]config.Function
+	hasContextFunction bool
+	hasRootReference   bool
 	accessorMode       bool
+	keyOrder           keyOrder
+	observer           config.Observer
+	farthestPosition   int
+	expectedTokens     string
 }
 
 func (p *jsonPathParser) saveParams() {
@@ -132,7 +142,8 @@ func (p *jsonPathParser) _unescapeJSONString(input []byte) (string, error) {
 }
 
 func (p *jsonPathParser) syntaxErr(pos int, reason string, buffer string) error {
-	return errors.NewErrorInvalidSyntax(pos, reason, buffer[pos:])
+	// The position is counted in runes by the parser.
+	return errors.NewErrorInvalidSyntax(pos, reason, string([]rune(buffer)[pos:]))
 }
 
 func (p *jsonPathParser) setNodeChain() {
@@ -238,33 +249,326 @@ func (p *jsonPathParser) updateAccessorMode(checkNode syntaxNode, mode bool) {
 	}
 }
 
-func (p *jsonPathParser) pushFunction(path string, funcName string) {
-	if function, ok := p.filterFunctions[funcName]; ok {
-		functionNode := syntaxFilterFunction{
-			syntaxBasicNode: &syntaxBasicNode{
-				path:         path,
-				accessorMode: p.accessorMode,
-			},
-			function: function,
+func (p *jsonPathParser) pushFunction(
+	pos int, buffer string, path string, funcName string, arguments syntaxFunctionArguments) {
+
+	function, ok := p._findFunction(funcName)
+	if ok {
+		switch function.Kind {
+		case config.FilterKind:
+			p.checkFunctionArity(pos, buffer, function.Arity, len(arguments))
+			getContext := p._getFunctionContext(function)
+			p._pushFilterFunction(path, funcName, func(rt *syntaxRuntime, value any, arguments []any) (any, error) {
+				return function.Filter(getContext(rt), value, arguments)
+			}, arguments)
+			if function.Arity == 0 && !function.WithContext {
+				p.params[len(p.params)-1].(*syntaxFilterFunction).inverse = function.Inverse
+			}
+			return
+		case config.AggregateKind:
+			p.checkFunctionArity(pos, buffer, function.Arity, len(arguments))
+			getContext := p._getFunctionContext(function)
+			p._pushAggregateFunction(path, funcName, func(rt *syntaxRuntime, values []any, arguments []any) (any, error) {
+				return function.Aggregate(getContext(rt), values, arguments)
+			}, arguments)
+			return
 		}
+	}
 
-		p.push(&functionNode)
-		return
+	panic(errors.NewErrorFunctionNotFound(path))
+}
+
+func (p *jsonPathParser) checkFunctionArity(pos int, buffer string, expected, found int) {
+	if expected != found {
+		panic(p.syntaxErr(
+			pos, fmt.Sprintf(msgErrorInvalidSyntaxFunctionArity, expected, found), buffer))
+	}
+}
+
+func (p *jsonPathParser) _pushFilterFunction(
+	path string, name string, function func(*syntaxRuntime, any, []any) (any, error), arguments syntaxFunctionArguments) {
+
+	if observer := p.observer; observer != nil {
+		observedFunction := function
+		function = func(rt *syntaxRuntime, value any, arguments []any) (any, error) {
+			result, err := observedFunction(rt, value, arguments)
+			// The getter of the accessor calls the function after the retrieval without the runtime.
+			if rt != nil {
+				observer.OnFunctionCall(rt.ctx, name, err)
+			}
+			return result, err
+		}
+	}
+
+	p.push(&syntaxFilterFunction{
+		syntaxBasicNode: &syntaxBasicNode{
+			path:         path,
+			accessorMode: p.accessorMode,
+		},
+		name:      name,
+		function:  function,
+		arguments: arguments,
+	})
+}
+
+func (p *jsonPathParser) _pushAggregateFunction(
+	path string, name string, function func(*syntaxRuntime, []any, []any) (any, error), arguments syntaxFunctionArguments) {
+
+	if observer := p.observer; observer != nil {
+		observedFunction := function
+		function = func(rt *syntaxRuntime, values []any, arguments []any) (any, error) {
+			result, err := observedFunction(rt, values, arguments)
+			observer.OnFunctionCall(rt.ctx, name, err)
+			return result, err
+		}
+	}
+
+	p.push(&syntaxAggregateFunction{
+		syntaxBasicNode: &syntaxBasicNode{
+			path:         path,
+			accessorMode: p.accessorMode,
+		},
+		name:      name,
+		function:  function,
+		arguments: arguments,
+	})
+}
+
+func (p *jsonPathParser) pushFunctionArgumentLiteral(literal any) {
+	p.push(&syntaxFunctionArgumentLiteral{
+		literal: literal,
+	})
+}
+
+func (p *jsonPathParser) pushFunctionArgumentPath(node syntaxNode) {
+	if _, ok := getHeadNode(node).(*syntaxRootNodeIdentifier); ok {
+		p.hasRootReference = true
+	}
+	p.updateAccessorMode(node, false)
+	p.setConnectedPath(node)
+	p.push(&syntaxFunctionArgumentPath{
+		param: node,
+	})
+}
+
+func (p *jsonPathParser) pushFunctionExpression(
+	pos int, buffer string, path string, funcName string, arguments []any) {
+
+	paramTypes, resultType, function, ok := p._findExpressionFunction(funcName)
+	if !ok {
+		panic(errors.NewErrorFunctionNotFound(path))
+	}
+
+	p.checkFunctionArity(pos, buffer, len(paramTypes), len(arguments))
+
+	if observer := p.observer; observer != nil {
+		observedFunction := function
+		function = func(rt *syntaxRuntime, arguments []any) (any, error) {
+			result, err := observedFunction(rt, arguments)
+			observer.OnFunctionCall(rt.ctx, funcName, err)
+			return result, err
+		}
+	}
+
+	functionParam := syntaxQueryParamFunction{
+		path:       path,
+		name:       funcName,
+		function:   function,
+		resultType: resultType,
+		arguments:  make(syntaxFunctionArguments, len(arguments)),
+	}
+
+	for index := range arguments {
+		argument, isCurrentNodeDependent, ok := p._convertFunctionExpressionArgument(
+			arguments[index], paramTypes[index])
+		if !ok {
+			panic(p.syntaxErr(pos, fmt.Sprintf(
+				msgErrorInvalidSyntaxFunctionArgumentType, index+1, paramTypes[index]), buffer))
+		}
+		functionParam.arguments[index] = argument
+		functionParam.isCurrentNodeDependent = functionParam.isCurrentNodeDependent || isCurrentNodeDependent
+	}
+
+	p.push(&functionParam)
+}
+
+func (p *jsonPathParser) _findExpressionFunction(funcName string) (
+	[]config.FunctionType, config.FunctionType, func(*syntaxRuntime, []any) (any, error), bool) {
+
+	function, ok := p._findFunction(funcName)
+	if !ok {
+		return nil, 0, nil, false
+	}
+
+	switch function.Kind {
+	case config.FilterKind:
+		getContext := p._getFunctionContext(function)
+		return make([]config.FunctionType, 1+function.Arity), config.ValueType,
+			func(rt *syntaxRuntime, arguments []any) (any, error) {
+				return function.Filter(getContext(rt), arguments[0], arguments[1:])
+			}, true
+	case config.AggregateKind:
+		getContext := p._getFunctionContext(function)
+		paramTypes := make([]config.FunctionType, 1+function.Arity)
+		paramTypes[0] = config.NodesType
+		return paramTypes, config.ValueType,
+			func(rt *syntaxRuntime, arguments []any) (any, error) {
+				nodes, err := toNodesArgument(arguments[0])
+				if err != nil {
+					return nil, err
+				}
+				return function.Aggregate(getContext(rt), nodes, arguments[1:])
+			}, true
+	}
+
+	return function.ParamTypes, function.ResultType,
+		func(_ *syntaxRuntime, arguments []any) (any, error) {
+			for index, paramType := range function.ParamTypes {
+				if paramType == config.NodesType {
+					if _, err := toNodesArgument(arguments[index]); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return function.Expression(arguments)
+		}, true
+}
+
+// _findFunction returns the custom function of the name.
+// The functions set to FilterFunctions and AggregateFunctions of the config are converted to it.
+func (p *jsonPathParser) _findFunction(funcName string) (config.Function, bool) {
+	if function, ok := p.filterFunctions[funcName]; ok {
+		return config.Function{
+			Kind: config.FilterKind,
+			Filter: func(_ config.FunctionContext, value any, _ []any) (any, error) {
+				return function(value)
+			},
+		}, true
 	}
 	if function, ok := p.aggregateFunctions[funcName]; ok {
-		functionNode := syntaxAggregateFunction{
-			syntaxBasicNode: &syntaxBasicNode{
-				path:         path,
-				accessorMode: p.accessorMode,
+		return config.Function{
+			Kind: config.AggregateKind,
+			Aggregate: func(_ config.FunctionContext, values []any, _ []any) (any, error) {
+				return function(values)
 			},
-			function: function,
+		}, true
+	}
+	function, ok := p.functions[funcName]
+	return function, ok
+}
+
+// _getFunctionContext returns the getter of the context passed to the function.
+// Only the context-aware functions make the retrieval use the runtime.
+func (p *jsonPathParser) _getFunctionContext(function config.Function) func(*syntaxRuntime) config.FunctionContext {
+	if !function.WithContext {
+		return func(*syntaxRuntime) config.FunctionContext { return config.FunctionContext{} }
+	}
+	p.hasContextFunction = true
+	return (*syntaxRuntime).functionContext
+}
+
+// getHeadNode returns the identifier at the head of the path,
+// unwrapping the aggregate functions and the nodes wrapped by the observer and the error collector.
+func getHeadNode(node syntaxNode) syntaxNode {
+	for {
+		node = unwrapNode(node)
+		aggregate, ok := node.(*syntaxAggregateFunction)
+		if !ok {
+			return node
+		}
+		node = aggregate.param
+	}
+}
+
+// toNodesArgument returns the nodes passed to the aggregate function.
+// The expression function declared to return NodesType may return the value that is not the nodes.
+func toNodesArgument(argument any) ([]any, error) {
+	nodes, ok := argument.([]any)
+	if !ok {
+		if argument == nil {
+			return nil, fmt.Errorf(msgErrorFunctionNodesArgument, msgTypeNull)
+		}
+		return nil, fmt.Errorf(msgErrorFunctionNodesArgument, reflect.TypeOf(argument))
+	}
+	return nodes, nil
+}
+
+func (p *jsonPathParser) _convertFunctionExpressionArgument(
+	argument any, paramType config.FunctionType) (syntaxFunctionArgument, bool, bool) {
+
+	switch typedArgument := argument.(type) {
+	case *syntaxFunctionArgumentLiteral:
+		switch paramType {
+		case config.ValueType:
+			return typedArgument, false, true
+		case config.NumberType:
+			_, isNumber := typedArgument.literal.(float64)
+			return typedArgument, false, isNumber
+		case config.StringType:
+			_, isString := typedArgument.literal.(string)
+			return typedArgument, false, isString
+		}
+
+	case *syntaxFunctionArgumentPath:
+		_, isCurrentNodeDependent := getHeadNode(typedArgument.param).(*syntaxCurrentNodeIdentifier)
+		switch paramType {
+		case config.ValueType, config.NumberType, config.StringType:
+			return typedArgument, isCurrentNodeDependent, !typedArgument.param.isValueGroup()
+		case config.NodesType:
+			return &syntaxFunctionArgumentNodes{param: typedArgument.param}, isCurrentNodeDependent, true
+		case config.LogicalType:
+			return &syntaxFunctionArgumentExistence{
+				argument: &syntaxFunctionArgumentNodes{param: typedArgument.param},
+			}, isCurrentNodeDependent, true
 		}
 
-		p.push(&functionNode)
+	case *syntaxQueryParamFunction:
+		if paramType == config.LogicalType && typedArgument.resultType == config.NodesType {
+			return &syntaxFunctionArgumentExistence{
+				argument: typedArgument,
+			}, typedArgument.isCurrentNodeDependent, true
+		}
+		return typedArgument, typedArgument.isCurrentNodeDependent, paramType == typedArgument.resultType ||
+			paramType == config.ValueType && isValueType(typedArgument.resultType)
+
+	case syntaxQuery:
+		// The logical expression is evaluated for each current node.
+		return &syntaxFunctionArgumentLogical{query: typedArgument}, true, paramType == config.LogicalType
+	}
+
+	return nil, false, false
+}
+
+// isValueType reports whether the type is the single JSON value, including the number and the string.
+func isValueType(functionType config.FunctionType) bool {
+	switch functionType {
+	case config.ValueType, config.NumberType, config.StringType:
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) checkFunctionResultType(
+	pos int, buffer string, function *syntaxQueryParamFunction, isTest bool) {
+
+	if isTest {
+		if function.resultType != config.LogicalType && function.resultType != config.NodesType {
+			panic(p.syntaxErr(pos, fmt.Sprintf(
+				msgErrorInvalidSyntaxFunctionResultType, msgTypeLogicalOrNodes, function.resultType), buffer))
+		}
 		return
 	}
 
-	panic(errors.NewErrorFunctionNotFound(path))
+	if !isValueType(function.resultType) {
+		panic(p.syntaxErr(pos, fmt.Sprintf(
+			msgErrorInvalidSyntaxFunctionResultType, config.ValueType, function.resultType), buffer))
+	}
+}
+
+func (p *jsonPathParser) pushFunctionTest(function *syntaxQueryParamFunction) {
+	p.push(&syntaxQueryFunction{
+		function: function,
+	})
 }
 
 func (p *jsonPathParser) pushRootNodeIdentifier() {
@@ -358,6 +662,7 @@ func (p *jsonPathParser) pushChildWildcardIdentifier() {
 			path:         `*`,
 			valueGroup:   true,
 			accessorMode: p.accessorMode,
+			keyOrder:     p.keyOrder,
 		},
 	}
 
@@ -382,6 +687,7 @@ func (p *jsonPathParser) pushRecursiveChildIdentifier(node syntaxNode) {
 			valueGroup:   true,
 			next:         node,
 			accessorMode: p.accessorMode,
+			keyOrder:     p.keyOrder,
 		},
 		nextMapRequired:  nextMapRequired,
 		nextListRequired: nextListRequired,
@@ -407,6 +713,7 @@ func (p *jsonPathParser) pushFilterQualifier(query syntaxQuery) {
 		syntaxBasicNode: &syntaxBasicNode{
 			valueGroup:   true,
 			accessorMode: p.accessorMode,
+			keyOrder:     p.keyOrder,
 		},
 		query: query,
 	}
@@ -486,7 +793,7 @@ func (p *jsonPathParser) pushLogicalNot(query syntaxQuery) {
 	})
 }
 
-func (p *jsonPathParser) _createCompareQuery(
+func createCompareQuery(
 	leftParam, rightParam syntaxCompareParameter,
 	comparator syntaxComparator) syntaxQuery {
 
@@ -506,19 +813,24 @@ func (p *jsonPathParser) pushCompareEQ(
 	if rightLiteralParam, ok := rightParam.(*syntaxQueryParamLiteral); ok {
 		switch rightLiteralParam.literal[0].(type) {
 		case float64:
-			p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
+			p.push(createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
 		case bool:
-			p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
+			p.push(createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
 		case string:
-			p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
+			p.push(createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
 		case nil:
-			p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
+			p.push(createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
 		}
 
 		return
 	}
 
-	p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareDeepEQ{}))
+	if isNumberParam(rightParam) || isStringParam(rightParam) {
+		p.push(createCompareQuery(leftParam, rightParam, &syntaxCompareDirectEQ{}))
+		return
+	}
+
+	p.push(createCompareQuery(leftParam, rightParam, &syntaxCompareDeepEQ{}))
 }
 
 func (p *jsonPathParser) pushCompareNE(
@@ -527,7 +839,23 @@ func (p *jsonPathParser) pushCompareNE(
 	p.push(&syntaxLogicalNot{query: p.pop().(syntaxQuery)})
 }
 
-func (p *jsonPathParser) _isStringLiteral(param syntaxCompareParameter) bool {
+// isNumberParam reports whether the parameter is the number literal or the function that returns the number.
+func isNumberParam(param syntaxCompareParameter) bool {
+	if function, ok := param.(*syntaxQueryParamFunction); ok {
+		return function.resultType == config.NumberType
+	}
+	return isNumberLiteralParam(param)
+}
+
+// isStringParam reports whether the parameter is the string literal or the function that returns the string.
+func isStringParam(param syntaxCompareParameter) bool {
+	if function, ok := param.(*syntaxQueryParamFunction); ok {
+		return function.resultType == config.StringType
+	}
+	return isStringLiteralParam(param)
+}
+
+func isStringLiteralParam(param syntaxCompareParameter) bool {
 	if literalParam, ok := param.(*syntaxQueryParamLiteral); ok {
 		if len(literalParam.literal) == 1 {
 			if _, isString := literalParam.literal[0].(string); isString {
@@ -538,7 +866,7 @@ func (p *jsonPathParser) _isStringLiteral(param syntaxCompareParameter) bool {
 	return false
 }
 
-func (p *jsonPathParser) _isNumberLiteral(param syntaxCompareParameter) bool {
+func isNumberLiteralParam(param syntaxCompareParameter) bool {
 	if literalParam, ok := param.(*syntaxQueryParamLiteral); ok {
 		if len(literalParam.literal) == 1 {
 			if _, isFloat := literalParam.literal[0].(float64); isFloat {
@@ -551,82 +879,90 @@ func (p *jsonPathParser) _isNumberLiteral(param syntaxCompareParameter) bool {
 
 func (p *jsonPathParser) pushCompareGE(
 	leftParam, rightParam syntaxCompareParameter) {
+	p.push(createCompareGE(leftParam, rightParam))
+}
+
+func createCompareGE(
+	leftParam, rightParam syntaxCompareParameter) syntaxQuery {
 	if isLiteralParam(leftParam) && !isLiteralParam(rightParam) {
-		p.pushCompareLE(rightParam, leftParam)
-		return
+		return createCompareLE(rightParam, leftParam)
 	}
 
-	if p._isNumberLiteral(rightParam) {
-		p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareNumberGE{}))
-		return
+	if isNumberParam(rightParam) {
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareNumberGE{})
 	}
 
-	if p._isStringLiteral(rightParam) {
-		p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareStringGE{}))
-		return
+	if isStringParam(rightParam) {
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareStringGE{})
 	}
 
-	p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareGE{}))
+	return createCompareQuery(leftParam, rightParam, &syntaxCompareGE{})
 }
 
 func (p *jsonPathParser) pushCompareGT(
 	leftParam, rightParam syntaxCompareParameter) {
+	p.push(createCompareGT(leftParam, rightParam))
+}
+
+func createCompareGT(
+	leftParam, rightParam syntaxCompareParameter) syntaxQuery {
 	if isLiteralParam(leftParam) && !isLiteralParam(rightParam) {
-		p.pushCompareLT(rightParam, leftParam)
-		return
+		return createCompareLT(rightParam, leftParam)
 	}
 
-	if p._isNumberLiteral(rightParam) {
-		p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareNumberGT{}))
-		return
+	if isNumberParam(rightParam) {
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareNumberGT{})
 	}
 
-	if p._isStringLiteral(rightParam) {
-		p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareStringGT{}))
-		return
+	if isStringParam(rightParam) {
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareStringGT{})
 	}
 
-	p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareGT{}))
+	return createCompareQuery(leftParam, rightParam, &syntaxCompareGT{})
 }
 
 func (p *jsonPathParser) pushCompareLE(
 	leftParam, rightParam syntaxCompareParameter) {
+	p.push(createCompareLE(leftParam, rightParam))
+}
+
+func createCompareLE(
+	leftParam, rightParam syntaxCompareParameter) syntaxQuery {
 	if isLiteralParam(leftParam) && !isLiteralParam(rightParam) {
-		p.pushCompareGE(rightParam, leftParam)
-		return
+		return createCompareGE(rightParam, leftParam)
 	}
 
-	if p._isNumberLiteral(rightParam) {
-		p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareNumberLE{}))
-		return
+	if isNumberParam(rightParam) {
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareNumberLE{})
 	}
 
-	if p._isStringLiteral(rightParam) {
-		p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareStringLE{}))
-		return
+	if isStringParam(rightParam) {
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareStringLE{})
 	}
 
-	p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareLE{}))
+	return createCompareQuery(leftParam, rightParam, &syntaxCompareLE{})
 }
 
 func (p *jsonPathParser) pushCompareLT(
 	leftParam, rightParam syntaxCompareParameter) {
+	p.push(createCompareLT(leftParam, rightParam))
+}
+
+func createCompareLT(
+	leftParam, rightParam syntaxCompareParameter) syntaxQuery {
 	if isLiteralParam(leftParam) && !isLiteralParam(rightParam) {
-		p.pushCompareGT(rightParam, leftParam)
-		return
+		return createCompareGT(rightParam, leftParam)
 	}
 
-	if p._isNumberLiteral(rightParam) {
-		p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareNumberLT{}))
-		return
+	if isNumberParam(rightParam) {
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareNumberLT{})
 	}
 
-	if p._isStringLiteral(rightParam) {
-		p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareStringLT{}))
-		return
+	if isStringParam(rightParam) {
+		return createCompareQuery(leftParam, rightParam, &syntaxCompareStringLT{})
 	}
 
-	p.push(p._createCompareQuery(leftParam, rightParam, &syntaxCompareLT{}))
+	return createCompareQuery(leftParam, rightParam, &syntaxCompareLT{})
 }
 
 func (p *jsonPathParser) pushCompareRegex(
@@ -636,7 +972,7 @@ func (p *jsonPathParser) pushCompareRegex(
 		panic(errors.NewErrorInvalidArgument(regex, err))
 	}
 
-	p.push(p._createCompareQuery(
+	p.push(createCompareQuery(
 		leftParam, &syntaxQueryParamLiteral{
 			literal: []any{`regex`},
 		},
@@ -653,6 +989,7 @@ func (p *jsonPathParser) pushCompareParameterLiteral(text any) {
 }
 
 func (p *jsonPathParser) pushCompareParameterRoot(node syntaxNode) {
+	p.hasRootReference = true
 	p.updateAccessorMode(node, false)
 	if _, ok := node.(*syntaxRootNodeIdentifier); ok {
 		// Fast path: parameter is the root node '$' itself.
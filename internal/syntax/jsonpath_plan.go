@@ -0,0 +1,224 @@
+package syntax
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// planWriter renders the syntax tree that the executor runs, one node per line.
+// The nested queries, parameters and arguments are indented by two spaces.
+type planWriter struct {
+	builder strings.Builder
+}
+
+func writePlan(root syntaxNode) string {
+	writer := planWriter{}
+	writer.writeNode(root, 0)
+	return writer.builder.String()
+}
+
+func (w *planWriter) writeLine(depth int, texts ...string) {
+	w.builder.WriteString(strings.Repeat(`  `, depth))
+	for _, text := range texts {
+		w.builder.WriteString(text)
+	}
+	w.builder.WriteString("\n")
+}
+
+func (w *planWriter) writeNode(node syntaxNode, depth int) {
+	for node != nil {
+		node = unwrapNode(node)
+		switch typedNode := node.(type) {
+		case *syntaxRootNodeIdentifier:
+			w.writeLine(depth, `root $`)
+		case *syntaxCurrentNodeIdentifier:
+			w.writeLine(depth, `current @`)
+		case *syntaxChildSingleIdentifier:
+			w.writeLine(depth, `child `, planName(typedNode.identifier))
+		case *syntaxChildMultiIdentifier:
+			names := make([]string, len(typedNode.identifiers))
+			for index, identifier := range typedNode.identifiers {
+				names[index] = `*`
+				if singleIdentifier, ok := identifier.(*syntaxChildSingleIdentifier); ok {
+					names[index] = planName(singleIdentifier.identifier)
+				}
+			}
+			w.writeLine(depth, `child `, strings.Join(names, `,`))
+		case *syntaxChildWildcardIdentifier:
+			w.writeLine(depth, `wildcard *`)
+		case *syntaxChildWildcardChain:
+			w.writeLine(depth, `wildcard * depth=`, strconv.Itoa(len(typedNode.levels)))
+		case *syntaxRecursiveChildIdentifier:
+			w.writeLine(depth, `descendant ..`)
+		case *syntaxRecursiveChildNameIdentifier:
+			w.writeLine(depth, `descendant ..`, planName(typedNode.child.identifier), ` (key lookup)`)
+			node = typedNode.child
+		case *syntaxUnionQualifier:
+			subscripts := make([]string, len(typedNode.subscripts))
+			for index, subscript := range typedNode.subscripts {
+				subscripts[index] = planSubscript(subscript)
+			}
+			w.writeLine(depth, `union [`, strings.Join(subscripts, `,`), `]`)
+		case *syntaxFilterQualifier:
+			w.writeLine(depth, `filter`)
+			w.writeQuery(typedNode.query, depth+1)
+		case *syntaxFilterFunction:
+			w.writeLine(depth, `function `, typedNode.name, `()`)
+			w.writeArguments(typedNode.arguments, depth+1)
+		case *syntaxAggregateFunction:
+			w.writeLine(depth, `aggregate `, typedNode.name, `()`)
+			w.writeNode(typedNode.param, depth+1)
+			w.writeArguments(typedNode.arguments, depth+1)
+		}
+		node = node.getNext()
+	}
+}
+
+func (w *planWriter) writeQuery(query syntaxQuery, depth int) {
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		w.writeLine(depth, `or`)
+		w.writeQuery(typedQuery.leftQuery, depth+1)
+		w.writeQuery(typedQuery.rightQuery, depth+1)
+	case *syntaxLogicalAnd:
+		w.writeLine(depth, `and`)
+		w.writeQuery(typedQuery.leftQuery, depth+1)
+		w.writeQuery(typedQuery.rightQuery, depth+1)
+	case *syntaxLogicalNot:
+		w.writeLine(depth, `not`)
+		w.writeQuery(typedQuery.query, depth+1)
+	case *syntaxCompareQuery:
+		w.writeLine(depth, `compare `, planComparator(typedQuery.comparator))
+		w.writeQuery(typedQuery.leftParam, depth+1)
+		if _, ok := typedQuery.comparator.(*syntaxCompareRegex); !ok {
+			w.writeQuery(typedQuery.rightParam, depth+1)
+		}
+	case *syntaxQueryConstant:
+		w.writeLine(depth, `constant `, strconv.FormatBool(typedQuery.result))
+	case *syntaxQueryHoisted:
+		w.writeLine(depth, `hoisted #`, strconv.Itoa(typedQuery.slot))
+		w.writeQuery(typedQuery.query, depth+1)
+	case *syntaxQueryParamHoisted:
+		w.writeLine(depth, `hoisted #`, strconv.Itoa(typedQuery.slot))
+		w.writeQuery(typedQuery.param, depth+1)
+	case *syntaxQueryFunction:
+		w.writeQuery(typedQuery.function, depth)
+	case *syntaxQueryParamFunction:
+		w.writeLine(depth, `function `, typedQuery.name, `()`)
+		w.writeArguments(typedQuery.arguments, depth+1)
+	case *syntaxQueryParamLiteral:
+		w.writeLine(depth, `literal `, planLiteral(typedQuery.literal[0]))
+	case *syntaxQueryParamRootNode:
+		w.writeLine(depth, `root $`)
+	case *syntaxQueryParamRootNodePath:
+		w.writeLine(depth, `root $`)
+		w.writeNode(typedQuery.param, depth+1)
+	case *syntaxQueryParamCurrentNode:
+		w.writeLine(depth, `current @`)
+	case *syntaxQueryParamCurrentNodePath:
+		w.writeLine(depth, `current @`)
+		w.writeNode(typedQuery.param, depth+1)
+	}
+}
+
+func (w *planWriter) writeArguments(arguments syntaxFunctionArguments, depth int) {
+	for _, argument := range arguments {
+		w.writeArgument(argument, depth)
+	}
+}
+
+func (w *planWriter) writeArgument(argument syntaxFunctionArgument, depth int) {
+	switch typedArgument := argument.(type) {
+	case *syntaxFunctionArgumentLiteral:
+		w.writeLine(depth, `literal `, planLiteral(typedArgument.literal))
+	case *syntaxFunctionArgumentPath:
+		w.writeNode(typedArgument.param, depth)
+	case *syntaxFunctionArgumentNodes:
+		w.writeNode(typedArgument.param, depth)
+	case *syntaxFunctionArgumentExistence:
+		w.writeArgument(typedArgument.argument, depth)
+	case *syntaxFunctionArgumentLogical:
+		w.writeQuery(typedArgument.query, depth)
+	case *syntaxQueryParamFunction:
+		w.writeQuery(typedArgument, depth)
+	}
+}
+
+func planName(name string) string {
+	var builder strings.Builder
+	builder.WriteString(`['`)
+	writeNormalizedName(&builder, name)
+	builder.WriteString(`']`)
+	return builder.String()
+}
+
+func planLiteral(literal any) string {
+	text, err := json.Marshal(literal)
+	if err != nil {
+		return `?`
+	}
+	return string(text)
+}
+
+func planSubscript(subscript syntaxSubscript) string {
+	switch typedSubscript := subscript.(type) {
+	case *syntaxIndexSubscript:
+		return strconv.Itoa(typedSubscript.number)
+	case *syntaxSlicePositiveStepSubscript:
+		return planSlice(typedSubscript.start, typedSubscript.end, typedSubscript.step)
+	case *syntaxSliceNegativeStepSubscript:
+		return planSlice(typedSubscript.start, typedSubscript.end, typedSubscript.step)
+	}
+	return `*`
+}
+
+func planSlice(start, end, step *syntaxIndexSubscript) string {
+	var builder strings.Builder
+	if !start.isOmitted {
+		builder.WriteString(strconv.Itoa(start.number))
+	}
+	builder.WriteString(`:`)
+	if !end.isOmitted {
+		builder.WriteString(strconv.Itoa(end.number))
+	}
+	builder.WriteString(`:`)
+	builder.WriteString(strconv.Itoa(step.number))
+	return builder.String()
+}
+
+func planComparator(comparator syntaxComparator) string {
+	switch typedComparator := comparator.(type) {
+	case *syntaxCompareDeepEQ:
+		return `== (deep)`
+	case *syntaxCompareDirectEQ:
+		return `==`
+	case *syntaxCompareGE:
+		return `>=`
+	case *syntaxCompareGT:
+		return `>`
+	case *syntaxCompareLE:
+		return `<=`
+	case *syntaxCompareLT:
+		return `<`
+	case *syntaxCompareNumberGE:
+		return `>= (number)`
+	case *syntaxCompareNumberGT:
+		return `> (number)`
+	case *syntaxCompareNumberLE:
+		return `<= (number)`
+	case *syntaxCompareNumberLT:
+		return `< (number)`
+	case *syntaxCompareStringGE:
+		return `>= (string)`
+	case *syntaxCompareStringGT:
+		return `> (string)`
+	case *syntaxCompareStringLE:
+		return `<= (string)`
+	case *syntaxCompareStringLT:
+		return `< (string)`
+	case *syntaxCompareRegex:
+		return `=~ /` + typedComparator.regex.String() + `/`
+	}
+	return `?`
+}
@@ -0,0 +1,217 @@
+package syntax
+
+import (
+	"slices"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+)
+
+// TraceFilter is the record of a filter applied to the elements of an object or an array.
+type TraceFilter struct {
+	Path       string
+	Expression ast.Expression
+	Candidates []TraceCandidate
+}
+
+// TraceCandidate is the record of an element tested by the filter.
+type TraceCandidate struct {
+	Path    string
+	Value   any
+	Matched bool
+	Steps   []TraceStep
+}
+
+// TraceStep is the record of a sub-expression of the filter computed for the candidate.
+// Left and Right are set for the comparisons only.
+type TraceStep struct {
+	Expression ast.Expression
+	Left       *TraceOperand
+	Right      *TraceOperand
+	Matched    bool
+}
+
+// TraceOperand is the computed value of an operand of the comparison.
+// Exists is false when the query of the operand selects nothing.
+type TraceOperand struct {
+	Value  any
+	Exists bool
+}
+
+// syntaxTracer records the filters computed during a retrieval.
+type syntaxTracer struct {
+	filters  []TraceFilter
+	stack    []int
+	operands [][]any
+}
+
+func (t *syntaxTracer) beginFilter(rt *syntaxRuntime, expression ast.Expression, currentList []any) {
+	filter := TraceFilter{
+		Path:       rt.normalizedPath(),
+		Expression: expression,
+		Candidates: make([]TraceCandidate, len(currentList)),
+	}
+	for index := range currentList {
+		filter.Candidates[index].Value = currentList[index]
+		filter.Candidates[index].Path = filter.Path
+		if rt.enterCandidate(index) {
+			filter.Candidates[index].Path = rt.normalizedPath()
+			rt.popPath()
+		}
+	}
+	t.stack = append(t.stack, len(t.filters))
+	t.filters = append(t.filters, filter)
+}
+
+func (t *syntaxTracer) endFilter(computedList []any) {
+	filter := &t.filters[t.stack[len(t.stack)-1]]
+	for index := range filter.Candidates {
+		filter.Candidates[index].Matched = getTracedValue(computedList, index) != emptyEntity
+	}
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// addOperand records the values of an operand of the comparison in progress.
+func (t *syntaxTracer) addOperand(values []any) {
+	t.operands = append(t.operands, slices.Clone(values))
+}
+
+// addStep records the sub-expression for each candidate of the current filter,
+// together with the operands recorded since operandCount.
+// The operands are swapped if the expression shows them in reverse order.
+func (t *syntaxTracer) addStep(
+	expression ast.Expression, computedList []any, operandCount int, isOperandMirrored bool) {
+
+	operands := t.operands[operandCount:]
+	defer func() {
+		clear(operands)
+		t.operands = t.operands[:operandCount]
+	}()
+
+	if len(t.stack) == 0 {
+		return
+	}
+
+	filter := &t.filters[t.stack[len(t.stack)-1]]
+	for index := range filter.Candidates {
+		step := TraceStep{
+			Expression: expression,
+			Matched:    getTracedValue(computedList, index) != emptyEntity,
+		}
+		if len(operands) > 0 {
+			step.Left = newTraceOperand(operands[0], index)
+		}
+		if len(operands) > 1 {
+			step.Right = newTraceOperand(operands[1], index)
+			if isOperandMirrored {
+				step.Left, step.Right = step.Right, step.Left
+			}
+		}
+		filter.Candidates[index].Steps = append(filter.Candidates[index].Steps, step)
+	}
+}
+
+func newTraceOperand(values []any, index int) *TraceOperand {
+	value := getTracedValue(values, index)
+	if value == emptyEntity {
+		return &TraceOperand{}
+	}
+	return &TraceOperand{Value: value, Exists: true}
+}
+
+// getTracedValue returns the computed value of the candidate.
+// The list of one value is the result common to all the candidates.
+func getTracedValue(computedList []any, index int) any {
+	if len(computedList) == 1 {
+		return computedList[0]
+	}
+	if index < len(computedList) {
+		return computedList[index]
+	}
+	return emptyEntity
+}
+
+// jsonPathTraceBuilder wraps the filter queries of the syntax tree built by the parser,
+// so that the sub-expressions are recorded by the tracer of the runtime.
+type jsonPathTraceBuilder struct{}
+
+func (b *jsonPathTraceBuilder) build(root syntaxNode) syntaxNode {
+	b.traceNode(root)
+	return root
+}
+
+func (b *jsonPathTraceBuilder) traceNode(node syntaxNode) {
+	for ; node != nil; node = node.getNext() {
+		switch typedNode := node.(type) {
+		case *syntaxFilterQualifier:
+			expression := buildASTExpression(typedNode.query)
+			typedNode.query = &syntaxQueryTraceFilter{
+				query:      b.traceQuery(typedNode.query),
+				expression: expression,
+			}
+		case *syntaxAggregateFunction:
+			b.traceNode(typedNode.param)
+		}
+	}
+}
+
+func (b *jsonPathTraceBuilder) traceQuery(query syntaxQuery) syntaxQuery {
+	// The expression is built before the sub-queries are wrapped.
+	expression := buildASTExpression(query)
+	var isOperandMirrored bool
+
+	switch typedQuery := query.(type) {
+	case *syntaxLogicalOr:
+		typedQuery.leftQuery = b.traceQuery(typedQuery.leftQuery)
+		typedQuery.rightQuery = b.traceQuery(typedQuery.rightQuery)
+	case *syntaxLogicalAnd:
+		typedQuery.leftQuery = b.traceQuery(typedQuery.leftQuery)
+		typedQuery.rightQuery = b.traceQuery(typedQuery.rightQuery)
+	case *syntaxLogicalNot:
+		// '!=' is recorded as one comparison rather than the negation of '=='.
+		if compareQuery, ok := typedQuery.query.(*syntaxCompareQuery); ok {
+			if _, ok := expression.(*ast.Comparison); ok {
+				isOperandMirrored = b.isOperandMirrored(compareQuery)
+				b.traceCompareQuery(compareQuery)
+				break
+			}
+		}
+		typedQuery.query = b.traceQuery(typedQuery.query)
+	case *syntaxCompareQuery:
+		isOperandMirrored = b.isOperandMirrored(typedQuery)
+		b.traceCompareQuery(typedQuery)
+	case *syntaxQueryParamCurrentNodePath:
+		b.traceNode(typedQuery.param)
+	case *syntaxQueryParamRootNodePath:
+		b.traceNode(typedQuery.param)
+	}
+
+	return &syntaxQueryTraceStep{query: query, expression: expression, isOperandMirrored: isOperandMirrored}
+}
+
+// isOperandMirrored reports whether the expression of the comparison shows the operands
+// in reverse order, as buildASTExpression moves the literal on the left side to the right side.
+func (b *jsonPathTraceBuilder) isOperandMirrored(query *syntaxCompareQuery) bool {
+	if _, ok := query.comparator.(*syntaxCompareRegex); ok {
+		return false
+	}
+	_, ok := query.leftParam.(*syntaxQueryParamLiteral)
+	return ok
+}
+
+func (b *jsonPathTraceBuilder) traceCompareQuery(query *syntaxCompareQuery) {
+	query.leftParam = b.traceParam(query.leftParam)
+	// The right side of the regular expression is not an operand.
+	if _, ok := query.comparator.(*syntaxCompareRegex); !ok {
+		query.rightParam = b.traceParam(query.rightParam)
+	}
+}
+
+func (b *jsonPathTraceBuilder) traceParam(param syntaxCompareParameter) syntaxCompareParameter {
+	switch typedParam := param.(type) {
+	case *syntaxQueryParamCurrentNodePath:
+		b.traceNode(typedParam.param)
+	case *syntaxQueryParamRootNodePath:
+		b.traceNode(typedParam.param)
+	}
+	return &syntaxQueryParamTrace{param: param}
+}
@@ -19,6 +19,7 @@ type syntaxBasicNode struct {
 	valueGroup       bool
 	next             syntaxNode
 	accessorMode     bool
+	keyOrder         keyOrder
 	errState         *syntaxNodeErrState
 	onceErrState     sync.Once
 }
@@ -56,6 +57,11 @@ func (i *syntaxBasicNode) setNext(next syntaxNode) {
 	}
 }
 
+// replaceNext replaces the following nodes, unlike setNext that appends them to the end.
+func (i *syntaxBasicNode) replaceNext(next syntaxNode) {
+	i.next = next
+}
+
 func (i *syntaxBasicNode) getNext() syntaxNode {
 	return i.next
 }
@@ -81,17 +87,21 @@ func (i *syntaxBasicNode) newErrTypeUnmatched(expected string, current any) erro
 }
 
 func (i *syntaxBasicNode) retrieveAnyValueNext(
-	root any, nextSrc any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root any, nextSrc any, results *[]any) errors.ErrorRuntime {
 
 	if i.next != nil {
-		return i.next.retrieve(root, nextSrc, results)
+		if i.accessorMode && i.isNextReversible() {
+			return i.next.retrieve(rt, root, i.newReadOnlyAccessor(nextSrc), results)
+		}
+		return i.next.retrieve(rt, root, nextSrc, results)
+	}
+
+	if rt.isResultPathRecorded(results) {
+		rt.recordResultPath(nil)
 	}
 
 	if i.accessorMode {
-		*results = append(*results, config.Accessor{
-			Get: func() any { return nextSrc },
-			Set: nil,
-		})
+		*results = append(*results, i.newReadOnlyAccessor(nextSrc))
 		return nil
 	}
 
@@ -100,7 +110,7 @@ func (i *syntaxBasicNode) retrieveAnyValueNext(
 }
 
 func (i *syntaxBasicNode) retrieveMapNext(
-	root any, currentMap map[string]any, key string, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root any, currentMap map[string]any, key string, results *[]any) errors.ErrorRuntime {
 
 	nextNode, ok := currentMap[key]
 	if !ok {
@@ -108,14 +118,24 @@ func (i *syntaxBasicNode) retrieveMapNext(
 	}
 
 	if i.next != nil {
-		return i.next.retrieve(root, nextNode, results)
+		if i.accessorMode && i.isNextReversible() {
+			nextNode = newMapAccessor(currentMap, key)
+		}
+		if rt.isPathTracked() {
+			rt.pushPath(key)
+			err := i.next.retrieve(rt, root, nextNode, results)
+			rt.popPath()
+			return err
+		}
+		return i.next.retrieve(rt, root, nextNode, results)
+	}
+
+	if rt.isResultPathRecorded(results) {
+		rt.recordResultPath(key)
 	}
 
 	if i.accessorMode {
-		*results = append(*results, config.Accessor{
-			Get: func() any { return currentMap[key] },
-			Set: func(value any) { currentMap[key] = value },
-		})
+		*results = append(*results, newMapAccessor(currentMap, key))
 		return nil
 	}
 
@@ -124,17 +144,28 @@ func (i *syntaxBasicNode) retrieveMapNext(
 }
 
 func (i *syntaxBasicNode) retrieveListNext(
-	root any, currentList []any, index int, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root any, currentList []any, index int, results *[]any) errors.ErrorRuntime {
 
 	if i.next != nil {
-		return i.next.retrieve(root, currentList[index], results)
+		nextNode := currentList[index]
+		if i.accessorMode && i.isNextReversible() {
+			nextNode = newListAccessor(currentList, index)
+		}
+		if rt.isPathTracked() {
+			rt.pushPath(index)
+			err := i.next.retrieve(rt, root, nextNode, results)
+			rt.popPath()
+			return err
+		}
+		return i.next.retrieve(rt, root, nextNode, results)
+	}
+
+	if rt.isResultPathRecorded(results) {
+		rt.recordResultPath(index)
 	}
 
 	if i.accessorMode {
-		*results = append(*results, config.Accessor{
-			Get: func() any { return currentList[index] },
-			Set: func(value any) { currentList[index] = value },
-		})
+		*results = append(*results, newListAccessor(currentList, index))
 		return nil
 	}
 
@@ -142,6 +173,84 @@ func (i *syntaxBasicNode) retrieveListNext(
 	return nil
 }
 
+// retrieveAccessorNext passes the accessor to the reversible function that follows,
+// or the value of the accessor to the other nodes.
+func (i *syntaxBasicNode) retrieveAccessorNext(
+	rt *syntaxRuntime, root any, accessor config.Accessor, results *[]any) errors.ErrorRuntime {
+
+	if i.next != nil {
+		if i.isNextReversible() {
+			return i.next.retrieve(rt, root, accessor, results)
+		}
+		return i.next.retrieve(rt, root, accessor.Get(), results)
+	}
+
+	if rt.isResultPathRecorded(results) {
+		rt.recordResultPath(nil)
+	}
+
+	*results = append(*results, accessor)
+	return nil
+}
+
+func (i *syntaxBasicNode) isNextReversible() bool {
+	function, ok := unwrapNode(i.next).(*syntaxFilterFunction)
+	return ok && function.inverse != nil
+}
+
+// unwrapNode returns the node wrapped by the observer and the error collector.
+func unwrapNode(node syntaxNode) syntaxNode {
+	for {
+		switch typedNode := node.(type) {
+		case *syntaxObservedNode:
+			node = typedNode.syntaxNode
+		case *syntaxCollectedNode:
+			node = typedNode.syntaxNode
+		default:
+			return node
+		}
+	}
+}
+
+func (i *syntaxBasicNode) newReadOnlyAccessor(value any) config.Accessor {
+	i.ensureErrState()
+	err := errors.NewErrorReadOnly(&i.errState.basicRuntime)
+	return config.Accessor{
+		Get:    func() any { return value },
+		TryGet: func() (any, error) { return value, nil },
+		Set:    func(any) { panic(err) },
+		TrySet: func(any) error { return err },
+	}
+}
+
+func newMapAccessor(currentMap map[string]any, key string) config.Accessor {
+	return config.Accessor{
+		Get: func() any { return currentMap[key] },
+		TryGet: func() (any, error) {
+			return currentMap[key], nil
+		},
+		Set: func(value any) { currentMap[key] = value },
+		TrySet: func(value any) error {
+			currentMap[key] = value
+			return nil
+		},
+	}
+}
+
+func newListAccessor(currentList []any, index int) config.Accessor {
+	return config.Accessor{
+		Get: func() any { return currentList[index] },
+		TryGet: func() (any, error) {
+			return currentList[index], nil
+		},
+		Set: func(value any) { currentList[index] = value },
+		TrySet: func(value any) error {
+			currentList[index] = value
+			return nil
+		},
+	}
+}
+
 func (i *syntaxBasicNode) setAccessorMode(mode bool) {
 	i.accessorMode = mode
 }
@@ -7,9 +7,12 @@ type syntaxCompareQuery struct {
 }
 
 func (q *syntaxCompareQuery) compute(
-	root any, currentList []any) []any {
+	rt *syntaxRuntime, root any, currentList []any) []any {
 
-	leftValues := q.leftParam.compute(root, currentList)
+	leftValues := q.leftParam.compute(rt, root, currentList)
+	if len(leftValues) == 0 {
+		return emptyList
+	}
 	if len(leftValues) == 1 && leftValues[0] == emptyEntity {
 		if _, ok := q.comparator.(*syntaxCompareDeepEQ); !ok {
 			return emptyList
@@ -17,7 +20,11 @@ func (q *syntaxCompareQuery) compute(
 	}
 
 	// The syntax parser always results in a literal value on the right side as input.
-	rightValue := q.rightParam.compute(root, currentList)[0]
+	rightValues := q.rightParam.compute(rt, root, currentList)
+	if len(rightValues) == 0 {
+		return emptyList
+	}
+	rightValue := rightValues[0]
 
 	if q.comparator.compare(leftValues, rightValue) {
 		return leftValues
@@ -25,7 +32,8 @@ func (q *syntaxCompareQuery) compute(
 
 	if len(leftValues) == 1 && leftValues[0] == emptyEntity && rightValue == emptyEntity {
 		if _, ok := q.comparator.(*syntaxCompareDeepEQ); ok {
-			return currentList
+			// The candidates are not returned, as the logical operators rewrite the returned list in place.
+			return fullList
 		}
 	}
 
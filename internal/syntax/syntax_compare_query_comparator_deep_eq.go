@@ -1,6 +1,6 @@
 package syntax
 
-import "reflect"
+import "github.com/AsaiYusuke/jsonpath/v2/ordered"
 
 type syntaxCompareDeepEQ struct {
 }
@@ -11,7 +11,7 @@ func (c *syntaxCompareDeepEQ) compare(left []any, right any) bool {
 		if left[leftIndex] == emptyEntity {
 			continue
 		}
-		if reflect.DeepEqual(left[leftIndex], right) {
+		if ordered.Equal(left[leftIndex], right) {
 			hasValue = true
 		} else {
 			left[leftIndex] = emptyEntity
@@ -0,0 +1,20 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/errors"
+
+// syntaxFunctionArgumentExistence converts the nodes argument to the logical argument.
+type syntaxFunctionArgumentExistence struct {
+	argument syntaxFunctionArgument
+}
+
+func (a *syntaxFunctionArgumentExistence) evaluate(
+	rt *syntaxRuntime, root, current any) (any, errors.ErrorRuntime) {
+
+	value, err := a.argument.evaluate(rt, root, current)
+	if err != nil {
+		return false, nil
+	}
+
+	nodes, _ := value.([]any)
+	return len(nodes) > 0, nil
+}
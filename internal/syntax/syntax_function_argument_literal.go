@@ -0,0 +1,13 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/errors"
+
+type syntaxFunctionArgumentLiteral struct {
+	literal any
+}
+
+func (a *syntaxFunctionArgumentLiteral) evaluate(
+	_ *syntaxRuntime, _, _ any) (any, errors.ErrorRuntime) {
+
+	return a.literal, nil
+}
@@ -0,0 +1,16 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/errors"
+
+type syntaxFunctionArgumentLogical struct {
+	query syntaxQuery
+}
+
+func (a *syntaxFunctionArgumentLogical) evaluate(
+	rt *syntaxRuntime, root, current any) (any, errors.ErrorRuntime) {
+
+	hasCandidates, candidateKeys := rt.setCandidates(false, nil)
+	computedList := a.query.compute(rt, root, []any{current})
+	rt.setCandidates(hasCandidates, candidateKeys)
+	return computedList[0] != emptyEntity, nil
+}
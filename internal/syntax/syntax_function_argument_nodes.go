@@ -0,0 +1,22 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/errors"
+
+type syntaxFunctionArgumentNodes struct {
+	param syntaxNode
+}
+
+func (a *syntaxFunctionArgumentNodes) evaluate(
+	rt *syntaxRuntime, root, current any) (any, errors.ErrorRuntime) {
+
+	buf := getNodeSlice()
+	defer func() { putNodeSlice(buf) }()
+
+	if a.param.retrieve(rt, root, current, buf) != nil {
+		return []any{}, nil
+	}
+
+	nodes := make([]any, len(*buf))
+	copy(nodes, *buf)
+	return nodes, nil
+}
@@ -0,0 +1,20 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/errors"
+
+type syntaxFunctionArgumentPath struct {
+	param syntaxNode
+}
+
+func (a *syntaxFunctionArgumentPath) evaluate(
+	rt *syntaxRuntime, root, current any) (any, errors.ErrorRuntime) {
+
+	buf := getNodeSlice()
+	defer func() { putNodeSlice(buf) }()
+
+	if err := a.param.retrieve(rt, root, current, buf); err != nil {
+		return nil, err
+	}
+
+	return (*buf)[0], nil
+}
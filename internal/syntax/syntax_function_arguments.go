@@ -0,0 +1,23 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/errors"
+
+type syntaxFunctionArguments []syntaxFunctionArgument
+
+func (a syntaxFunctionArguments) evaluate(
+	rt *syntaxRuntime, root, current any) ([]any, errors.ErrorRuntime) {
+
+	if len(a) == 0 {
+		return nil, nil
+	}
+
+	values := make([]any, len(a))
+	for index := range a {
+		value, err := a[index].evaluate(rt, root, current)
+		if err != nil {
+			return nil, err
+		}
+		values[index] = value
+	}
+	return values, nil
+}
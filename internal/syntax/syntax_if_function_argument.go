@@ -0,0 +1,7 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/errors"
+
+type syntaxFunctionArgument interface {
+	evaluate(rt *syntaxRuntime, root, current any) (any, errors.ErrorRuntime)
+}
@@ -3,7 +3,7 @@ package syntax
 import "github.com/AsaiYusuke/jsonpath/v2/errors"
 
 type syntaxNode interface {
-	retrieve(root, current any, results *[]any) errors.ErrorRuntime
+	retrieve(rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime
 	setPath(path string)
 	getPath() string
 	setValueGroup()
@@ -11,6 +11,7 @@ type syntaxNode interface {
 	setRemainingPath(path string)
 	getRemainingPath() string
 	setNext(next syntaxNode)
+	replaceNext(next syntaxNode)
 	getNext() syntaxNode
 	setAccessorMode(mode bool)
 }
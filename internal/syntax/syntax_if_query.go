@@ -1,5 +1,5 @@
 package syntax
 
 type syntaxQuery interface {
-	compute(root any, currentList []any) []any
+	compute(rt *syntaxRuntime, root any, currentList []any) []any
 }
@@ -0,0 +1,28 @@
+package syntax
+
+import (
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// syntaxCollectedNode records the error of the node in the runtime,
+// together with the normalized path of the current node that the node failed on.
+// If isFunctionOnly is set, only the failures of the function are recorded.
+type syntaxCollectedNode struct {
+	syntaxNode
+
+	isFunctionOnly bool
+}
+
+func (n *syntaxCollectedNode) retrieve(
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
+
+	failureCount := len(rt.failures)
+	err := n.syntaxNode.retrieve(rt, root, current, results)
+	// The error is recorded once, by the deepest node that failed.
+	if err != nil && len(rt.failures) == failureCount {
+		if _, ok := err.(errors.ErrorFunctionFailed); ok || !n.isFunctionOnly {
+			rt.failures = append(rt.failures, errors.NewErrorBranchFailed(rt.normalizedPath(), err))
+		}
+	}
+	return err
+}
@@ -5,17 +5,19 @@ import "github.com/AsaiYusuke/jsonpath/v2/errors"
 type syntaxAggregateFunction struct {
 	*syntaxBasicNode
 
-	function func([]any) (any, error)
-	param    syntaxNode
+	name      string
+	function  func(*syntaxRuntime, []any, []any) (any, error)
+	arguments syntaxFunctionArguments
+	param     syntaxNode
 }
 
 func (f *syntaxAggregateFunction) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
 
 	buf := getNodeSlice()
 	defer func() { putNodeSlice(buf) }()
 
-	if err := f.param.retrieve(root, current, buf); err != nil {
+	if err := f.param.retrieve(rt, root, current, buf); err != nil {
 		return err
 	}
 
@@ -26,10 +28,15 @@ func (f *syntaxAggregateFunction) retrieve(
 		}
 	}
 
-	filteredValue, err := f.function(result)
+	arguments, err := f.arguments.evaluate(rt, root, result)
 	if err != nil {
 		return errors.NewErrorFunctionFailed(f.path, f.remainingPathLen, err)
 	}
 
-	return f.retrieveAnyValueNext(root, filteredValue, results)
+	filteredValue, functionErr := f.function(rt, result, arguments)
+	if functionErr != nil {
+		return errors.NewErrorFunctionFailed(f.path, f.remainingPathLen, functionErr)
+	}
+
+	return f.retrieveAnyValueNext(rt, root, filteredValue, results)
 }
@@ -1,20 +1,84 @@
 package syntax
 
-import "github.com/AsaiYusuke/jsonpath/v2/errors"
+import (
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
 
 type syntaxFilterFunction struct {
 	*syntaxBasicNode
 
-	function func(any) (any, error)
+	name      string
+	function  func(*syntaxRuntime, any, []any) (any, error)
+	inverse   func(any) (any, error)
+	arguments syntaxFunctionArguments
 }
 
 func (f *syntaxFilterFunction) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
 
-	filteredValue, err := f.function(current)
+	if accessor, ok := current.(config.Accessor); ok && f.inverse != nil && f.accessorMode {
+		return f.retrieveAccessor(rt, root, accessor, results)
+	}
+
+	arguments, err := f.arguments.evaluate(rt, root, current)
 	if err != nil {
 		return errors.NewErrorFunctionFailed(f.path, f.remainingPathLen, err)
 	}
 
-	return f.retrieveAnyValueNext(root, filteredValue, results)
+	filteredValue, functionErr := f.function(rt, current, arguments)
+	if functionErr != nil {
+		return errors.NewErrorFunctionFailed(f.path, f.remainingPathLen, functionErr)
+	}
+
+	return f.retrieveAnyValueNext(rt, root, filteredValue, results)
+}
+
+// retrieveAccessor returns the accessor that writes back to the source accessor through the inverse function.
+func (f *syntaxFilterFunction) retrieveAccessor(
+	rt *syntaxRuntime, root any, accessor config.Accessor, results *[]any) errors.ErrorRuntime {
+
+	tryGet := func() (any, error) {
+		value, err := accessor.TryGet()
+		if err != nil {
+			return nil, err
+		}
+		filteredValue, err := f.function(nil, value, nil)
+		if err != nil {
+			return nil, errors.NewErrorFunctionFailed(f.path, f.remainingPathLen, err)
+		}
+		return filteredValue, nil
+	}
+
+	if _, err := tryGet(); err != nil {
+		if functionErr, ok := err.(errors.ErrorFunctionFailed); ok {
+			return functionErr
+		}
+		return errors.NewErrorFunctionFailed(f.path, f.remainingPathLen, err)
+	}
+
+	trySet := func(value any) error {
+		inversedValue, err := f.inverse(value)
+		if err != nil {
+			return errors.NewErrorFunctionFailed(f.path, f.remainingPathLen, err)
+		}
+		return accessor.TrySet(inversedValue)
+	}
+
+	return f.retrieveAccessorNext(rt, root, config.Accessor{
+		Get: func() any {
+			value, err := tryGet()
+			if err != nil {
+				panic(err)
+			}
+			return value
+		},
+		TryGet: tryGet,
+		Set: func(value any) {
+			if err := trySet(value); err != nil {
+				panic(err)
+			}
+		},
+		TrySet: trySet,
+	}, results)
 }
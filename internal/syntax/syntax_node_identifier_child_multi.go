@@ -2,6 +2,7 @@ package syntax
 
 import (
 	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
 )
 
 type syntaxChildMultiIdentifier struct {
@@ -13,31 +14,37 @@ type syntaxChildMultiIdentifier struct {
 }
 
 func (i *syntaxChildMultiIdentifier) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
 
 	if i.isAllWildcard {
 		if _, ok := current.([]any); ok {
 			// If the "current" variable points to the array structure
 			// and only wildcards are specified for qualifier,
 			// then switch to syntaxUnionQualifier.
-			return i.unionQualifier.retrieve(root, current, results)
+			return i.unionQualifier.retrieve(rt, root, current, results)
 		}
 	}
 
-	if srcMap, ok := current.(map[string]any); ok {
-		return i.retrieveMap(root, srcMap, results)
+	switch typedNodes := current.(type) {
+	case map[string]any:
+		return i.retrieveMap(rt, root, typedNodes, typedNodes, results)
+
+	case *ordered.Object:
+		return i.retrieveMap(rt, root, typedNodes, typedNodes.Map(), results)
 	}
 
 	return i.newErrTypeUnmatched(msgTypeObject, current)
 }
 
+// retrieveMap passes the object to each identifier, and looks up srcMap, the members of the object,
+// for the identifiers that fail.
 func (i *syntaxChildMultiIdentifier) retrieveMap(
-	root any, srcMap map[string]any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, object any, srcMap map[string]any, results *[]any) errors.ErrorRuntime {
 
 	var deepestError errors.ErrorRuntime
 
 	for _, identifier := range i.identifiers {
-		if err := identifier.retrieve(root, srcMap, results); len(*results) == 0 && err != nil {
+		if err := identifier.retrieve(rt, root, object, results); len(*results) == 0 && err != nil {
 			if singleIdentifier, ok := identifier.(*syntaxChildSingleIdentifier); ok {
 				if _, ok = srcMap[singleIdentifier.identifier]; !ok {
 					continue
@@ -2,6 +2,7 @@ package syntax
 
 import (
 	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
 )
 
 type syntaxChildSingleIdentifier struct {
@@ -11,10 +12,14 @@ type syntaxChildSingleIdentifier struct {
 }
 
 func (i *syntaxChildSingleIdentifier) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
 
-	if srcMap, ok := current.(map[string]any); ok {
-		return i.retrieveMapNext(root, srcMap, i.identifier, results)
+	switch typedNodes := current.(type) {
+	case map[string]any:
+		return i.retrieveMapNext(rt, root, typedNodes, i.identifier, results)
+
+	case *ordered.Object:
+		return i.retrieveMapNext(rt, root, typedNodes.Map(), i.identifier, results)
 	}
 
 	return i.newErrTypeUnmatched(msgTypeObject, current)
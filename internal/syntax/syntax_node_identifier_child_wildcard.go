@@ -1,7 +1,10 @@
 package syntax
 
 import (
+	"sort"
+
 	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
 )
 
 type syntaxChildWildcardIdentifier struct {
@@ -9,29 +12,39 @@ type syntaxChildWildcardIdentifier struct {
 }
 
 func (i *syntaxChildWildcardIdentifier) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
 
 	switch typedNodes := current.(type) {
 	case map[string]any:
-		return i.retrieveMap(root, typedNodes, results)
+		if i.keyOrder == keyOrderNone {
+			return i.retrieveMapUnordered(rt, root, typedNodes, results)
+		}
+		sortKeys, keyLength := getMapKeys(typedNodes, i.keyOrder)
+		return i.retrieveMap(rt, root, typedNodes, sortKeys, keyLength, results)
+
+	case *ordered.Object:
+		if i.keyOrder == keyOrderNone {
+			return i.retrieveMapUnordered(rt, root, typedNodes.Map(), results)
+		}
+		sortKeys, keyLength := getOrderedKeys(typedNodes, i.keyOrder)
+		return i.retrieveMap(rt, root, typedNodes.Map(), sortKeys, keyLength, results)
 
 	case []any:
-		return i.retrieveList(root, typedNodes, results)
+		return i.retrieveList(rt, root, typedNodes, results)
 
 	default:
 		return i.newErrTypeUnmatched(msgTypeObjectOrArray, current)
 	}
 }
 
-func (i *syntaxChildWildcardIdentifier) retrieveMap(
-	root any, srcMap map[string]any, results *[]any) errors.ErrorRuntime {
+// retrieveMap retrieves the members in order of sortKeys, and puts it back to the pool.
+func (i *syntaxChildWildcardIdentifier) retrieveMap(rt *syntaxRuntime, root any,
+	srcMap map[string]any, sortKeys *sort.StringSlice, keyLength int, results *[]any) errors.ErrorRuntime {
 
 	var deepestError errors.ErrorRuntime
 
-	sortKeys, keyLength := getSortedKeys(srcMap)
-
 	for index := range keyLength {
-		if err := i.retrieveMapNext(root, srcMap, (*sortKeys)[index], results); len(*results) == 0 && err != nil {
+		if err := i.retrieveMapNext(rt, root, srcMap, (*sortKeys)[index], results); len(*results) == 0 && err != nil {
 			deepestError = i.getMostResolvedError(err, deepestError)
 		}
 	}
@@ -49,13 +62,36 @@ func (i *syntaxChildWildcardIdentifier) retrieveMap(
 	return deepestError
 }
 
+// retrieveMapUnordered retrieves the members in the order of ranging over the map.
+func (i *syntaxChildWildcardIdentifier) retrieveMapUnordered(
+	rt *syntaxRuntime, root any, srcMap map[string]any, results *[]any) errors.ErrorRuntime {
+
+	var deepestError errors.ErrorRuntime
+
+	for key := range srcMap {
+		if err := i.retrieveMapNext(rt, root, srcMap, key, results); len(*results) == 0 && err != nil {
+			deepestError = i.getMostResolvedError(err, deepestError)
+		}
+	}
+
+	if len(*results) > 0 {
+		return nil
+	}
+
+	if deepestError == nil {
+		return i.newErrMemberNotExist()
+	}
+
+	return deepestError
+}
+
 func (i *syntaxChildWildcardIdentifier) retrieveList(
-	root any, srcList []any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root any, srcList []any, results *[]any) errors.ErrorRuntime {
 
 	var deepestError errors.ErrorRuntime
 
 	for index := range srcList {
-		if err := i.retrieveListNext(root, srcList, index, results); len(*results) == 0 && err != nil {
+		if err := i.retrieveListNext(rt, root, srcList, index, results); len(*results) == 0 && err != nil {
 			deepestError = i.getMostResolvedError(err, deepestError)
 		}
 	}
@@ -0,0 +1,131 @@
+package syntax
+
+import (
+	"sort"
+
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
+)
+
+// syntaxChildWildcardChain retrieves the consecutive wildcards such as `[*][*]` in one node.
+// The errors are created by the wildcard of each level, the same as without the chain.
+type syntaxChildWildcardChain struct {
+	*syntaxBasicNode
+
+	levels []*syntaxChildWildcardIdentifier
+}
+
+func (c *syntaxChildWildcardChain) retrieve(
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
+
+	return c.retrieveLevel(rt, root, current, 0, results)
+}
+
+func (c *syntaxChildWildcardChain) retrieveLevel(
+	rt *syntaxRuntime, root, current any, level int, results *[]any) errors.ErrorRuntime {
+
+	wildcard := c.levels[level]
+	isLastLevel := level == len(c.levels)-1
+
+	var deepestError errors.ErrorRuntime
+
+	switch typedNodes := current.(type) {
+	case map[string]any:
+		if wildcard.keyOrder == keyOrderNone {
+			deepestError = c.retrieveMapLevelUnordered(rt, root, typedNodes, level, results)
+			break
+		}
+		sortKeys, keyLength := getMapKeys(typedNodes, wildcard.keyOrder)
+		deepestError = c.retrieveMapLevel(rt, root, typedNodes, sortKeys, keyLength, level, results)
+
+	case *ordered.Object:
+		if wildcard.keyOrder == keyOrderNone {
+			deepestError = c.retrieveMapLevelUnordered(rt, root, typedNodes.Map(), level, results)
+			break
+		}
+		sortKeys, keyLength := getOrderedKeys(typedNodes, wildcard.keyOrder)
+		deepestError = c.retrieveMapLevel(rt, root, typedNodes.Map(), sortKeys, keyLength, level, results)
+
+	case []any:
+		for index := range typedNodes {
+			var err errors.ErrorRuntime
+			if isLastLevel {
+				err = wildcard.retrieveListNext(rt, root, typedNodes, index, results)
+			} else {
+				if rt.isPathTracked() {
+					rt.pushPath(index)
+				}
+				err = c.retrieveLevel(rt, root, typedNodes[index], level+1, results)
+				if rt.isPathTracked() {
+					rt.popPath()
+				}
+			}
+			if len(*results) == 0 && err != nil {
+				deepestError = wildcard.getMostResolvedError(err, deepestError)
+			}
+		}
+
+	default:
+		return wildcard.newErrTypeUnmatched(msgTypeObjectOrArray, current)
+	}
+
+	if len(*results) > 0 {
+		return nil
+	}
+
+	if deepestError == nil {
+		return wildcard.newErrMemberNotExist()
+	}
+
+	return deepestError
+}
+
+// retrieveMapLevel retrieves the members in order of sortKeys, puts it back to the pool, and returns the deepest error.
+func (c *syntaxChildWildcardChain) retrieveMapLevel(rt *syntaxRuntime, root any,
+	srcMap map[string]any, sortKeys *sort.StringSlice, keyLength int, level int, results *[]any) errors.ErrorRuntime {
+
+	wildcard := c.levels[level]
+
+	var deepestError errors.ErrorRuntime
+
+	for index := range keyLength {
+		if err := c.retrieveMember(rt, root, srcMap, (*sortKeys)[index], level, results); len(*results) == 0 && err != nil {
+			deepestError = wildcard.getMostResolvedError(err, deepestError)
+		}
+	}
+	putSortSlice(sortKeys)
+
+	return deepestError
+}
+
+// retrieveMapLevelUnordered retrieves the members in the order of ranging over the map, and returns the deepest error.
+func (c *syntaxChildWildcardChain) retrieveMapLevelUnordered(
+	rt *syntaxRuntime, root any, srcMap map[string]any, level int, results *[]any) errors.ErrorRuntime {
+
+	wildcard := c.levels[level]
+
+	var deepestError errors.ErrorRuntime
+
+	for key := range srcMap {
+		if err := c.retrieveMember(rt, root, srcMap, key, level, results); len(*results) == 0 && err != nil {
+			deepestError = wildcard.getMostResolvedError(err, deepestError)
+		}
+	}
+
+	return deepestError
+}
+
+func (c *syntaxChildWildcardChain) retrieveMember(
+	rt *syntaxRuntime, root any, srcMap map[string]any, key string, level int, results *[]any) errors.ErrorRuntime {
+
+	if level == len(c.levels)-1 {
+		return c.levels[level].retrieveMapNext(rt, root, srcMap, key, results)
+	}
+	if rt.isPathTracked() {
+		rt.pushPath(key)
+		err := c.retrieveLevel(rt, root, srcMap[key], level+1, results)
+		rt.popPath()
+		return err
+	}
+	return c.retrieveLevel(rt, root, srcMap[key], level+1, results)
+}
@@ -7,6 +7,6 @@ type syntaxCurrentNodeIdentifier struct {
 }
 
 func (i *syntaxCurrentNodeIdentifier) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
-	return i.retrieveAnyValueNext(root, current, results)
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
+	return i.retrieveAnyValueNext(rt, root, current, results)
 }
@@ -4,6 +4,7 @@ import (
 	"slices"
 
 	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
 )
 
 type syntaxRecursiveChildIdentifier struct {
@@ -14,14 +15,18 @@ type syntaxRecursiveChildIdentifier struct {
 }
 
 func (i *syntaxRecursiveChildIdentifier) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
 
 	switch current.(type) {
-	case map[string]any, []any:
+	case map[string]any, []any, *ordered.Object:
 	default:
 		return i.newErrTypeUnmatched(msgTypeObjectOrArray, current)
 	}
 
+	if rt.isPathTracked() {
+		return i.retrievePathTracked(rt, root, current, results)
+	}
+
 	var deepestError errors.ErrorRuntime
 
 	pooledNodes := getNodeSlice()
@@ -32,14 +37,24 @@ func (i *syntaxRecursiveChildIdentifier) retrieve(
 		currentTargetNode := targetNodes[len(targetNodes)-1]
 		targetNodes = targetNodes[:len(targetNodes)-1]
 		switch typedNodes := currentTargetNode.(type) {
-		case map[string]any:
+		case map[string]any, *ordered.Object:
 			if i.nextMapRequired {
-				if err := i.next.retrieve(root, typedNodes, results); len(*results) == 0 && err != nil {
+				if err := i.next.retrieve(rt, root, typedNodes, results); len(*results) == 0 && err != nil {
 					deepestError = i.getMostResolvedError(err, deepestError)
 				}
 			}
 
-			sortKeys, keyLength := getSortedRecursiveKeys(typedNodes)
+			if i.keyOrder == keyOrderNone {
+				for _, value := range getObjectMap(typedNodes) {
+					switch value.(type) {
+					case map[string]any, []any, *ordered.Object:
+						targetNodes = append(targetNodes, value)
+					}
+				}
+				break
+			}
+
+			srcMap, sortKeys, keyLength := getRecursiveKeys(typedNodes, i.keyOrder)
 			if keyLength > 0 {
 				oldLength := len(targetNodes)
 				targetNodes = slices.Grow(targetNodes, keyLength)
@@ -47,7 +62,7 @@ func (i *syntaxRecursiveChildIdentifier) retrieve(
 
 				appendIndex := oldLength
 				for index := keyLength - 1; index >= 0; index-- {
-					targetNodes[appendIndex] = typedNodes[(*sortKeys)[index]]
+					targetNodes[appendIndex] = srcMap[(*sortKeys)[index]]
 					appendIndex++
 				}
 			}
@@ -56,7 +71,7 @@ func (i *syntaxRecursiveChildIdentifier) retrieve(
 
 		case []any:
 			if i.nextListRequired {
-				if err := i.next.retrieve(root, typedNodes, results); len(*results) == 0 && err != nil {
+				if err := i.next.retrieve(rt, root, typedNodes, results); len(*results) == 0 && err != nil {
 					deepestError = i.getMostResolvedError(err, deepestError)
 				}
 			}
@@ -64,7 +79,7 @@ func (i *syntaxRecursiveChildIdentifier) retrieve(
 			keyLength := 0
 			for index := range typedNodes {
 				switch typedNodes[index].(type) {
-				case map[string]any, []any:
+				case map[string]any, []any, *ordered.Object:
 					keyLength++
 				}
 			}
@@ -76,7 +91,7 @@ func (i *syntaxRecursiveChildIdentifier) retrieve(
 				appendIndex := oldLength
 				for index := len(typedNodes) - 1; index >= 0; index-- {
 					switch typedNodes[index].(type) {
-					case map[string]any, []any:
+					case map[string]any, []any, *ordered.Object:
 						targetNodes[appendIndex] = typedNodes[index]
 						appendIndex++
 					}
@@ -98,3 +113,69 @@ func (i *syntaxRecursiveChildIdentifier) retrieve(
 
 	return deepestError
 }
+
+// retrievePathTracked walks the nodes in the same order as retrieve while keeping the path up to date.
+func (i *syntaxRecursiveChildIdentifier) retrievePathTracked(
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
+
+	var deepestError errors.ErrorRuntime
+
+	var walk func(node any)
+	walk = func(node any) {
+		switch typedNodes := node.(type) {
+		case map[string]any, *ordered.Object:
+			if i.nextMapRequired {
+				if err := i.next.retrieve(rt, root, typedNodes, results); len(*results) == 0 && err != nil {
+					deepestError = i.getMostResolvedError(err, deepestError)
+				}
+			}
+
+			if i.keyOrder == keyOrderNone {
+				for key, value := range getObjectMap(typedNodes) {
+					switch value.(type) {
+					case map[string]any, []any, *ordered.Object:
+						rt.pushPath(key)
+						walk(value)
+						rt.popPath()
+					}
+				}
+				return
+			}
+
+			srcMap, sortKeys, keyLength := getRecursiveKeys(typedNodes, i.keyOrder)
+			for index := range keyLength {
+				rt.pushPath((*sortKeys)[index])
+				walk(srcMap[(*sortKeys)[index]])
+				rt.popPath()
+			}
+			putSortSlice(sortKeys)
+
+		case []any:
+			if i.nextListRequired {
+				if err := i.next.retrieve(rt, root, typedNodes, results); len(*results) == 0 && err != nil {
+					deepestError = i.getMostResolvedError(err, deepestError)
+				}
+			}
+
+			for index := range typedNodes {
+				switch typedNodes[index].(type) {
+				case map[string]any, []any, *ordered.Object:
+					rt.pushPath(index)
+					walk(typedNodes[index])
+					rt.popPath()
+				}
+			}
+		}
+	}
+	walk(current)
+
+	if len(*results) > 0 {
+		return nil
+	}
+
+	if deepestError == nil {
+		return i.newErrMemberNotExist()
+	}
+
+	return deepestError
+}
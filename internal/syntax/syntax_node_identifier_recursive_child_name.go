@@ -0,0 +1,100 @@
+package syntax
+
+import (
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
+)
+
+// syntaxRecursiveChildNameIdentifier retrieves `..name` by looking up the key in each object,
+// instead of passing every object to the child identifier.
+type syntaxRecursiveChildNameIdentifier struct {
+	*syntaxBasicNode
+
+	child *syntaxChildSingleIdentifier
+}
+
+func (i *syntaxRecursiveChildNameIdentifier) retrieve(
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
+
+	switch current.(type) {
+	case map[string]any, []any, *ordered.Object:
+	default:
+		return i.newErrTypeUnmatched(msgTypeObjectOrArray, current)
+	}
+
+	var deepestError errors.ErrorRuntime
+	var hasObject bool
+
+	var walk func(node any)
+	walk = func(node any) {
+		switch typedNodes := node.(type) {
+		case map[string]any, *ordered.Object:
+			hasObject = true
+			srcMap := getObjectMap(typedNodes)
+			if _, ok := srcMap[i.child.identifier]; ok {
+				if err := i.child.retrieveMapNext(rt, root, srcMap, i.child.identifier, results); len(*results) == 0 && err != nil {
+					deepestError = i.getMostResolvedError(err, deepestError)
+				}
+			}
+
+			if i.keyOrder == keyOrderNone {
+				for key, value := range srcMap {
+					switch value.(type) {
+					case map[string]any, []any, *ordered.Object:
+						if rt.isPathTracked() {
+							rt.pushPath(key)
+						}
+						walk(value)
+						if rt.isPathTracked() {
+							rt.popPath()
+						}
+					}
+				}
+				return
+			}
+
+			_, sortKeys, keyLength := getRecursiveKeys(typedNodes, i.keyOrder)
+
+			for index := range keyLength {
+				if rt.isPathTracked() {
+					rt.pushPath((*sortKeys)[index])
+				}
+				walk(srcMap[(*sortKeys)[index]])
+				if rt.isPathTracked() {
+					rt.popPath()
+				}
+			}
+			putSortSlice(sortKeys)
+
+		case []any:
+			for index := range typedNodes {
+				switch typedNodes[index].(type) {
+				case map[string]any, []any, *ordered.Object:
+					if rt.isPathTracked() {
+						rt.pushPath(index)
+					}
+					walk(typedNodes[index])
+					if rt.isPathTracked() {
+						rt.popPath()
+					}
+				}
+			}
+		}
+	}
+	walk(current)
+
+	if len(*results) > 0 {
+		return nil
+	}
+
+	if deepestError != nil {
+		return deepestError
+	}
+
+	// The child identifier reports the missing member, the same as without the key lookup.
+	if hasObject {
+		return i.child.newErrMemberNotExist()
+	}
+
+	return i.newErrMemberNotExist()
+}
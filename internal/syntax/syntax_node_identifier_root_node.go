@@ -7,6 +7,6 @@ type syntaxRootNodeIdentifier struct {
 }
 
 func (i *syntaxRootNodeIdentifier) retrieve(
-	root, _ any, results *[]any) errors.ErrorRuntime {
-	return i.retrieveAnyValueNext(root, root, results)
+	rt *syntaxRuntime, root, _ any, results *[]any) errors.ErrorRuntime {
+	return i.retrieveAnyValueNext(rt, root, root, results)
 }
@@ -0,0 +1,20 @@
+package syntax
+
+import (
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// syntaxObservedNode notifies the observer of the retrieval of the node.
+type syntaxObservedNode struct {
+	syntaxNode
+
+	observer config.Observer
+}
+
+func (n *syntaxObservedNode) retrieve(
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
+
+	n.observer.OnNodeVisit(rt.ctx, n.getPath())
+	return n.syntaxNode.retrieve(rt, root, current, results)
+}
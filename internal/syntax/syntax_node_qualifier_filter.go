@@ -1,7 +1,10 @@
 package syntax
 
 import (
+	"sort"
+
 	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
 )
 
 type syntaxFilterQualifier struct {
@@ -11,28 +14,34 @@ type syntaxFilterQualifier struct {
 }
 
 func (f *syntaxFilterQualifier) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
 
 	switch typedNodes := current.(type) {
 	case map[string]any:
-		return f.retrieveMap(root, typedNodes, results)
+		if len(typedNodes) == 0 {
+			return f.newErrMemberNotExist()
+		}
+		sortKeys, keyLength := getMapKeys(typedNodes, f.keyOrder)
+		return f.retrieveMap(rt, root, typedNodes, sortKeys, keyLength, results)
+
+	case *ordered.Object:
+		if typedNodes.Len() == 0 {
+			return f.newErrMemberNotExist()
+		}
+		sortKeys, keyLength := getOrderedKeys(typedNodes, f.keyOrder)
+		return f.retrieveMap(rt, root, typedNodes.Map(), sortKeys, keyLength, results)
 
 	case []any:
-		return f.retrieveList(root, typedNodes, results)
+		return f.retrieveList(rt, root, typedNodes, results)
 
 	default:
 		return f.newErrTypeUnmatched(msgTypeObjectOrArray, current)
 	}
 }
 
-func (f *syntaxFilterQualifier) retrieveMap(
-	root any, srcMap map[string]any, results *[]any) errors.ErrorRuntime {
-
-	if len(srcMap) == 0 {
-		return f.newErrMemberNotExist()
-	}
-
-	sortKeys, keyLength := getSortedKeys(srcMap)
+// retrieveMap filters the members in order of sortKeys, and puts it back to the pool.
+func (f *syntaxFilterQualifier) retrieveMap(rt *syntaxRuntime, root any,
+	srcMap map[string]any, sortKeys *sort.StringSlice, keyLength int, results *[]any) errors.ErrorRuntime {
 
 	buf := getNodeSlice()
 	if cap(*buf) < keyLength {
@@ -43,11 +52,13 @@ func (f *syntaxFilterQualifier) retrieveMap(
 		(*buf)[index] = srcMap[(*sortKeys)[index]]
 	}
 
-	valueList := f.query.compute(root, *buf)
+	hasCandidates, candidateKeys := rt.setCandidates(true, *sortKeys)
+	valueList := f.query.compute(rt, root, *buf)
+	rt.setCandidates(hasCandidates, candidateKeys)
 
 	putNodeSlice(buf)
 
-	isEachResult := len(valueList) == len(srcMap)
+	isEachResult := len(valueList) == keyLength
 
 	if !isEachResult {
 		if valueList[0] == emptyEntity {
@@ -64,7 +75,7 @@ func (f *syntaxFilterQualifier) retrieveMap(
 				continue
 			}
 		}
-		if err := f.retrieveMapNext(root, srcMap, (*sortKeys)[index], results); len(*results) == 0 && err != nil {
+		if err := f.retrieveMapNext(rt, root, srcMap, (*sortKeys)[index], results); len(*results) == 0 && err != nil {
 			deepestError = f.getMostResolvedError(err, deepestError)
 		}
 	}
@@ -83,13 +94,15 @@ func (f *syntaxFilterQualifier) retrieveMap(
 }
 
 func (f *syntaxFilterQualifier) retrieveList(
-	root any, srcList []any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root any, srcList []any, results *[]any) errors.ErrorRuntime {
 
 	if len(srcList) == 0 {
 		return f.newErrMemberNotExist()
 	}
 
-	valueList := f.query.compute(root, srcList)
+	hasCandidates, candidateKeys := rt.setCandidates(true, nil)
+	valueList := f.query.compute(rt, root, srcList)
+	rt.setCandidates(hasCandidates, candidateKeys)
 
 	isEachResult := len(valueList) == len(srcList)
 
@@ -107,7 +120,7 @@ func (f *syntaxFilterQualifier) retrieveList(
 				continue
 			}
 		}
-		if err := f.retrieveListNext(root, srcList, index, results); len(*results) == 0 && err != nil {
+		if err := f.retrieveListNext(rt, root, srcList, index, results); len(*results) == 0 && err != nil {
 			deepestError = f.getMostResolvedError(err, deepestError)
 		}
 	}
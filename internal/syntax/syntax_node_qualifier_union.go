@@ -11,7 +11,7 @@ type syntaxUnionQualifier struct {
 }
 
 func (u *syntaxUnionQualifier) retrieve(
-	root, current any, results *[]any) errors.ErrorRuntime {
+	rt *syntaxRuntime, root, current any, results *[]any) errors.ErrorRuntime {
 
 	srcArray, ok := current.([]any)
 	if !ok {
@@ -23,7 +23,7 @@ func (u *syntaxUnionQualifier) retrieve(
 	srcLen := len(srcArray)
 	for _, subscript := range u.subscripts {
 		for ord := range subscript.count(srcLen) {
-			if err := u.retrieveListNext(root, srcArray, subscript.indexAt(srcLen, ord), results); len(*results) == 0 && err != nil {
+			if err := u.retrieveListNext(rt, root, srcArray, subscript.indexAt(srcLen, ord), results); len(*results) == 0 && err != nil {
 				deepestError = u.getMostResolvedError(err, deepestError)
 			}
 		}
@@ -0,0 +1,15 @@
+package syntax
+
+// syntaxQueryConstant is the filter query whose result is decided by the optimizer.
+type syntaxQueryConstant struct {
+	result bool
+}
+
+func (q *syntaxQueryConstant) compute(
+	_ *syntaxRuntime, _ any, _ []any) []any {
+
+	if q.result {
+		return fullList
+	}
+	return emptyList
+}
@@ -0,0 +1,54 @@
+package syntax
+
+type syntaxQueryFunction struct {
+	function *syntaxQueryParamFunction
+}
+
+func (q *syntaxQueryFunction) compute(
+	rt *syntaxRuntime, root any, currentList []any) []any {
+
+	if !q.function.isCurrentNodeDependent {
+		if q.test(rt, root, nil) {
+			return fullList
+		}
+		return emptyList
+	}
+
+	result := make([]any, len(currentList))
+
+	var hasValue bool
+	for index := range currentList {
+		isCandidateEntered := rt.enterCandidate(index)
+		isMatched := q.test(rt, root, currentList[index])
+		if isCandidateEntered {
+			rt.popPath()
+		}
+		if !isMatched {
+			result[index] = emptyEntity
+			continue
+		}
+		hasValue = true
+		result[index] = currentList[index]
+	}
+
+	if hasValue {
+		return result
+	}
+
+	return emptyList
+}
+
+func (q *syntaxQueryFunction) test(rt *syntaxRuntime, root, current any) bool {
+	value, err := q.function.evaluate(rt, root, current)
+	if err != nil {
+		return false
+	}
+
+	switch typedValue := value.(type) {
+	case bool:
+		return typedValue
+	case []any:
+		return len(typedValue) > 0
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package syntax
+
+// syntaxQueryHoisted computes the query that does not depend on the current node
+// only once per retrieval.
+type syntaxQueryHoisted struct {
+	query syntaxQuery
+	slot  int
+}
+
+func (q *syntaxQueryHoisted) compute(
+	rt *syntaxRuntime, root any, currentList []any) []any {
+
+	if result := rt.hoisted[q.slot]; result != nil {
+		return result
+	}
+
+	result := fullList
+	if computedList := q.query.compute(rt, root, currentList); len(computedList) == 1 && computedList[0] == emptyEntity {
+		result = emptyList
+	}
+	rt.hoisted[q.slot] = result
+	return result
+}
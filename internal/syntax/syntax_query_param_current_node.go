@@ -9,7 +9,7 @@ func (e *syntaxQueryParamCurrentNode) isValueGroupParameter() bool {
 }
 
 func (e *syntaxQueryParamCurrentNode) compute(
-	root any, currentList []any) []any {
+	rt *syntaxRuntime, root any, currentList []any) []any {
 
 	result := make([]any, len(currentList))
 	copy(result, currentList)
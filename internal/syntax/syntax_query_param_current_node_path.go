@@ -9,7 +9,7 @@ func (e *syntaxQueryParamCurrentNodePath) isValueGroupParameter() bool {
 }
 
 func (e *syntaxQueryParamCurrentNodePath) compute(
-	root any, currentList []any) []any {
+	rt *syntaxRuntime, root any, currentList []any) []any {
 
 	result := make([]any, len(currentList))
 
@@ -19,7 +19,12 @@ func (e *syntaxQueryParamCurrentNodePath) compute(
 
 	for index := range currentList {
 		*buf = (*buf)[:0]
-		if e.param.retrieve(root, currentList[index], buf) != nil {
+		isCandidateEntered := rt.enterCandidate(index)
+		err := e.param.retrieve(rt, root, currentList[index], buf)
+		if isCandidateEntered {
+			rt.popPath()
+		}
+		if err != nil {
 			result[index] = emptyEntity
 			continue
 		}
@@ -0,0 +1,110 @@
+package syntax
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+type syntaxQueryParamFunction struct {
+	path                   string
+	name                   string
+	function               func(*syntaxRuntime, []any) (any, error)
+	resultType             config.FunctionType
+	arguments              syntaxFunctionArguments
+	isCurrentNodeDependent bool
+}
+
+func (f *syntaxQueryParamFunction) evaluate(
+	rt *syntaxRuntime, root, current any) (any, errors.ErrorRuntime) {
+
+	arguments, err := f.arguments.evaluate(rt, root, current)
+	if err != nil {
+		return nil, err
+	}
+
+	value, functionErr := f.function(rt, arguments)
+	if functionErr != nil {
+		return nil, errors.NewErrorFunctionFailed(f.path, len(f.path), functionErr)
+	}
+
+	if resultErr := checkFunctionResult(f.resultType, value); resultErr != nil {
+		return nil, errors.NewErrorFunctionFailed(f.path, len(f.path), resultErr)
+	}
+
+	return value, nil
+}
+
+// checkFunctionResult checks the result of the function declared to return the number or the string.
+func checkFunctionResult(resultType config.FunctionType, value any) error {
+	switch resultType {
+	case config.NumberType:
+		switch value.(type) {
+		case float64, json.Number:
+			return nil
+		}
+	case config.StringType:
+		if _, ok := value.(string); ok {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if value == nil {
+		return fmt.Errorf(msgErrorFunctionResultType, resultType, msgTypeNull)
+	}
+	return fmt.Errorf(msgErrorFunctionResultType, resultType, reflect.TypeOf(value))
+}
+
+// toComparedValue converts json.Number returned by the function declared to return the number into float64,
+// as the number comparators take float64 on the right side.
+func (f *syntaxQueryParamFunction) toComparedValue(value any) any {
+	if f.resultType != config.NumberType {
+		return value
+	}
+	if number, ok := value.(json.Number); ok {
+		if float, err := number.Float64(); err == nil {
+			return float
+		}
+	}
+	return value
+}
+
+func (f *syntaxQueryParamFunction) compute(
+	rt *syntaxRuntime, root any, currentList []any) []any {
+
+	if !f.isCurrentNodeDependent {
+		value, err := f.evaluate(rt, root, nil)
+		if err != nil {
+			// The missing value would be equal to another missing value.
+			return failedList
+		}
+		return []any{f.toComparedValue(value)}
+	}
+
+	result := make([]any, len(currentList))
+
+	var hasValue bool
+	for index := range currentList {
+		isCandidateEntered := rt.enterCandidate(index)
+		value, err := f.evaluate(rt, root, currentList[index])
+		if isCandidateEntered {
+			rt.popPath()
+		}
+		if err != nil {
+			result[index] = emptyEntity
+			continue
+		}
+		hasValue = true
+		result[index] = f.toComparedValue(value)
+	}
+
+	if hasValue {
+		return result
+	}
+
+	return emptyList
+}
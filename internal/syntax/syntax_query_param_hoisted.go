@@ -0,0 +1,21 @@
+package syntax
+
+// syntaxQueryParamHoisted computes the parameter that does not depend on the current node
+// only once per retrieval.
+// It is placed only on the right side of the comparison, where the values are not modified.
+type syntaxQueryParamHoisted struct {
+	param syntaxCompareParameter
+	slot  int
+}
+
+func (e *syntaxQueryParamHoisted) compute(
+	rt *syntaxRuntime, root any, currentList []any) []any {
+
+	if values := rt.hoisted[e.slot]; values != nil {
+		return values
+	}
+
+	values := e.param.compute(rt, root, currentList)
+	rt.hoisted[e.slot] = values
+	return values
+}
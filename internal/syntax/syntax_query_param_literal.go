@@ -5,7 +5,7 @@ type syntaxQueryParamLiteral struct {
 }
 
 func (l *syntaxQueryParamLiteral) compute(
-	_ any, _ []any) []any {
+	_ *syntaxRuntime, _ any, _ []any) []any {
 
 	return l.literal
 }
@@ -10,7 +10,7 @@ func (e *syntaxQueryParamRootNode) isValueGroupParameter() bool {
 }
 
 func (e *syntaxQueryParamRootNode) compute(
-	_ any, _ []any) []any {
+	_ *syntaxRuntime, _ any, _ []any) []any {
 
 	return fullList
 }
@@ -9,11 +9,15 @@ func (e *syntaxQueryParamRootNodePath) isValueGroupParameter() bool {
 }
 
 func (e *syntaxQueryParamRootNodePath) compute(
-	root any, _ []any) []any {
+	rt *syntaxRuntime, root any, _ []any) []any {
 
 	buf := getNodeSlice()
 
-	if e.param.retrieve(root, root, buf) != nil {
+	pathBase := rt.enterRoot()
+	err := e.param.retrieve(rt, root, root, buf)
+	rt.leaveRoot(pathBase)
+
+	if err != nil {
 		putNodeSlice(buf)
 		return emptyList
 	}
@@ -0,0 +1,17 @@
+package syntax
+
+import "slices"
+
+// syntaxQueryParamTrace records the values of the operand of the comparison.
+type syntaxQueryParamTrace struct {
+	param syntaxCompareParameter
+}
+
+func (p *syntaxQueryParamTrace) compute(
+	rt *syntaxRuntime, root any, currentList []any) []any {
+
+	values := p.param.compute(rt, root, currentList)
+	rt.tracer.addOperand(values)
+	// The comparator marks the values, so the recorded values and the parameter are kept intact.
+	return slices.Clone(values)
+}
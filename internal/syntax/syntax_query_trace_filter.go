@@ -0,0 +1,18 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/ast"
+
+// syntaxQueryTraceFilter records the candidates of the filter and whether each of them matched.
+type syntaxQueryTraceFilter struct {
+	query      syntaxQuery
+	expression ast.Expression
+}
+
+func (q *syntaxQueryTraceFilter) compute(
+	rt *syntaxRuntime, root any, currentList []any) []any {
+
+	rt.tracer.beginFilter(rt, q.expression, currentList)
+	computedList := q.query.compute(rt, root, currentList)
+	rt.tracer.endFilter(computedList)
+	return computedList
+}
@@ -0,0 +1,19 @@
+package syntax
+
+import "github.com/AsaiYusuke/jsonpath/v2/ast"
+
+// syntaxQueryTraceStep records the result of the sub-expression of the filter.
+type syntaxQueryTraceStep struct {
+	query             syntaxQuery
+	expression        ast.Expression
+	isOperandMirrored bool
+}
+
+func (q *syntaxQueryTraceStep) compute(
+	rt *syntaxRuntime, root any, currentList []any) []any {
+
+	operandCount := len(rt.tracer.operands)
+	computedList := q.query.compute(rt, root, currentList)
+	rt.tracer.addStep(q.expression, computedList, operandCount, q.isOperandMirrored)
+	return computedList
+}
@@ -0,0 +1,202 @@
+package syntax
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// syntaxRuntime holds the state of a single retrieval.
+// It is nil unless the query contains a function that requires it, the hoisted values,
+// the trace of the filters or the collection of the errors.
+type syntaxRuntime struct {
+	root     any
+	ctx      context.Context
+	userData any
+
+	trackPath bool
+	path      []any
+	pathBase  int
+
+	hoisted [][]any
+
+	tracer *syntaxTracer
+
+	failures []errors.ErrorBranchFailed
+
+	pathResults *[]any
+	resultPaths []string
+
+	hasCandidates bool
+	candidateKeys []string
+}
+
+var runtimeSyncPool = &sync.Pool{
+	New: func() any { return new(syntaxRuntime) },
+}
+
+func getRuntime(ctx context.Context, root any, userData any) *syntaxRuntime {
+	rt := runtimeSyncPool.Get().(*syntaxRuntime)
+	rt.root = root
+	rt.ctx = ctx
+	rt.userData = userData
+	return rt
+}
+
+func putRuntime(rt *syntaxRuntime) {
+	rt.root = nil
+	rt.ctx = nil
+	rt.userData = nil
+	rt.trackPath = false
+	clear(rt.path)
+	rt.path = rt.path[:0]
+	clear(rt.hoisted)
+	rt.hoisted = rt.hoisted[:0]
+	rt.tracer = nil
+	clear(rt.failures)
+	rt.failures = rt.failures[:0]
+	rt.pathResults = nil
+	rt.resultPaths = nil
+	runtimeSyncPool.Put(rt)
+}
+
+// isPathTracked reports whether the path of the current node is kept up to date.
+func (r *syntaxRuntime) isPathTracked() bool {
+	return r != nil && r.trackPath
+}
+
+// prepareHoisted allocates the slots of the values computed once per retrieval.
+func (r *syntaxRuntime) prepareHoisted(count int) {
+	if cap(r.hoisted) < count {
+		r.hoisted = make([][]any, count)
+		return
+	}
+	r.hoisted = r.hoisted[:count]
+}
+
+// isResultPathRecorded reports whether the paths of the values added to the results are recorded.
+// The results of the filters and the function arguments are retrieved into the other slices, so they are not recorded.
+func (r *syntaxRuntime) isResultPathRecorded(results *[]any) bool {
+	return r != nil && r.pathResults == results
+}
+
+// recordResultPath records the path of the value added to the results.
+// The element is appended to the path of the current node unless it is nil.
+func (r *syntaxRuntime) recordResultPath(element any) {
+	if element == nil {
+		r.resultPaths = append(r.resultPaths, r.normalizedPath())
+		return
+	}
+	r.pushPath(element)
+	r.resultPaths = append(r.resultPaths, r.normalizedPath())
+	r.popPath()
+}
+
+func (r *syntaxRuntime) pushPath(element any) {
+	r.path = append(r.path, element)
+}
+
+func (r *syntaxRuntime) popPath() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+// enterRoot starts a new path from the root and returns the state to restore.
+func (r *syntaxRuntime) enterRoot() int {
+	if r == nil {
+		return 0
+	}
+	pathBase := r.pathBase
+	r.pathBase = len(r.path)
+	return pathBase
+}
+
+func (r *syntaxRuntime) leaveRoot(pathBase int) {
+	if r != nil {
+		r.pathBase = pathBase
+	}
+}
+
+// setCandidates declares the path elements of the current nodes passed to the filter query
+// and returns the previous declaration. The list indexes are used when keys is nil.
+func (r *syntaxRuntime) setCandidates(hasCandidates bool, keys []string) (bool, []string) {
+	if r == nil {
+		return false, nil
+	}
+	savedHasCandidates, savedKeys := r.hasCandidates, r.candidateKeys
+	r.hasCandidates, r.candidateKeys = hasCandidates, keys
+	return savedHasCandidates, savedKeys
+}
+
+// enterCandidate appends the path element of the current node to the path.
+// It returns false if there is nothing to append.
+func (r *syntaxRuntime) enterCandidate(index int) bool {
+	if !r.isPathTracked() || !r.hasCandidates {
+		return false
+	}
+	if r.candidateKeys != nil {
+		r.pushPath(r.candidateKeys[index])
+	} else {
+		r.pushPath(index)
+	}
+	return true
+}
+
+func (r *syntaxRuntime) normalizedPath() string {
+	var builder strings.Builder
+	builder.WriteString(`$`)
+	for _, element := range r.path[r.pathBase:] {
+		switch typedElement := element.(type) {
+		case string:
+			builder.WriteString(`['`)
+			writeNormalizedName(&builder, typedElement)
+			builder.WriteString(`']`)
+		case int:
+			builder.WriteString(`[`)
+			builder.WriteString(strconv.Itoa(typedElement))
+			builder.WriteString(`]`)
+		}
+	}
+	return builder.String()
+}
+
+func (r *syntaxRuntime) functionContext() config.FunctionContext {
+	return config.FunctionContext{
+		Context:  r.ctx,
+		Root:     r.root,
+		Path:     r.normalizedPath(),
+		UserData: r.userData,
+	}
+}
+
+func writeNormalizedName(builder *strings.Builder, name string) {
+	for _, r := range name {
+		switch r {
+		case '\'':
+			builder.WriteString(`\'`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				builder.WriteString(`\u00`)
+				builder.WriteString(strconv.FormatInt(int64(r)>>4, 16))
+				builder.WriteString(strconv.FormatInt(int64(r)&0xf, 16))
+				continue
+			}
+			builder.WriteRune(r)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func rootQuery(segments ...ast.Segment) *ast.Query {
+	return &ast.Query{Identifier: ast.RootIdentifier, Segments: segments}
+}
+
+func currentQuery(segments ...ast.Segment) *ast.Query {
+	return &ast.Query{Identifier: ast.CurrentIdentifier, Segments: segments}
+}
+
+func child(selectors ...ast.Selector) *ast.ChildSegment {
+	return &ast.ChildSegment{Selectors: selectors}
+}
+
+func name(name string) *ast.NameSelector {
+	return &ast.NameSelector{Name: name}
+}
+
+func filter(expression ast.Expression) *ast.ChildSegment {
+	return child(&ast.FilterSelector{Expression: expression})
+}
+
+func literal(value any) *ast.Literal {
+	return &ast.Literal{Value: value}
+}
+
+// expectAST validates that the query has the expected syntax tree.
+func expectAST(expected *ast.Query) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, _ any, _ config.Config) error {
+		if actual := query.AST(); !reflect.DeepEqual(expected, actual) {
+			expectedJSON, _ := json.Marshal(expected)
+			actualJSON, _ := json.Marshal(actual)
+			return fmt.Errorf(`expected<%s> != actual<%s>`, expectedJSON, actualJSON)
+		}
+		return nil
+	}
+}
+
+func TestAST_Segments(t *testing.T) {
+	runTestCases(t, `TestAST_Segments`, []TestCase{
+		{
+			jsonpath:       `$`,
+			queryValidator: expectAST(rootQuery()),
+		},
+		{
+			jsonpath: `$.a['b'].*`,
+			queryValidator: expectAST(rootQuery(
+				child(name(`a`)),
+				child(name(`b`)),
+				child(&ast.WildcardSelector{}),
+			)),
+		},
+		{
+			jsonpath: `a.b`,
+			queryValidator: expectAST(rootQuery(
+				child(name(`a`)),
+				child(name(`b`)),
+			)),
+		},
+		{
+			jsonpath: `$['a','b\'c',*].d`,
+			queryValidator: expectAST(rootQuery(
+				child(name(`a`), name(`b'c`), &ast.WildcardSelector{}),
+				child(name(`d`)),
+			)),
+		},
+		{
+			jsonpath: `$[0,-1,*,1:3,::-1]`,
+			queryValidator: expectAST(rootQuery(
+				child(
+					&ast.IndexSelector{Index: 0},
+					&ast.IndexSelector{Index: -1},
+					&ast.WildcardSelector{},
+					&ast.SliceSelector{Start: intPtr(1), End: intPtr(3)},
+					&ast.SliceSelector{Step: intPtr(-1)},
+				),
+			)),
+		},
+		{
+			jsonpath: `$..a..[0]..*.b`,
+			queryValidator: expectAST(rootQuery(
+				&ast.DescendantSegment{Selectors: []ast.Selector{name(`a`)}},
+				&ast.DescendantSegment{Selectors: []ast.Selector{&ast.IndexSelector{Index: 0}}},
+				&ast.DescendantSegment{Selectors: []ast.Selector{&ast.WildcardSelector{}}},
+				child(name(`b`)),
+			)),
+		},
+		{
+			jsonpath: `$..['a','b']`,
+			queryValidator: expectAST(rootQuery(
+				&ast.DescendantSegment{Selectors: []ast.Selector{name(`a`), name(`b`)}},
+			)),
+		},
+	})
+}
+
+func TestAST_Filter(t *testing.T) {
+	runTestCases(t, `TestAST_Filter`, []TestCase{
+		{
+			jsonpath: `$[?(@.a)]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.ExistenceTest{Query: currentQuery(child(name(`a`)))}),
+			)),
+		},
+		{
+			jsonpath: `$[?(!@)]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.LogicalNot{Expression: &ast.ExistenceTest{Query: currentQuery()}}),
+			)),
+		},
+		{
+			jsonpath: `$[?(@.a==1)]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{Left: currentQuery(child(name(`a`))), Operator: ast.EQ, Right: literal(float64(1))}),
+			)),
+		},
+		{
+			jsonpath: `$[?(@.a!='b')]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{Left: currentQuery(child(name(`a`))), Operator: ast.NE, Right: literal(`b`)}),
+			)),
+		},
+		{
+			jsonpath: `$[?(1<@.a)]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{Left: currentQuery(child(name(`a`))), Operator: ast.GT, Right: literal(float64(1))}),
+			)),
+		},
+		{
+			jsonpath: `$[?($.b<@.a)]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{Left: currentQuery(child(name(`a`))), Operator: ast.GT, Right: rootQuery(child(name(`b`)))}),
+			)),
+		},
+		{
+			jsonpath: `$[?(@.a<=$.b[0])]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{
+					Left:     currentQuery(child(name(`a`))),
+					Operator: ast.LE,
+					Right:    rootQuery(child(name(`b`)), child(&ast.IndexSelector{Index: 0})),
+				}),
+			)),
+		},
+		{
+			jsonpath: `$[?(@==1)]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{Left: currentQuery(), Operator: ast.EQ, Right: literal(float64(1))}),
+			)),
+		},
+		{
+			jsonpath: `$[?(@.a>='a' && @.a<'b' || @.c==null || !@.d)]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.LogicalOr{
+					Left: &ast.LogicalOr{
+						Left: &ast.LogicalAnd{
+							Left:  &ast.Comparison{Left: currentQuery(child(name(`a`))), Operator: ast.GE, Right: literal(`a`)},
+							Right: &ast.Comparison{Left: currentQuery(child(name(`a`))), Operator: ast.LT, Right: literal(`b`)},
+						},
+						Right: &ast.Comparison{Left: currentQuery(child(name(`c`))), Operator: ast.EQ, Right: literal(nil)},
+					},
+					Right: &ast.LogicalNot{Expression: &ast.ExistenceTest{Query: currentQuery(child(name(`d`)))}},
+				}),
+			)),
+		},
+		{
+			jsonpath: `$[?(@.a=~/^a\/b/)]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.RegexMatch{Left: currentQuery(child(name(`a`))), Pattern: `^a/b`}),
+			)),
+		},
+		{
+			jsonpath: `$[?(@[?(@.b==true)])]`,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.ExistenceTest{Query: currentQuery(
+					filter(&ast.Comparison{Left: currentQuery(child(name(`b`))), Operator: ast.EQ, Right: literal(true)}),
+				)}),
+			)),
+		},
+	})
+}
+
+func TestAST_Function(t *testing.T) {
+	runTestCases(t, `TestAST_Function`, []TestCase{
+		{
+			jsonpath: `$.a[*].max().twice()`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFilter,
+			},
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			queryValidator: expectAST(rootQuery(
+				child(name(`a`)),
+				child(&ast.WildcardSelector{}),
+				&ast.FunctionSegment{Function: &ast.Function{Name: `max`, Arguments: []ast.Argument{}}, Aggregate: true},
+				&ast.FunctionSegment{Function: &ast.Function{Name: `twice`, Arguments: []ast.Argument{}}},
+			)),
+		},
+		{
+			jsonpath: `$.a.round(2)`,
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+			queryValidator: expectAST(rootQuery(
+				child(name(`a`)),
+				&ast.FunctionSegment{Function: &ast.Function{Name: `round`, Arguments: []ast.Argument{literal(float64(2))}}},
+			)),
+		},
+		{
+			jsonpath:          `$[?(length(@.a)>1)]`,
+			standardFunctions: true,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{
+					Left:     &ast.Function{Name: `length`, Arguments: []ast.Argument{currentQuery(child(name(`a`)))}},
+					Operator: ast.GT,
+					Right:    literal(float64(1)),
+				}),
+			)),
+		},
+		{
+			jsonpath: `$[?(!isEmail(@.a))]`,
+			functions: map[string]config.Function{
+				`isEmail`: isEmailExpression,
+			},
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.LogicalNot{Expression: &ast.FunctionTest{Function: &ast.Function{
+					Name:      `isEmail`,
+					Arguments: []ast.Argument{currentQuery(child(name(`a`)))},
+				}}}),
+			)),
+		},
+		{
+			jsonpath:          `$[?(2<count($..b[*]))]`,
+			standardFunctions: true,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{
+					Left: &ast.Function{Name: `count`, Arguments: []ast.Argument{rootQuery(
+						&ast.DescendantSegment{Selectors: []ast.Selector{name(`b`)}},
+						child(&ast.WildcardSelector{}),
+					)}},
+					Operator: ast.GT,
+					Right:    literal(float64(2)),
+				}),
+			)),
+		},
+		{
+			jsonpath:          `$[?(count($..b[*])==2)]`,
+			standardFunctions: true,
+			queryValidator: expectAST(rootQuery(
+				filter(&ast.Comparison{
+					Left: &ast.Function{Name: `count`, Arguments: []ast.Argument{rootQuery(
+						&ast.DescendantSegment{Selectors: []ast.Selector{name(`b`)}},
+						child(&ast.WildcardSelector{}),
+					)}},
+					Operator: ast.EQ,
+					Right:    literal(float64(2)),
+				}),
+			)),
+		},
+	})
+}
+
+func TestAST_Inspect(t *testing.T) {
+	query, err := jsonpath.Compile(`$.a[?(@.b>1 && $.c[0].d)].e..f`)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	var names []string
+	ast.Inspect(query.AST(), func(node ast.Node) bool {
+		if nameSelector, ok := node.(*ast.NameSelector); ok {
+			names = append(names, nameSelector.Name)
+		}
+		return true
+	})
+
+	expected := `["a","b","c","d","e","f"]`
+	actual, _ := json.Marshal(names)
+	if string(actual) != expected {
+		t.Errorf("expected<%s> != actual<%s>\n", expected, actual)
+	}
+}
+
+func TestAST_NewTreeEachCall(t *testing.T) {
+	query, err := jsonpath.Compile(`$.a`)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	query.AST().Segments[0].(*ast.ChildSegment).Selectors[0].(*ast.NameSelector).Name = `b`
+
+	if name := query.AST().Segments[0].(*ast.ChildSegment).Selectors[0].(*ast.NameSelector).Name; name != `a` {
+		t.Errorf("expected<a> != actual<%s>\n", name)
+	}
+}
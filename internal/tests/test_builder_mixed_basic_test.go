@@ -0,0 +1,263 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// expectBuilder validates that the builder builds the JSONPath of the query and compiles it to the same syntax tree.
+func expectBuilder(builder *jsonpath.Builder) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, _ any, config config.Config) error {
+		if actualPath := builder.String(); actualPath != query.String() {
+			return fmt.Errorf(`expected path<%s> != actual path<%s>`, query.String(), actualPath)
+		}
+
+		builtQuery, err := builder.Compile(config)
+		if err != nil {
+			return err
+		}
+
+		// The builder does not know whether the function is an aggregate function.
+		actualAST := builtQuery.AST()
+		ast.Inspect(actualAST, func(node ast.Node) bool {
+			if function, ok := node.(*ast.FunctionSegment); ok {
+				function.Aggregate = false
+			}
+			return true
+		})
+		if !reflect.DeepEqual(actualAST, builder.AST()) {
+			expectedJSON, _ := json.Marshal(builder.AST())
+			actualJSON, _ := json.Marshal(actualAST)
+			return fmt.Errorf(`expected AST<%s> != actual AST<%s>`, expectedJSON, actualJSON)
+		}
+		return nil
+	}
+}
+
+// expectBuilderError validates that the builder builds the JSONPath of the query and fails to compile it.
+func expectBuilderError(builder *jsonpath.Builder, expectedErr error) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, _ any, config config.Config) error {
+		if actualPath := builder.String(); actualPath != query.String() {
+			return fmt.Errorf(`expected path<%s> != actual path<%s>`, query.String(), actualPath)
+		}
+		if _, err := builder.Compile(config); !reflect.DeepEqual(err, expectedErr) {
+			return fmt.Errorf(`expected error<%s> != actual error<%s>`, expectedErr, err)
+		}
+		return nil
+	}
+}
+
+func TestBuilder_Segments(t *testing.T) {
+	runTestCases(t, `TestBuilder_Segments`, []TestCase{
+		{
+			jsonpath:       `$`,
+			inputJSON:      `{"a":1}`,
+			expectedJSON:   `[{"a":1}]`,
+			queryValidator: expectBuilder(jsonpath.Root()),
+		},
+		{
+			jsonpath:       `$['a.b'][-1]`,
+			inputJSON:      `{"a.b":[1,2,3],"a":{"b":[4]}}`,
+			expectedJSON:   `[3]`,
+			queryValidator: expectBuilder(jsonpath.Root().Child(`a.b`).Index(-1)),
+		},
+		{
+			jsonpath:       `$['it\'s','back\\slash','line\nfeed','\u0001']`,
+			inputJSON:      `{"it's":1,"back\\slash":2,"line\nfeed":3,"\u0001":4}`,
+			expectedJSON:   `[1,2,3,4]`,
+			queryValidator: expectBuilder(jsonpath.Root().Child(`it's`, `back\slash`, "line\nfeed", "\x01")),
+		},
+		{
+			jsonpath:       `$['"quoted"','日本語','*']`,
+			inputJSON:      `{"\"quoted\"":1,"日本語":2,"*":3,"other":4}`,
+			expectedJSON:   `[1,2,3]`,
+			queryValidator: expectBuilder(jsonpath.Root().Child(`"quoted"`, `日本語`, `*`)),
+		},
+		{
+			jsonpath:       `$[*][0,2][0:1]`,
+			inputJSON:      `[[["a"],["b"],["c"]]]`,
+			expectedJSON:   `["a","c"]`,
+			queryValidator: expectBuilder(jsonpath.Root().Wildcard().Index(0, 2).Slice(0, 1)),
+		},
+		{
+			jsonpath:     `$[::-1][1:]`,
+			inputJSON:    `[[1,2],[3,4]]`,
+			expectedJSON: `[4,2]`,
+			queryValidator: expectBuilder(jsonpath.Root().Select(
+				&ast.SliceSelector{Step: intPtr(-1)},
+			).Select(&ast.SliceSelector{Start: intPtr(1)})),
+		},
+		{
+			jsonpath:       `$..['a']..[*]`,
+			inputJSON:      `{"x":{"a":{"b":[1]}}}`,
+			expectedJSON:   `[[1],1]`,
+			queryValidator: expectBuilder(jsonpath.Root().Descendant(`a`).DescendantWildcard()),
+		},
+		{
+			jsonpath:       `$..[0]`,
+			inputJSON:      `[[1],2]`,
+			expectedJSON:   `[[1],1]`,
+			queryValidator: expectBuilder(jsonpath.Root().DescendantSelect(&ast.IndexSelector{Index: 0})),
+		},
+	})
+}
+
+func TestBuilder_Filter(t *testing.T) {
+	runTestCases(t, `TestBuilder_Filter`, []TestCase{
+		{
+			jsonpath:       `$[?(@['x']>3)]`,
+			inputJSON:      `[{"x":1},{"x":4}]`,
+			expectedJSON:   `[{"x":4}]`,
+			queryValidator: expectBuilder(jsonpath.Root().Filter(jsonpath.Cur().Child(`x`).Gt(3))),
+		},
+		{
+			jsonpath:       `$[?(@=='it\'s')]`,
+			inputJSON:      `["it's","its"]`,
+			expectedJSON:   `["it's"]`,
+			queryValidator: expectBuilder(jsonpath.Root().Filter(jsonpath.Cur().Eq(`it's`))),
+		},
+		{
+			jsonpath:     `$[?(@['a']>=1.5&&@['a']<3&&@['b']!=null||@['c']==true)]`,
+			inputJSON:    `[{"a":2,"b":1},{"a":2,"b":null},{"a":1,"c":true}]`,
+			expectedJSON: `[{"a":2,"b":1},{"a":1,"c":true}]`,
+			queryValidator: expectBuilder(jsonpath.Root().Filter(
+				jsonpath.Cur().Child(`a`).Ge(1.5).And(
+					jsonpath.Cur().Child(`a`).Lt(int64(3)),
+					jsonpath.Cur().Child(`b`).Ne(nil),
+				).Or(jsonpath.Cur().Child(`c`).Eq(true)),
+			)),
+		},
+		{
+			jsonpath:     `$[?(@['a']&&(@['b']||@['c']))]`,
+			inputJSON:    `[{"a":1,"b":1},{"a":1},{"c":1}]`,
+			expectedJSON: `[{"a":1,"b":1}]`,
+			queryValidator: expectBuilder(jsonpath.Root().Filter(
+				jsonpath.Cur().Child(`a`).Exists().And(
+					jsonpath.Cur().Child(`b`).Exists().Or(jsonpath.Cur().Child(`c`).Exists()),
+				),
+			)),
+		},
+		{
+			jsonpath:     `$[?(!@['a']&&@['b']!=1)]`,
+			inputJSON:    `[{"a":1},{"b":1},{"b":2}]`,
+			expectedJSON: `[{"b":2}]`,
+			queryValidator: expectBuilder(jsonpath.Root().Filter(
+				jsonpath.Not(jsonpath.Cur().Child(`a`).Exists().Or(jsonpath.Cur().Child(`b`).Eq(1))),
+			)),
+		},
+		{
+			jsonpath:       `$[?(@['a']=~/^a\/b/)]`,
+			inputJSON:      `[{"a":"a/b"},{"a":"b"}]`,
+			expectedJSON:   `[{"a":"a/b"}]`,
+			queryValidator: expectBuilder(jsonpath.Root().Filter(jsonpath.Cur().Child(`a`).Match(`^a/b`))),
+		},
+		{
+			jsonpath:       `$['items'][?(@['price']<=$['limit'])]`,
+			inputJSON:      `{"limit":10,"items":[{"price":5},{"price":20}]}`,
+			expectedJSON:   `[{"price":5}]`,
+			queryValidator: expectBuilder(jsonpath.Root().Child(`items`).Filter(jsonpath.Cur().Child(`price`).Le(jsonpath.Root().Child(`limit`)))),
+		},
+	})
+}
+
+func TestBuilder_Function(t *testing.T) {
+	runTestCases(t, `TestBuilder_Function`, []TestCase{
+		{
+			jsonpath: `$[*].max().round(1)`,
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+			inputJSON:      `[1.26,0.5]`,
+			expectedJSON:   `[1.3]`,
+			queryValidator: expectBuilder(jsonpath.Root().Wildcard().Function(`max`).Function(`round`, 1)),
+		},
+		{
+			jsonpath:          `$[?(length(@)>2)]`,
+			standardFunctions: true,
+			inputJSON:         `["ab","abc"]`,
+			expectedJSON:      `["abc"]`,
+			queryValidator:    expectBuilder(jsonpath.Root().Filter(jsonpath.NewCall(`length`, jsonpath.Cur()).Gt(2))),
+		},
+		{
+			jsonpath: `$[?(!isEmail(@))]`,
+			functions: map[string]config.Function{
+				`isEmail`: isEmailExpression,
+			},
+			inputJSON:      `["a@x","b"]`,
+			expectedJSON:   `["b"]`,
+			queryValidator: expectBuilder(jsonpath.Root().Filter(jsonpath.Not(jsonpath.NewCall(`isEmail`, jsonpath.Cur()).Test()))),
+		},
+	})
+}
+
+func TestBuilder_Error(t *testing.T) {
+	runTestCases(t, `TestBuilder_Error`, []TestCase{
+		{
+			jsonpath: `$[?(@==null)]`,
+			queryValidator: expectBuilderError(
+				jsonpath.Root().Filter(jsonpath.Cur().Eq(struct{}{})),
+				createErrorInvalidArgument(`{}`, fmt.Errorf(`unsupported literal type struct {}`)),
+			),
+		},
+		{
+			jsonpath: `$[?(@==null)]`,
+			queryValidator: expectBuilderError(
+				jsonpath.Root().Filter(jsonpath.Cur().Eq(math.Inf(1))),
+				createErrorInvalidArgument(`+Inf`, fmt.Errorf(`unsupported literal type float64`)),
+			),
+		},
+		{
+			jsonpath: `$[?(@>1)]`,
+			queryValidator: expectBuilderError(
+				jsonpath.Root().Filter(jsonpath.Not(jsonpath.Cur().Gt(1))),
+				createErrorInvalidArgument(`>`, fmt.Errorf(`negation is not supported`)),
+			),
+		},
+		{
+			jsonpath: `$[?(@=~/a/)]`,
+			queryValidator: expectBuilderError(
+				jsonpath.Root().Filter(jsonpath.Not(jsonpath.Cur().Match(`a`))),
+				createErrorInvalidArgument(`=~`, fmt.Errorf(`negation is not supported`)),
+			),
+		},
+		{
+			jsonpath:  jsonpath.Root().Filter(jsonpath.Cur().Wildcard().Eq(1)).String(),
+			inputJSON: `[{"a":1}]`,
+			expectedErr: errors.ErrorInvalidSyntax{
+				Position: 4,
+				Reason:   `JSONPath that returns a value group is prohibited`,
+				Near:     `@[*]==1)]`,
+				JSONPath: `$[?(@[*]==1)]`,
+				Line:     1,
+				Column:   5,
+			},
+		},
+	})
+}
+
+func TestBuilder_Reuse(t *testing.T) {
+	base := jsonpath.Root().Child(`a`)
+	first := base.Child(`b`)
+	second := base.Child(`c`)
+
+	if actual := first.String(); actual != `$['a']['b']` {
+		t.Errorf("expected<$['a']['b']> != actual<%s>\n", actual)
+	}
+	if actual := second.String(); actual != `$['a']['c']` {
+		t.Errorf("expected<$['a']['c']> != actual<%s>\n", actual)
+	}
+	if actual := base.String(); actual != `$['a']` {
+		t.Errorf("expected<$['a']> != actual<%s>\n", actual)
+	}
+}
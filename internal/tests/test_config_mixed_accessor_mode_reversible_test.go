@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+func createErrorReadOnly(path string) errors.ErrorReadOnly {
+	errBasicError := errors.NewErrorBasicRuntime(path, len(path))
+	return errors.NewErrorReadOnly(&errBasicError)
+}
+
+func TestConfig_AccessorModeReversibleFunction(t *testing.T) {
+	testGroups := TestGroup{
+		`write-back`: []TestCase{
+			{
+				jsonpath:     `$.price.toCents()`,
+				inputJSON:    `{"price":12.34}`,
+				accessorMode: true,
+				functions:    map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createReversibleValidator(1234.0, 550.0,
+					func(src any) any { return src.(map[string]any)[`price`] }, 5.5),
+			},
+			{
+				jsonpath:     `$[1].toCents()`,
+				inputJSON:    `[1,2,3]`,
+				accessorMode: true,
+				functions:    map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createReversibleValidator(200.0, 700.0,
+					func(src any) any { return src.([]any)[1] }, 7.0),
+			},
+			{
+				jsonpath:     `$[?(@.id==2)].price.toCents()`,
+				inputJSON:    `[{"id":1,"price":1},{"id":2,"price":2}]`,
+				accessorMode: true,
+				functions:    map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createReversibleValidator(200.0, 300.0,
+					func(src any) any { return src.([]any)[1].(map[string]any)[`price`] }, 3.0),
+			},
+			{
+				jsonpath:     `$.a.toCents().negate()`,
+				inputJSON:    `{"a":1.5}`,
+				accessorMode: true,
+				functions:    map[string]config.Function{`toCents`: toCentsFunc, `negate`: negateFunc},
+				resultValidator: createReversibleValidator(-150.0, -250.0,
+					func(src any) any { return src.(map[string]any)[`a`] }, 2.5),
+			},
+		},
+		`read-only`: []TestCase{
+			{
+				jsonpath:        `$.a.twice()`,
+				inputJSON:       `{"a":1}`,
+				accessorMode:    true,
+				filters:         map[string]func(any) (any, error){`twice`: twiceFunc},
+				resultValidator: createTrySetErrorValidator(4.0, createErrorReadOnly(`.twice()`)),
+				functions:       map[string]config.Function{`toCents`: toCentsFunc},
+			},
+			{
+				jsonpath:        `$.a.toCents().twice()`,
+				inputJSON:       `{"a":1}`,
+				accessorMode:    true,
+				filters:         map[string]func(any) (any, error){`twice`: twiceFunc},
+				functions:       map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createTrySetErrorValidator(4.0, createErrorReadOnly(`.twice()`)),
+			},
+			{
+				jsonpath:        `$.a.twice().toCents()`,
+				inputJSON:       `{"a":1}`,
+				accessorMode:    true,
+				filters:         map[string]func(any) (any, error){`twice`: twiceFunc},
+				functions:       map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createTrySetErrorValidator(400.0, createErrorReadOnly(`.twice()`)),
+			},
+			{
+				jsonpath:        `$[*].max().toCents()`,
+				inputJSON:       `[1,2]`,
+				accessorMode:    true,
+				aggregates:      map[string]func([]any) (any, error){`max`: maxFunc},
+				functions:       map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createTrySetErrorValidator(400.0, createErrorReadOnly(`.max()`)),
+			},
+			{
+				jsonpath:        `$.toCents()`,
+				inputJSON:       `1`,
+				accessorMode:    true,
+				functions:       map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createTrySetErrorValidator(400.0, createErrorReadOnly(`.toCents()`)),
+			},
+			{
+				jsonpath:        `$.a.toCents()`,
+				inputJSON:       `{"a":1}`,
+				accessorMode:    true,
+				functions:       map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createTrySetErrorValidator(`x`, createErrorFunctionFailed(`.toCents()`, `inverse type error`)),
+			},
+		},
+		`get failure`: []TestCase{
+			{
+				jsonpath:     `$.a.toCents()`,
+				inputJSON:    `{"a":1}`,
+				accessorMode: true,
+				functions:    map[string]config.Function{`toCents`: toCentsFunc},
+				resultValidator: createTryGetErrorValidator(func(src any) { src.(map[string]any)[`a`] = `x` },
+					createErrorFunctionFailed(`.toCents()`, `type error`)),
+			},
+			{
+				jsonpath:     `$.a.toCents().negate()`,
+				inputJSON:    `{"a":1}`,
+				accessorMode: true,
+				functions:    map[string]config.Function{`toCents`: toCentsFunc, `negate`: negateFunc},
+				resultValidator: createTryGetErrorValidator(func(src any) { src.(map[string]any)[`a`] = `x` },
+					createErrorFunctionFailed(`.toCents()`, `type error`)),
+			},
+		},
+	}
+
+	runTestGroups(t, testGroups)
+}
+
+func TestReversibleFunction_Value(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$[*].toCents()`,
+			inputJSON:    `[1,2.5]`,
+			expectedJSON: `[100,250]`,
+			functions:    map[string]config.Function{`toCents`: toCentsFunc},
+		},
+		{
+			jsonpath:     `$[?(toCents(@.p) > 150)].id`,
+			inputJSON:    `[{"id":1,"p":1},{"id":2,"p":2}]`,
+			expectedJSON: `[2]`,
+			functions:    map[string]config.Function{`toCents`: toCentsFunc},
+		},
+		{
+			jsonpath:    `$.a.toCents()`,
+			inputJSON:   `{"a":"x"}`,
+			expectedErr: createErrorFunctionFailed(`.toCents()`, `type error`),
+			functions:   map[string]config.Function{`toCents`: toCentsFunc},
+		},
+		{
+			jsonpath:     `$.a.toCents()`,
+			inputJSON:    `{"a":"x"}`,
+			expectedErr:  createErrorFunctionFailed(`.toCents()`, `type error`),
+			accessorMode: true,
+			functions:    map[string]config.Function{`toCents`: toCentsFunc},
+		},
+	}
+
+	runTestCases(t, "TestReversibleFunction_Value", testCases)
+}
@@ -0,0 +1,296 @@
+package tests
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// expectBranchErrors validates that the query collects the errors of the failed branches.
+func expectBranchErrors(expected ...string) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, src any, _ config.Config) error {
+		var actual []string
+		if _, err := query.Retrieve(src); err != nil {
+			multipleErr, ok := err.(errors.ErrorMultiple)
+			if !ok {
+				return fmt.Errorf(`expected error<errors.ErrorMultiple> != actual error<%v>`, err)
+			}
+			for _, branchErr := range multipleErr.Errors {
+				actual = append(actual, branchErr.Error())
+			}
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			return fmt.Errorf(`expected errors<%v> != actual errors<%v>`, expected, actual)
+		}
+		return nil
+	}
+}
+
+func TestConfig_CollectAllErrors(t *testing.T) {
+	runTestCases(t, `TestConfig_CollectAllErrors`, []TestCase{
+		{
+			jsonpath:         `$.items[*].price`,
+			inputJSON:        `{"items":[{"price":1},{"name":"a"},{"price":2},3]}`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1,2]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$['items'][1], error=member did not exist (path=.price))`,
+				`branch failed (location=$['items'][3], error=type unmatched (path=.price, expected=object, found=float64))`,
+			),
+		},
+		{
+			jsonpath:         `$.items[*].price.v`,
+			inputJSON:        `{"items":[{"price":{"v":1}},{"price":2}]}`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$['items'][1]['price'], error=type unmatched (path=.v, expected=object, found=float64))`,
+			),
+		},
+		{
+			jsonpath:         `$.a[*].b`,
+			inputJSON:        `{"a":[{"c":1},{"c":2}]}`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedErr:      createErrorMemberNotExist(`.b`),
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$['a'][0], error=member did not exist (path=.b))`,
+				`branch failed (location=$['a'][1], error=member did not exist (path=.b))`,
+			),
+		},
+		{
+			jsonpath:         `$.a[*].b`,
+			inputJSON:        `{"a":[{"b":1},{"b":2}]}`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1,2]`,
+			queryValidator:   expectBranchErrors(),
+		},
+		{
+			jsonpath:         `$.x`,
+			inputJSON:        `{"a":1}`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedErr:      createErrorMemberNotExist(`.x`),
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$, error=member did not exist (path=.x))`,
+			),
+		},
+		{
+			jsonpath:         `$[?(@.a)].a.b`,
+			inputJSON:        `[{"a":{"b":1}},{"a":2},{"c":3}]`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$[1]['a'], error=type unmatched (path=.b, expected=object, found=float64))`,
+			),
+		},
+		{
+			jsonpath:         `$['a','b','c'].d`,
+			inputJSON:        `{"a":{"d":1},"b":{}}`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$['b'], error=member did not exist (path=.d))`,
+			),
+		},
+		{
+			jsonpath:         `$..a.b`,
+			inputJSON:        `{"a":{"b":1},"x":{"a":2},"y":{"z":3}}`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$['x']['a'], error=type unmatched (path=.b, expected=object, found=float64))`,
+			),
+		},
+		{
+			jsonpath:         `$..['a'].b`,
+			inputJSON:        `{"a":{"b":1},"x":{"a":{}},"y":{"z":3}}`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$['x']['a'], error=member did not exist (path=.b))`,
+			),
+		},
+		{
+			jsonpath:         `$[*][*]`,
+			inputJSON:        `[[1],2,{"a":3}]`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1,3]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$[1], error=type unmatched (path=[*], expected=object/array, found=float64))`,
+			),
+		},
+		{
+			jsonpath:         `$[*].*`,
+			inputJSON:        `[[1],2,{"a":3}]`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1,3]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$[1], error=type unmatched (path=.*, expected=object/array, found=float64))`,
+			),
+		},
+		{
+			jsonpath:         `$[*][*][*]`,
+			inputJSON:        `[[[1],2]]`,
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[1]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$[0][1], error=type unmatched (path=[*], expected=object/array, found=float64))`,
+			),
+		},
+		{
+			jsonpath:  `$[*].a.max()`,
+			inputJSON: `[{"a":1},{"a":3},{}]`,
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			collectAllErrors: true,
+			optimization:     true,
+			expectedJSON:     `[3]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$[2], error=member did not exist (path=.a))`,
+			),
+		},
+		{
+			jsonpath:  `$[*].errFilter()`,
+			inputJSON: `[1,2]`,
+			filters: map[string]func(any) (any, error){
+				`errFilter`: errFilterFunc,
+			},
+			collectAllErrors: true,
+			optimization:     true,
+			expectedErr:      createErrorFunctionFailed(`.errFilter()`, `filter error`),
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$[0], error=function failed (path=.errFilter(), error=filter error))`,
+				`branch failed (location=$[1], error=function failed (path=.errFilter(), error=filter error))`,
+			),
+		},
+	})
+}
+
+func TestConfig_ContinueOnFunctionError(t *testing.T) {
+	runTestCases(t, `TestConfig_ContinueOnFunctionError`, []TestCase{
+		{
+			jsonpath:  `$[*].twice()`,
+			inputJSON: `[1,"a",2,"b"]`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFunc,
+			},
+			continueOnFuncError: true,
+			optimization:        true,
+			expectedJSON:        `[2,4]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$[1], error=function failed (path=.twice(), error=type error))`,
+				`branch failed (location=$[3], error=function failed (path=.twice(), error=type error))`,
+			),
+		},
+		{
+			jsonpath:  `$[*].twice().max()`,
+			inputJSON: `[1,"a",2]`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFunc,
+			},
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			continueOnFuncError: true,
+			optimization:        true,
+			expectedJSON:        `[4]`,
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$[1], error=function failed (path=.twice(), error=type error))`,
+			),
+		},
+		{
+			jsonpath:  `$.a.twice()`,
+			inputJSON: `{"a":"b"}`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFunc,
+			},
+			continueOnFuncError: true,
+			optimization:        true,
+			expectedErr:         createErrorFunctionFailed(`.twice()`, `type error`),
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$['a'], error=function failed (path=.twice(), error=type error))`,
+			),
+		},
+		{
+			jsonpath:  `$[*].a.twice()`,
+			inputJSON: `[{"a":1},{"b":2}]`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFunc,
+			},
+			continueOnFuncError: true,
+			optimization:        true,
+			expectedJSON:        `[2]`,
+			queryValidator:      expectBranchErrors(),
+		},
+		{
+			jsonpath:  `$[?(@.twice() > 2)]`,
+			inputJSON: `[1,"a",2]`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFunc,
+			},
+			continueOnFuncError: true,
+			optimization:        true,
+			expectedJSON:        `[2]`,
+			queryValidator:      expectBranchErrors(),
+		},
+		{
+			jsonpath:  `$.max()`,
+			inputJSON: `[1,"a",2]`,
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			continueOnFuncError: true,
+			optimization:        true,
+			expectedErr:         createErrorFunctionFailed(`.max()`, `function failed (path=max, error=non-numeric value)`),
+			queryValidator: expectBranchErrors(
+				`branch failed (location=$, error=function failed (path=.max(), error=function failed (path=max, error=non-numeric value)))`,
+			),
+		},
+	})
+}
+
+func TestConfig_CollectAllErrorsWrapping(t *testing.T) {
+	collectedConfig := config.Config{}
+	collectedConfig.SetCollectAllErrors()
+
+	var src any
+	json.Unmarshal([]byte(`[{"a":1},{"b":2},3]`), &src)
+	_, err := jsonpath.Retrieve(`$[*].a`, src, collectedConfig)
+
+	if !goerrors.Is(err, errors.ErrNotFound) || !goerrors.Is(err, errors.ErrTypeUnmatched) {
+		t.Errorf("expected errors.Is to find both causes in error<%v>\n", err)
+	}
+
+	var branchErr errors.ErrorBranchFailed
+	if !goerrors.As(err, &branchErr) || branchErr.Location != `$[1]` {
+		t.Errorf("expected location<$[1]> != actual location<%s>\n", branchErr.Location)
+	}
+
+	var typeErr errors.ErrorTypeUnmatched
+	if !goerrors.As(err, &typeErr) || typeErr.Error() != `type unmatched (path=.a, expected=object, found=float64)` {
+		t.Errorf("expected error<type unmatched> != actual error<%v>\n", typeErr)
+	}
+
+	joinedErr := goerrors.Join(err.(errors.ErrorMultiple).Unwrap()...)
+	if joinedErr.Error() != err.Error() {
+		t.Errorf("expected error<%s> != actual error<%s>\n", joinedErr, err)
+	}
+}
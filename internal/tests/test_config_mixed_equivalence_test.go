@@ -0,0 +1,218 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+// equivalenceInputJSON is the input shared by the equivalence test cases.
+const equivalenceInputJSON = `{"store":{"book":[{"category":"reference","title":"Sayings","price":8.95},{"category":"fiction","title":"Sword","price":12.99,"isbn":"0-553"},{"category":"fiction","title":"Moby","price":8.99,"isbn":"0-395"}],"bicycle":{"color":"red","price":19.95}},"limit":10,"name":"store"}`
+
+// equivalenceTestCases returns the test cases that must return the same results and errors
+// whichever mode of the config is set.
+func equivalenceTestCases() []TestCase {
+	return []TestCase{
+		{
+			jsonpath:     `$.store.bicycle.color`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["red"]`,
+		},
+		{
+			jsonpath:     `$.store.book[0,2].title`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["Sayings","Moby"]`,
+		},
+		{
+			jsonpath:     `$.store.book[1:].title`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["Sword","Moby"]`,
+		},
+		{
+			jsonpath:     `$.store.book[*].isbn`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["0-553","0-395"]`,
+		},
+		{
+			jsonpath:     `$..price`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `[19.95,8.95,12.99,8.99]`,
+		},
+		{
+			jsonpath:     `$.store.*.color`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["red"]`,
+		},
+		{
+			jsonpath:     `$..book[0].title`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["Sayings"]`,
+		},
+		{
+			jsonpath:     `$.store.book.*.*`,
+			inputJSON:    `{"store":{"book":[{"title":"Sayings"},{"title":"Sword","isbn":"0-553"}]}}`,
+			expectedJSON: `["Sayings","0-553","Sword"]`,
+		},
+		{
+			jsonpath:     `$.store.book[?(@.price < $.limit)].title`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["Sayings","Moby"]`,
+		},
+		{
+			jsonpath:     `$.store.book[?(@.isbn && @.category == 'fiction')].title`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["Sword","Moby"]`,
+		},
+		{
+			jsonpath:     `$.store.book[?(@.price > 10 || @.title =~ /^S/)].title`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["Sayings","Sword"]`,
+		},
+		{
+			jsonpath:     `$.store.book[?(!@.isbn)].title`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["Sayings"]`,
+		},
+		{
+			jsonpath:     `$.store.book[?(1 == 1)].category`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `["reference","fiction","fiction"]`,
+		},
+		{
+			jsonpath:     `$.store.book[?($.name == 'store')].price`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `[8.95,12.99,8.99]`,
+		},
+		{
+			jsonpath:          `$.store.book[*].price.sum()`,
+			inputJSON:         `{"store":{"book":[{"price":1},{"price":2},{"price":3}]}}`,
+			expectedJSON:      `[6]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.store.book[?(length(@.title) == 5)].title`,
+			inputJSON:         equivalenceInputJSON,
+			expectedJSON:      `["Sword"]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:     `$.store.book[*].price.max()`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `[12.99]`,
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxFunc,
+			},
+		},
+		{
+			jsonpath:     `$.limit.twice()`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `[20]`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFunc,
+			},
+		},
+		{
+			jsonpath:     `$.store.book[*].price.round(1)`,
+			inputJSON:    equivalenceInputJSON,
+			expectedJSON: `[9,13,9]`,
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:    `$.store.bicycle.size`,
+			inputJSON:   equivalenceInputJSON,
+			expectedErr: createErrorMemberNotExist(`.size`),
+		},
+		{
+			jsonpath:    `$.store.book[5]`,
+			inputJSON:   equivalenceInputJSON,
+			expectedErr: createErrorMemberNotExist(`[5]`),
+		},
+		{
+			jsonpath:    `$.store.book[*].size`,
+			inputJSON:   equivalenceInputJSON,
+			expectedErr: createErrorMemberNotExist(`.size`),
+		},
+		{
+			jsonpath:    `$.name[0]`,
+			inputJSON:   equivalenceInputJSON,
+			expectedErr: createErrorTypeUnmatched(`[0]`, `array`, `string`),
+		},
+		{
+			jsonpath:    `$.store.book[?(@.price > 100)]`,
+			inputJSON:   equivalenceInputJSON,
+			expectedErr: createErrorMemberNotExist(`[?(@.price > 100)]`),
+		},
+		{
+			jsonpath:    `$.store.book[?(1 == 2)]`,
+			inputJSON:   equivalenceInputJSON,
+			expectedErr: createErrorMemberNotExist(`[?(1 == 2)]`),
+		},
+		{
+			jsonpath:    `$.limit.fail()`,
+			inputJSON:   equivalenceInputJSON,
+			expectedErr: createErrorFunctionFailed(`.fail()`, `filter error`),
+			filters: map[string]func(any) (any, error){
+				`fail`: errFilterFunc,
+			},
+		},
+	}
+}
+
+// runEquivalenceTestCases runs the equivalence test cases in the mode set by setMode.
+func runEquivalenceTestCases(t *testing.T, testGroupName string, setMode func(*TestCase)) {
+	testCases := equivalenceTestCases()
+	for index := range testCases {
+		setMode(&testCases[index])
+	}
+	runTestCases(t, testGroupName, testCases)
+}
+
+func TestConfig_EquivalencePlain(t *testing.T) {
+	runEquivalenceTestCases(t, `TestConfig_EquivalencePlain`, func(*TestCase) {})
+}
+
+func TestConfig_EquivalenceOptimized(t *testing.T) {
+	runEquivalenceTestCases(t, `TestConfig_EquivalenceOptimized`, func(testCase *TestCase) {
+		testCase.optimization = true
+	})
+}
+
+func TestConfig_EquivalenceObserved(t *testing.T) {
+	runEquivalenceTestCases(t, `TestConfig_EquivalenceObserved`, func(testCase *TestCase) {
+		testCase.observer = &countingObserver{}
+	})
+}
+
+func TestConfig_EquivalenceCollected(t *testing.T) {
+	runEquivalenceTestCases(t, `TestConfig_EquivalenceCollected`, func(testCase *TestCase) {
+		testCase.collectAllErrors = true
+	})
+}
+
+func TestConfig_EquivalenceOptimizedCollected(t *testing.T) {
+	runEquivalenceTestCases(t, `TestConfig_EquivalenceOptimizedCollected`, func(testCase *TestCase) {
+		testCase.optimization = true
+		testCase.collectAllErrors = true
+	})
+}
+
+func TestConfig_EquivalenceContinued(t *testing.T) {
+	runEquivalenceTestCases(t, `TestConfig_EquivalenceContinued`, func(testCase *TestCase) {
+		testCase.continueOnFuncError = true
+	})
+}
+
+func TestConfig_EquivalenceOptimizedContinued(t *testing.T) {
+	runEquivalenceTestCases(t, `TestConfig_EquivalenceOptimizedContinued`, func(testCase *TestCase) {
+		testCase.optimization = true
+		testCase.continueOnFuncError = true
+	})
+}
+
+func TestConfig_EquivalenceUnordered(t *testing.T) {
+	runEquivalenceTestCases(t, `TestConfig_EquivalenceUnordered`, func(testCase *TestCase) {
+		testCase.unordered = true
+	})
+}
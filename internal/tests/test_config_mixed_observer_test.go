@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+type observerContextKey struct{}
+
+// recordingObserver records the events to validate them against the expected events.
+type recordingObserver struct {
+	expectedEvents []string
+	events         []string
+}
+
+func (o *recordingObserver) OnQueryStart(ctx context.Context, jsonPath string) context.Context {
+	o.events = append(o.events, `start `+jsonPath)
+	return context.WithValue(ctx, observerContextKey{}, `span`)
+}
+
+func (o *recordingObserver) OnNodeVisit(ctx context.Context, segment string) {
+	o.events = append(o.events, fmt.Sprintf(`visit %s (%v)`, segment, ctx.Value(observerContextKey{})))
+}
+
+func (o *recordingObserver) OnFunctionCall(ctx context.Context, name string, err error) {
+	o.events = append(o.events, fmt.Sprintf(`call %s %v (%v)`, name, err, ctx.Value(observerContextKey{})))
+}
+
+func (o *recordingObserver) OnQueryEnd(ctx context.Context, jsonPath string, resultCount int, err error) {
+	o.events = append(o.events, fmt.Sprintf(`end %s %d %v (%v)`, jsonPath, resultCount, err, ctx.Value(observerContextKey{})))
+}
+
+func (o *recordingObserver) validate() error {
+	if !reflect.DeepEqual(o.events, o.expectedEvents) {
+		return fmt.Errorf("expected events<\n%s> != actual events<\n%s>",
+			strings.Join(o.expectedEvents, "\n"), strings.Join(o.events, "\n"))
+	}
+	return nil
+}
+
+var spanFunc = config.Function{
+	Kind:        config.FilterKind,
+	WithContext: true,
+	Filter: func(functionContext config.FunctionContext, _ any, _ []any) (any, error) {
+		return functionContext.Context.Value(observerContextKey{}), nil
+	},
+}
+
+func TestConfig_Observer(t *testing.T) {
+	runTestCases(t, `TestConfig_Observer`, []TestCase{
+		{
+			jsonpath:     `$.a[0,1]`,
+			inputJSON:    `{"a":[1,2]}`,
+			expectedJSON: `[1,2]`,
+			observer: &recordingObserver{expectedEvents: []string{
+				`start $.a[0,1]`,
+				`visit .a (span)`,
+				`visit [0,1] (span)`,
+				`end $.a[0,1] 2 <nil> (span)`,
+			}},
+		},
+		{
+			jsonpath:  `$[?(@.b > 1)].b.twice()`,
+			inputJSON: `[{"b":1},{"b":2}]`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFilter,
+			},
+			expectedJSON: `[4]`,
+			observer: &recordingObserver{expectedEvents: []string{
+				`start $[?(@.b > 1)].b.twice()`,
+				`visit [?(@.b > 1)] (span)`,
+				`visit .b (span)`,
+				`visit .b (span)`,
+				`visit .b (span)`,
+				`visit .twice() (span)`,
+				`call twice <nil> (span)`,
+				`end $[?(@.b > 1)].b.twice() 1 <nil> (span)`,
+			}},
+		},
+		{
+			jsonpath:  `$[*].max()`,
+			inputJSON: `[1,3]`,
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			expectedJSON: `[3]`,
+			observer: &recordingObserver{expectedEvents: []string{
+				`start $[*].max()`,
+				`visit .max() (span)`,
+				`visit [*] (span)`,
+				`call max <nil> (span)`,
+				`end $[*].max() 1 <nil> (span)`,
+			}},
+		},
+		{
+			jsonpath:          `$[?(length(@) > 1)]`,
+			inputJSON:         `["ab","c"]`,
+			standardFunctions: true,
+			expectedJSON:      `["ab"]`,
+			observer: &recordingObserver{expectedEvents: []string{
+				`start $[?(length(@) > 1)]`,
+				`visit [?(length(@) > 1)] (span)`,
+				`visit @ (span)`,
+				`call length <nil> (span)`,
+				`visit @ (span)`,
+				`call length <nil> (span)`,
+				`end $[?(length(@) > 1)] 1 <nil> (span)`,
+			}},
+		},
+		{
+			jsonpath:  `$.a.errFilter()`,
+			inputJSON: `{"a":1}`,
+			filters: map[string]func(any) (any, error){
+				`errFilter`: errFilterFunc,
+			},
+			expectedErr: createErrorFunctionFailed(`.errFilter()`, `filter error`),
+			observer: &recordingObserver{expectedEvents: []string{
+				`start $.a.errFilter()`,
+				`visit .a (span)`,
+				`visit .errFilter() (span)`,
+				`call errFilter filter error (span)`,
+				`end $.a.errFilter() 0 function failed (path=.errFilter(), error=filter error) (span)`,
+			}},
+		},
+		{
+			jsonpath:  `$.span()`,
+			inputJSON: `{}`,
+			functions: map[string]config.Function{
+				`span`: spanFunc,
+			},
+			expectedJSON: `["span"]`,
+			observer: &recordingObserver{expectedEvents: []string{
+				`start $.span()`,
+				`visit .span() (span)`,
+				`call span <nil> (span)`,
+				`end $.span() 1 <nil> (span)`,
+			}},
+		},
+		{
+			jsonpath:     `$[*][*]..x`,
+			inputJSON:    `[[{"x":1}]]`,
+			optimization: true,
+			expectedJSON: `[1]`,
+			observer: &recordingObserver{expectedEvents: []string{
+				`start $[*][*]..x`,
+				`visit [*] (span)`,
+				`visit .. (span)`,
+				`end $[*][*]..x 1 <nil> (span)`,
+			}},
+		},
+	})
+}
+
+func TestConfig_ObserverPlan(t *testing.T) {
+	plainConfig := config.Config{}
+	observedConfig := config.Config{}
+	observedConfig.SetObserver(&countingObserver{})
+
+	for _, jsonPath := range []string{`$.a[?(@.b==$.c)]..d`, `$['a','b'][*]`} {
+		plainQuery, err := jsonpath.Compile(jsonPath, plainConfig)
+		if err != nil {
+			t.Errorf("expected error<nil> != actual error<%s>\n", err)
+			return
+		}
+		observedQuery, err := jsonpath.Compile(jsonPath, observedConfig)
+		if err != nil {
+			t.Errorf("expected error<nil> != actual error<%s>\n", err)
+			return
+		}
+		if plainQuery.Plan() != observedQuery.Plan() {
+			t.Errorf("expected plan<\n%s> != actual plan<\n%s>\n", plainQuery.Plan(), observedQuery.Plan())
+		}
+		if plainQuery.IsSingular() != observedQuery.IsSingular() {
+			t.Errorf("expected singular<%t> != actual singular<%t>\n", plainQuery.IsSingular(), observedQuery.IsSingular())
+		}
+	}
+}
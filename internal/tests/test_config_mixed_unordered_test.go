@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"testing"
+)
+
+func TestConfig_Unordered(t *testing.T) {
+	testGroups := TestGroup{
+		`aggregate`: []TestCase{
+			{
+				jsonpath:          `$..price.sum()`,
+				inputJSON:         `{"b":{"price":1},"a":[{"price":2},{"c":{"price":3}}],"price":4}`,
+				expectedJSON:      `[10]`,
+				standardFunctions: true,
+				unordered:         true,
+			},
+			{
+				jsonpath:          `$.*.price.sum()`,
+				inputJSON:         `{"c":{"price":1},"b":{"price":2},"a":{"price":3}}`,
+				expectedJSON:      `[6]`,
+				standardFunctions: true,
+				unordered:         true,
+			},
+			{
+				jsonpath:          `$[?(@.price > 1)].price.sum()`,
+				inputJSON:         `{"c":{"price":1},"b":{"price":2},"a":{"price":3}}`,
+				expectedJSON:      `[5]`,
+				standardFunctions: true,
+				unordered:         true,
+			},
+			{
+				jsonpath:          `$.*.*.count()`,
+				inputJSON:         `{"b":{"x":1,"y":2},"a":[3,{"z":4}]}`,
+				expectedJSON:      `[4]`,
+				standardFunctions: true,
+				unordered:         true,
+				optimization:      true,
+			},
+		},
+		`any-order`: []TestCase{
+			{
+				jsonpath:     `$.*`,
+				inputJSON:    `{"c":1,"a":2,"b":3}`,
+				expectedJSON: `[2,3,1]`,
+				unordered:    true,
+			},
+			{
+				jsonpath:     `$..*`,
+				inputJSON:    `{"b":{"d":1},"a":[2]}`,
+				expectedJSON: `[[2],{"d":1},2,1]`,
+				unordered:    true,
+			},
+			{
+				jsonpath:      `$..a`,
+				inputJSON:     `{"z":{"a":1},"y":[{"a":2}],"a":3}`,
+				expectedJSON:  `[3,2,1]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				unordered:     true,
+				documentOrder: true,
+			},
+			{
+				jsonpath:     `$..a`,
+				inputJSON:    `{"z":{"a":1},"y":[{"a":2}],"a":3}`,
+				expectedJSON: `[3,2,1]`,
+				unordered:    true,
+				optimization: true,
+			},
+		},
+		`error`: []TestCase{
+			{
+				jsonpath:     `$.*.a`,
+				inputJSON:    `{"c":{"b":1},"a":{"b":2}}`,
+				expectedErr:  createErrorMemberNotExist(`.a`),
+				unordered:    true,
+				optimization: true,
+			},
+			{
+				jsonpath:    `$..z`,
+				inputJSON:   `{"c":{"b":1},"a":[{"b":2}]}`,
+				expectedErr: createErrorMemberNotExist(`z`),
+				unordered:   true,
+			},
+			{
+				jsonpath:    `$.*`,
+				inputJSON:   `{}`,
+				expectedErr: createErrorMemberNotExist(`.*`),
+				unordered:   true,
+			},
+		},
+	}
+
+	runTestGroups(t, testGroups)
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+// expectFormat validates that the JSONPath of the query is formatted in the style of the config,
+// and that the formatted JSONPath is formatted to itself.
+func expectFormat(expected string) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, _ any, config config.Config) error {
+		actual, err := jsonpath.Format(query.String(), config)
+		if err != nil {
+			return err
+		}
+		if actual != expected {
+			return fmt.Errorf(`expected<%s> != actual<%s>`, expected, actual)
+		}
+
+		reformatted, err := jsonpath.Format(actual, config)
+		if err != nil {
+			return err
+		}
+		if reformatted != actual {
+			return fmt.Errorf(`expected reformatted<%s> != actual reformatted<%s>`, actual, reformatted)
+		}
+		return nil
+	}
+}
+
+func TestFormat_Canonical(t *testing.T) {
+	runTestCases(t, `TestFormat_Canonical`, []TestCase{
+		{jsonpath: `$.a['b']`, queryValidator: expectFormat(`$['a']['b']`)},
+		{jsonpath: `$['a'].b`, queryValidator: expectFormat(`$['a']['b']`)},
+		{jsonpath: `$[ 'a' ][ 'b' ]`, queryValidator: expectFormat(`$['a']['b']`)},
+		{jsonpath: `$["a"]["b"]`, queryValidator: expectFormat(`$['a']['b']`)},
+		{jsonpath: ` a.b `, queryValidator: expectFormat(`$['a']['b']`)},
+		{jsonpath: `$.a\.b`, queryValidator: expectFormat(`$['a.b']`)},
+		{jsonpath: `$["it's","a\"b","c\\d","é"]`, queryValidator: expectFormat(`$['it\'s','a"b','c\\d','é']`)},
+		{jsonpath: `$.*[*]..*..a`, queryValidator: expectFormat(`$[*][*]..[*]..['a']`)},
+		{jsonpath: `$[ 0 , -1 ][ 1 : 3 ][ ::-1 ][:2:]`, queryValidator: expectFormat(`$[0,-1][1:3][::-1][:2]`)},
+		{jsonpath: `$[?( @.a > 1 )]`, queryValidator: expectFormat(`$[?(@['a']>1)]`)},
+		{jsonpath: `$[?(1 < @.a)]`, queryValidator: expectFormat(`$[?(@['a']>1)]`)},
+		{jsonpath: `$[?(@.a != "x" && (@.b || @.c) || !@.d)]`, queryValidator: expectFormat(`$[?(@['a']!='x'&&(@['b']||@['c'])||!@['d'])]`)},
+		{jsonpath: `$[?(@.a || (@.b || @.c))]`, queryValidator: expectFormat(`$[?(@['a']||(@['b']||@['c']))]`)},
+		{jsonpath: `$[?(@.a =~ /a\/b/)]`, queryValidator: expectFormat(`$[?(@['a']=~/a\/b/)]`)},
+		{jsonpath: `$[?(@.a == 1.50)]`, queryValidator: expectFormat(`$[?(@['a']==1.5)]`)},
+		{jsonpath: `$[?(@.a == 1E3)]`, queryValidator: expectFormat(`$[?(@['a']==1000)]`)},
+		{jsonpath: `$[?(@.a == TRUE || @.b == Null)]`, queryValidator: expectFormat(`$[?(@['a']==true||@['b']==null)]`)},
+		{jsonpath: `$[?(@.a == $.b)]`, queryValidator: expectFormat(`$[?(@['a']==$['b'])]`)},
+		{jsonpath: `$[?(length( @.a ) > 1)]`, standardFunctions: true, queryValidator: expectFormat(`$[?(length(@['a'])>1)]`)},
+		{jsonpath: `$.a[*].max()`, aggregates: map[string]func([]any) (any, error){`max`: maxAggregate}, queryValidator: expectFormat(`$['a'][*].max()`)},
+	})
+}
+
+func TestFormat_Style(t *testing.T) {
+	runTestCases(t, `TestFormat_Style`, []TestCase{
+		{
+			jsonpath:       `$['a']["b-c"]['d.e'][''][0]['日本']`,
+			formatStyle:    config.FormatStyle{Notation: config.DotNotation},
+			queryValidator: expectFormat(`$.a.b-c['d.e'][''][0].日本`),
+		},
+		{
+			jsonpath:       `$[*]..['a']..[*]['a','b']`,
+			formatStyle:    config.FormatStyle{Notation: config.DotNotation},
+			queryValidator: expectFormat(`$.*..a..*['a','b']`),
+		},
+		{
+			jsonpath:       `$[?(@['a']['b'] == 'x')]`,
+			formatStyle:    config.FormatStyle{Notation: config.DotNotation},
+			queryValidator: expectFormat(`$[?(@.a.b=='x')]`),
+		},
+		{
+			jsonpath:       `$['a b',"it's",'say "hi"']`,
+			formatStyle:    config.FormatStyle{Quote: config.DoubleQuote},
+			queryValidator: expectFormat(`$["a b","it's","say \"hi\""]`),
+		},
+		{
+			jsonpath:          `$[0,1][?(@.a>1&&@.b=~/c/||length(@.c)==2)]`,
+			standardFunctions: true,
+			formatStyle:       config.FormatStyle{Spacing: true},
+			queryValidator:    expectFormat(`$[0, 1][?(@['a'] > 1 && @['b'] =~ /c/ || length(@['c']) == 2)]`),
+		},
+		{
+			jsonpath:       `$.a[?(@.b=='c')]`,
+			formatStyle:    config.FormatStyle{Notation: config.DotNotation, Quote: config.DoubleQuote, Spacing: true},
+			queryValidator: expectFormat(`$.a[?(@.b == "c")]`),
+		},
+	})
+}
+
+func TestFormat_Error(t *testing.T) {
+	runTestCases(t, `TestFormat_Error`, []TestCase{
+		{
+			jsonpath: `$.a.`,
+			expectedErr: errors.ErrorInvalidSyntax{
+				Position: 3,
+				Reason:   `unrecognized input`,
+				Near:     `.`,
+				JSONPath: `$.a.`,
+				Line:     1,
+				Column:   5,
+				Expected: `'*', member name`,
+			},
+		},
+		{
+			jsonpath:    `$.unknown()`,
+			expectedErr: createErrorFunctionNotFound(`.unknown()`),
+		},
+	})
+}
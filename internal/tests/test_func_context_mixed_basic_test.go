@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+var canceledContext = func() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}()
+
+func TestContextFunction_Path(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$.path()`,
+			inputJSON:    `{"a":1}`,
+			expectedJSON: `["$"]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$.a.b[1].path()`,
+			inputJSON:    `{"a":{"b":[1,2]}}`,
+			expectedJSON: `["$['a']['b'][1]"]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$[*].path()`,
+			inputJSON:    `{"b":1,"a":2}`,
+			expectedJSON: `["$['a']","$['b']"]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$['a','b'][0,-1].path()`,
+			inputJSON:    `{"a":[1,2],"b":[3]}`,
+			expectedJSON: `["$['a'][0]","$['a'][1]","$['b'][0]","$['b'][0]"]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$..x.path()`,
+			inputJSON:    `{"x":1,"a":[{"x":2},{"b":{"x":3}}]}`,
+			expectedJSON: `["$['x']","$['a'][0]['x']","$['a'][1]['b']['x']"]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$[?(@.x>1)].path()`,
+			inputJSON:    `[{"x":1},{"x":2},{"x":3}]`,
+			expectedJSON: `["$[1]","$[2]"]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$["it's","a\\b","c\nd"].path()`,
+			inputJSON:    `{"it's":1,"a\\b":2,"c\nd":3}`,
+			expectedJSON: `["$['it\\'s']","$['a\\\\b']","$['c\\nd']"]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$.a[*].paths()`,
+			inputJSON:    `{"a":[1,2,3]}`,
+			expectedJSON: `[["$",3]]`,
+			functions:    map[string]config.Function{`paths`: pathsAggregateFunc},
+		},
+		{
+			jsonpath:     `$.a.b[*].paths()`,
+			inputJSON:    `{"a":{"b":[1,2]}}`,
+			expectedJSON: `[["$",2]]`,
+			functions:    map[string]config.Function{`paths`: pathsAggregateFunc},
+		},
+		{
+			jsonpath:     `$.a[*].b[*].paths()`,
+			inputJSON:    `{"a":[{"b":[1,2]},{"b":[3]}]}`,
+			expectedJSON: `[["$",3]]`,
+			functions:    map[string]config.Function{`paths`: pathsAggregateFunc},
+		},
+	}
+
+	runTestCases(t, "TestContextFunction_Path", testCases)
+}
+
+func TestContextFunction_PathInFilter(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$.a[?(@.b.path() == "$['a'][1]['b']")].id`,
+			inputJSON:    `{"a":[{"id":1,"b":1},{"id":2,"b":2}]}`,
+			expectedJSON: `[2]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$.a[?(@.path() == "$['a']['y']")].id`,
+			inputJSON:    `{"a":{"x":{"id":1},"y":{"id":2}}}`,
+			expectedJSON: `[2]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$.a[?(path(@) == "$['a'][0]")].id`,
+			inputJSON:    `{"a":[{"id":1},{"id":2}]}`,
+			expectedJSON: `[1]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$.a[?(@.b[?(@.path() == "$['a'][1]['b'][0]")])].id`,
+			inputJSON:    `{"a":[{"id":1,"b":[1]},{"id":2,"b":[2]}]}`,
+			expectedJSON: `[2]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+		{
+			jsonpath:     `$.a[?($.b.path() == "$['b']")].id`,
+			inputJSON:    `{"a":[{"id":1}],"b":2}`,
+			expectedJSON: `[1]`,
+			functions:    map[string]config.Function{`path`: pathFunc},
+		},
+	}
+
+	runTestCases(t, "TestContextFunction_PathInFilter", testCases)
+}
+
+func TestContextFunction_RootAndUserData(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$.items[*].type.ref()`,
+			inputJSON:    `{"definitions":{"s":{"size":1},"l":{"size":3}},"items":[{"type":"s"},{"type":"l"}]}`,
+			expectedJSON: `[{"size":1},{"size":3}]`,
+			functions:    map[string]config.Function{`ref`: refFunc},
+		},
+		{
+			jsonpath:     `$.items[?(ref(@.type) > 1)].type`,
+			inputJSON:    `{"definitions":{"s":1,"l":3},"items":[{"type":"s"},{"type":"l"}]}`,
+			expectedJSON: `["l"]`,
+			functions:    map[string]config.Function{`ref`: refFunc},
+		},
+		{
+			jsonpath:    `$.items[*].type.ref()`,
+			inputJSON:   `{"definitions":{},"items":[{"type":"x"}]}`,
+			expectedErr: createErrorFunctionFailed(`.ref()`, `definition not found (name=x)`),
+			functions:   map[string]config.Function{`ref`: refFunc},
+		},
+		{
+			jsonpath:     `$.a.data('lang')`,
+			inputJSON:    `{"a":1}`,
+			expectedJSON: `["ja"]`,
+			functions:    map[string]config.Function{`data`: userDataFunc},
+			userData:     map[string]any{`lang`: `ja`},
+		},
+		{
+			jsonpath:    `$.a.data()`,
+			inputJSON:   `{"a":1}`,
+			expectedErr: createErrorInvalidSyntax(3, `wrong number of function arguments (expected=1, found=0)`, `.data()`),
+			functions:   map[string]config.Function{`data`: userDataFunc},
+		},
+	}
+
+	runTestCases(t, "TestContextFunction_RootAndUserData", testCases)
+}
+
+func TestContextFunction_Cancellation(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$.a.check()`,
+			inputJSON:    `{"a":1}`,
+			expectedJSON: `[1]`,
+			functions:    map[string]config.Function{`check`: cancelAwareFunc},
+		},
+		{
+			jsonpath:     `$.a.check()`,
+			inputJSON:    `{"a":1}`,
+			expectedJSON: `[1]`,
+			functions:    map[string]config.Function{`check`: cancelAwareFunc},
+			ctx:          context.Background(),
+		},
+		{
+			jsonpath:    `$.a.check()`,
+			inputJSON:   `{"a":1}`,
+			expectedErr: createErrorFunctionFailed(`.check()`, `context canceled`),
+			functions:   map[string]config.Function{`check`: cancelAwareFunc},
+			ctx:         canceledContext,
+		},
+	}
+
+	runTestCases(t, "TestContextFunction_Cancellation", testCases)
+}
@@ -0,0 +1,533 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+func TestExpressionFunction_Predicates(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$[?(isEmail(@.email))].name`,
+			inputJSON:    `[{"name":"a","email":"a@x"},{"name":"b","email":"b"},{"name":"c"}]`,
+			expectedJSON: `["a"]`,
+			functions: map[string]config.Function{
+				`isEmail`: isEmailExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(!isEmail(@.email))].name`,
+			inputJSON:    `[{"name":"a","email":"a@x"},{"name":"b","email":"b"},{"name":"c"}]`,
+			expectedJSON: `["b","c"]`,
+			functions: map[string]config.Function{
+				`isEmail`: isEmailExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?( isEmail( @.email ) && @.name != 'a' )].name`,
+			inputJSON:    `[{"name":"a","email":"a@x"},{"name":"b","email":"b@x"}]`,
+			expectedJSON: `["b"]`,
+			functions: map[string]config.Function{
+				`isEmail`: isEmailExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(isEmail($.admin))].name`,
+			inputJSON:    `{"admin":"root@x","user":{"name":"a"}}`,
+			expectedJSON: `["a"]`,
+			functions: map[string]config.Function{
+				`isEmail`: isEmailExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(isEmail($.admin))].name`,
+			inputJSON:   `{"admin":"root","user":{"name":"a"}}`,
+			expectedErr: createErrorMemberNotExist(`[?(isEmail($.admin))]`),
+			functions: map[string]config.Function{
+				`isEmail`: isEmailExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(not(@.a))]`,
+			inputJSON:    `[{"a":1},{"b":2}]`,
+			expectedJSON: `[{"b":2}]`,
+			functions: map[string]config.Function{
+				`not`: notExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(not(@.a > 1 || @.b))]`,
+			inputJSON:    `[{"a":1},{"a":2},{"b":2}]`,
+			expectedJSON: `[{"a":1}]`,
+			functions: map[string]config.Function{
+				`not`: notExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(elements(@))]`,
+			inputJSON:    `[[1],[],"a"]`,
+			expectedJSON: `[[1]]`,
+			functions: map[string]config.Function{
+				`elements`: elementsExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(not(elements(@)))]`,
+			inputJSON:    `[[1],[],"a"]`,
+			expectedJSON: `[[],"a"]`,
+			functions: map[string]config.Function{
+				`elements`: elementsExpression,
+				`not`:      notExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(error())]`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorMemberNotExist(`[?(error())]`),
+			functions: map[string]config.Function{
+				`error`: errExpression,
+			},
+		},
+	}
+
+	runTestCases(t, "TestExpressionFunction_Predicates", testCases)
+}
+
+func TestExpressionFunction_Comparisons(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$[?(lower(@.name) == 'bob')].id`,
+			inputJSON:    `[{"id":1,"name":"Bob"},{"id":2,"name":"Alice"},{"id":3,"name":"BOB"},{"id":4}]`,
+			expectedJSON: `[1,3]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?('bob' != lower(@.name))].id`,
+			inputJSON:    `[{"id":1,"name":"Bob"},{"id":2,"name":"Alice"},{"id":3,"name":1}]`,
+			expectedJSON: `[2,3]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(lower(@.name) >= 'b')].id`,
+			inputJSON:    `[{"id":1,"name":"Bob"},{"id":2,"name":"Alice"}]`,
+			expectedJSON: `[1]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?('b' < lower(@.name))].id`,
+			inputJSON:    `[{"id":1,"name":"Bob"},{"id":2,"name":"Alice"}]`,
+			expectedJSON: `[1]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(lower(@.name) =~ /^b/)].id`,
+			inputJSON:    `[{"id":1,"name":"Bob"},{"id":2,"name":"Alice"}]`,
+			expectedJSON: `[1]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:     `$.users[?(lower(@.name) == lower($.target))].id`,
+			inputJSON:    `{"target":"ALICE","users":[{"id":1,"name":"Bob"},{"id":2,"name":"Alice"}]}`,
+			expectedJSON: `[2]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(count(@.tags[*]) > 1)].id`,
+			inputJSON:    `[{"id":1,"tags":["a","b"]},{"id":2,"tags":["a"]},{"id":3}]`,
+			expectedJSON: `[1]`,
+			functions: map[string]config.Function{
+				`count`: countExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(count(@.tags[*]) == 0)].id`,
+			inputJSON:    `[{"id":1,"tags":["a","b"]},{"id":2,"tags":[]},{"id":3}]`,
+			expectedJSON: `[2,3]`,
+			functions: map[string]config.Function{
+				`count`: countExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(count(elements(@.tags)) == 1)].id`,
+			inputJSON:    `[{"id":1,"tags":["a","b"]},{"id":2,"tags":["a"]}]`,
+			expectedJSON: `[2]`,
+			functions: map[string]config.Function{
+				`count`:    countExpression,
+				`elements`: elementsExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(count($[*]) == 2)]`,
+			inputJSON:    `[1,2]`,
+			expectedJSON: `[1,2]`,
+			functions: map[string]config.Function{
+				`count`: countExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(lower(@.t[*].concat()) == 'ab')].n`,
+			inputJSON:    `[{"n":"x","t":["A","B"]},{"n":"y","t":["C","D"]}]`,
+			expectedJSON: `["x"]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+			standardFunctions: true,
+		},
+		{
+			jsonpath:     `$[?(lower(@.t[*].concat()) == 'cd')].n`,
+			inputJSON:    `[{"n":"x","t":["A","B"]},{"n":"y","t":["C","D"]}]`,
+			expectedJSON: `["y"]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+			standardFunctions: true,
+		},
+		{
+			jsonpath:     `$.users[?(lower($.names[*].concat()) == @.name)].id`,
+			inputJSON:    `{"names":["A","B"],"users":[{"id":1,"name":"ab"},{"id":2,"name":"cd"}]}`,
+			expectedJSON: `[1]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+			standardFunctions: true,
+		},
+		{
+			jsonpath:    `$[?(lower($[0].n) == 'ab')]`,
+			inputJSON:   `[{"n":1},{"n":2}]`,
+			expectedErr: createErrorMemberNotExist(`[?(lower($[0].n) == 'ab')]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?('ab' == lower($[0].n))]`,
+			inputJSON:   `[{"n":1},{"n":2}]`,
+			expectedErr: createErrorMemberNotExist(`[?('ab' == lower($[0].n))]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(lower($[0].n) == $.missing)]`,
+			inputJSON:   `[{"n":1},{"n":2}]`,
+			expectedErr: createErrorMemberNotExist(`[?(lower($[0].n) == $.missing)]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(@.n == lower($[0].n))]`,
+			inputJSON:   `[{"n":1},{"n":2}]`,
+			expectedErr: createErrorMemberNotExist(`[?(@.n == lower($[0].n))]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(lower($[0].n) != 'ab')].n`,
+			inputJSON:    `[{"n":1},{"n":2}]`,
+			expectedJSON: `[1,2]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+	}
+
+	runTestCases(t, "TestExpressionFunction_Comparisons", testCases)
+}
+
+func TestExpressionFunction_RegisteredFunctions(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$[?(twice(@) == 4)]`,
+			inputJSON:    `[1,2,3]`,
+			expectedJSON: `[2]`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFunc,
+			},
+		},
+		{
+			jsonpath:     `$[?(max(@[*]) == 3)]`,
+			inputJSON:    `[[1,3],[2]]`,
+			expectedJSON: `[[1,3]]`,
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxFunc,
+			},
+		},
+		{
+			jsonpath:     `$[?(round(@, 0) == 3)]`,
+			inputJSON:    `[1.2,2.5,3.4]`,
+			expectedJSON: `[2.5,3.4]`,
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:     `$[?(join(@[*], '-') == '1-2')]`,
+			inputJSON:    `[[1,2],[2]]`,
+			expectedJSON: `[[1,2]]`,
+			functions: map[string]config.Function{
+				`join`: joinFunc,
+			},
+		},
+	}
+
+	runTestCases(t, "TestExpressionFunction_RegisteredFunctions", testCases)
+}
+
+func TestExpressionFunction_ErrorCases(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:    `$[?(unknown(@))]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorFunctionNotFound(`unknown(@)`),
+		},
+		{
+			jsonpath:    `$[?(lower(@))]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function result type unmatched (expected=logical/nodes, found=value)`, `lower(@))]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(isEmail(@) == true)]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function result type unmatched (expected=value, found=logical)`, `isEmail(@) == true)]`),
+			functions: map[string]config.Function{
+				`isEmail`: isEmailExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(lower(@, 1) == 'a')]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `wrong number of function arguments (expected=1, found=2)`, `lower(@, 1) == 'a')]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(lower(@[*]) == 'a')]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function argument type unmatched (argument=1, expected=value)`, `lower(@[*]) == 'a')]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(lower(@ == 1) == 'a')]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function argument type unmatched (argument=1, expected=value)`, `lower(@ == 1) == 'a')]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(count(1) == 1)]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function argument type unmatched (argument=1, expected=nodes)`, `count(1) == 1)]`),
+			functions: map[string]config.Function{
+				`count`: countExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(not(1))]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function argument type unmatched (argument=1, expected=logical)`, `not(1))]`),
+			functions: map[string]config.Function{
+				`not`: notExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(not(lower(@)))]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function argument type unmatched (argument=1, expected=logical)`, `not(lower(@)))]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+				`not`:   notExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(lower(@.a) == @.b)]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `comparison between two current nodes is prohibited`, `lower(@.a) == @.b)]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(lower(@.a) == lower(@.b))]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `comparison between two current nodes is prohibited`, `lower(@.a) == lower(@.b))]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(cnt(nodesBad(@.a)) == 2)]`,
+			inputJSON:   `[{"a":1}]`,
+			expectedErr: createErrorMemberNotExist(`[?(cnt(nodesBad(@.a)) == 2)]`),
+			aggregates: map[string]func([]any) (any, error){
+				`cnt`: func(params []any) (any, error) { return float64(len(params)), nil },
+			},
+			functions: map[string]config.Function{
+				`nodesBad`: nodesBadExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(count(nodesBad(@.a)) == 2)]`,
+			inputJSON:   `[{"a":1}]`,
+			expectedErr: createErrorMemberNotExist(`[?(count(nodesBad(@.a)) == 2)]`),
+			functions: map[string]config.Function{
+				`count`:    countExpression,
+				`nodesBad`: nodesBadExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(lower(@.a) == 'a'`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(1, `unrecognized input`, `[?(lower(@.a) == 'a'`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+			},
+		},
+	}
+
+	runTestCases(t, "TestExpressionFunction_ErrorCases", testCases)
+}
+
+func TestExpressionFunction_TypedResults(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$.items[?(@.n == size($.list[*]))].id`,
+			inputJSON:    `{"list":[1,2],"items":[{"id":1,"n":2},{"id":2,"n":3}]}`,
+			expectedJSON: `[1]`,
+			functions: map[string]config.Function{
+				`size`: sizeExpression,
+			},
+		},
+		{
+			jsonpath:     `$.items[?(@.n >= size($.list[*]))].id`,
+			inputJSON:    `{"list":[1,2],"items":[{"id":1,"n":2},{"id":2,"n":3},{"id":3,"n":"3"}]}`,
+			expectedJSON: `[1,2]`,
+			functions: map[string]config.Function{
+				`size`: sizeExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(half(@.n) < 2)].n`,
+			inputJSON:    `[{"n":2},{"n":4},{"n":"a"}]`,
+			expectedJSON: `[2]`,
+			functions: map[string]config.Function{
+				`half`: halfExpression,
+			},
+		},
+		{
+			jsonpath:     `$.items[?(@.name > upper($.from))].name`,
+			inputJSON:    `{"from":"b","items":[{"name":"A"},{"name":"C"},{"name":1}]}`,
+			expectedJSON: `["C"]`,
+			functions: map[string]config.Function{
+				`upper`: upperExpression,
+			},
+		},
+		{
+			jsonpath:     `$[?(lower(upper(@.name)) == 'bob')].id`,
+			inputJSON:    `[{"id":1,"name":"Bob"},{"id":2,"name":"Alice"}]`,
+			expectedJSON: `[1]`,
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+				`upper`: upperExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(numberBad() == 1)]`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorMemberNotExist(`[?(numberBad() == 1)]`),
+			functions: map[string]config.Function{
+				`numberBad`: numberBadExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(half('a') == 1)]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function argument type unmatched (argument=1, expected=number)`, `half('a') == 1)]`),
+			functions: map[string]config.Function{
+				`half`: halfExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(upper(1) == 'A')]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function argument type unmatched (argument=1, expected=string)`, `upper(1) == 'A')]`),
+			functions: map[string]config.Function{
+				`upper`: upperExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(upper(lower(@.a)) == 'A')]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function argument type unmatched (argument=1, expected=string)`, `upper(lower(@.a)) == 'A')]`),
+			functions: map[string]config.Function{
+				`lower`: lowerExpression,
+				`upper`: upperExpression,
+			},
+		},
+		{
+			jsonpath:    `$[?(upper(@.a))]`,
+			inputJSON:   `[]`,
+			expectedErr: createErrorInvalidSyntax(4, `function result type unmatched (expected=logical/nodes, found=string)`, `upper(@.a))]`),
+			functions: map[string]config.Function{
+				`upper`: upperExpression,
+			},
+		},
+	}
+
+	runTestCases(t, "TestExpressionFunction_TypedResults", testCases)
+}
+
+func TestExpressionFunction_TypedComparators(t *testing.T) {
+	config := config.Config{}
+	config.SetFunction(`size`, sizeExpression)
+	config.SetFunction(`upper`, upperExpression)
+	config.SetFunction(`lower`, lowerExpression)
+
+	testCases := []struct {
+		jsonpath           string
+		expectedComparator string
+	}{
+		{jsonpath: `$[?(@.a >= size($[*]))]`, expectedComparator: `compare >= (number)`},
+		{jsonpath: `$[?(size($[*]) < @.a)]`, expectedComparator: `compare > (number)`},
+		{jsonpath: `$[?(@.a == size($[*]))]`, expectedComparator: `compare ==`},
+		{jsonpath: `$[?(@.a <= upper($.b))]`, expectedComparator: `compare <= (string)`},
+		{jsonpath: `$[?(@.a > lower($.b))]`, expectedComparator: `compare >`},
+		{jsonpath: `$[?(@.a == lower($.b))]`, expectedComparator: `compare == (deep)`},
+	}
+
+	for _, testCase := range testCases {
+		query, err := jsonpath.Compile(testCase.jsonpath, config)
+		if err != nil {
+			t.Errorf("expected error<nil> != actual error<%s>\n", err)
+			continue
+		}
+		comparator := strings.TrimSpace(strings.Split(query.Plan(), "\n")[1])
+		if comparator != testCase.expectedComparator {
+			t.Errorf("%s: expected comparator<%s> != actual comparator<%s>\n",
+				testCase.jsonpath, testCase.expectedComparator, comparator)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+func TestFunctionArguments_LiteralArguments(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$[*].round(2)`,
+			inputJSON:    `[1.2345,2.5]`,
+			expectedJSON: `[1.23,2.5]`,
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:     `$[*].round( 0 )`,
+			inputJSON:    `[1.2345,2.5]`,
+			expectedJSON: `[1,3]`,
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:     `$.echo(1,'a',true)`,
+			inputJSON:    `{}`,
+			expectedJSON: `[[1,"a",true]]`,
+			functions: map[string]config.Function{
+				`echo`: echoArgsFunc,
+			},
+		},
+		{
+			jsonpath:     `$.echo(null , "b" , false)`,
+			inputJSON:    `{}`,
+			expectedJSON: `[[null,"b",false]]`,
+			functions: map[string]config.Function{
+				`echo`: echoArgsFunc,
+			},
+		},
+		{
+			jsonpath:     `$[*].join(', ')`,
+			inputJSON:    `[1,"a",true]`,
+			expectedJSON: `["1, a, true"]`,
+			functions: map[string]config.Function{
+				`join`: joinFunc,
+			},
+		},
+		{
+			jsonpath:     `$[*].round(1).join('-')`,
+			inputJSON:    `[1.25,2.75]`,
+			expectedJSON: `["1.3-2.8"]`,
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+				`join`:  joinFunc,
+			},
+		},
+	}
+
+	runTestCases(t, "TestFunctionArguments_LiteralArguments", testCases)
+}
+
+func TestFunctionArguments_PathArguments(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:     `$.values[*].default($.fallback)`,
+			inputJSON:    `{"values":[1,null],"fallback":"none"}`,
+			expectedJSON: `[1,"none"]`,
+			functions: map[string]config.Function{
+				`default`: defaultFunc,
+			},
+		},
+		{
+			jsonpath:     `$.values[*].default( $.fallback.value )`,
+			inputJSON:    `{"values":[null],"fallback":{"value":2}}`,
+			expectedJSON: `[2]`,
+			functions: map[string]config.Function{
+				`default`: defaultFunc,
+			},
+		},
+		{
+			jsonpath:     `$.values[*].echo(@,@.a,$.b)`,
+			inputJSON:    `{"values":[{"a":1}],"b":2}`,
+			expectedJSON: `[[{"a":1},1,2]]`,
+			functions: map[string]config.Function{
+				`echo`: echoArgsFunc,
+			},
+		},
+		{
+			jsonpath:     `$.values[*].round($.digits)`,
+			inputJSON:    `{"values":[1.2345],"digits":1}`,
+			expectedJSON: `[1.2]`,
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:     `$.values.join($.separator)`,
+			inputJSON:    `{"values":["a","b"],"separator":"/"}`,
+			expectedJSON: `["a/b"]`,
+			functions: map[string]config.Function{
+				`join`: joinFunc,
+			},
+		},
+		{
+			jsonpath:     `$[?(@.round(0) == 3)]`,
+			inputJSON:    `[1.2345,2.5,3.4]`,
+			expectedJSON: `[2.5,3.4]`,
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+	}
+
+	runTestCases(t, "TestFunctionArguments_PathArguments", testCases)
+}
+
+func TestFunctionArguments_ErrorCases(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:    `$[*].round()`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorInvalidSyntax(4, `wrong number of function arguments (expected=1, found=0)`, `.round()`),
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:    `$[*].round(1,2)`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorInvalidSyntax(4, `wrong number of function arguments (expected=1, found=2)`, `.round(1,2)`),
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:    `$[*].twice(1)`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorInvalidSyntax(4, `wrong number of function arguments (expected=0, found=1)`, `.twice(1)`),
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFunc,
+			},
+		},
+		{
+			jsonpath:    `$[*].max(1)`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorInvalidSyntax(4, `wrong number of function arguments (expected=0, found=1)`, `.max(1)`),
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxFunc,
+			},
+		},
+		{
+			jsonpath:    `$[*].round(1)`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorFunctionNotFound(`.round(1)`),
+		},
+		{
+			jsonpath:    `$.values[*].default($.fallback[*])`,
+			inputJSON:   `{}`,
+			expectedErr: createErrorInvalidSyntax(20, `function argument that returns a value group is prohibited`, `$.fallback[*])`),
+			functions: map[string]config.Function{
+				`default`: defaultFunc,
+			},
+		},
+		{
+			jsonpath:    `$.values[*].default(@..a)`,
+			inputJSON:   `{}`,
+			expectedErr: createErrorInvalidSyntax(20, `function argument that returns a value group is prohibited`, `@..a)`),
+			functions: map[string]config.Function{
+				`default`: defaultFunc,
+			},
+		},
+		{
+			jsonpath:    `$[*].round(a)`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorInvalidSyntax(10, `unrecognized input`, `(a)`),
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:    `$[*].round(1`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorInvalidSyntax(10, `unrecognized input`, `(1`),
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+		{
+			jsonpath:    `$.values[*].default($.fallback)`,
+			inputJSON:   `{"values":[null]}`,
+			expectedErr: createErrorFunctionFailed(`.default($.fallback)`, `member did not exist (path=.fallback)`),
+			functions: map[string]config.Function{
+				`default`: defaultFunc,
+			},
+		},
+		{
+			jsonpath:    `$[*].round('a')`,
+			inputJSON:   `[1]`,
+			expectedErr: createErrorFunctionFailed(`.round('a')`, `argument type error`),
+			functions: map[string]config.Function{
+				`round`: roundFunc,
+			},
+		},
+	}
+
+	runTestCases(t, "TestFunctionArguments_ErrorCases", testCases)
+}
@@ -0,0 +1,481 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+func TestStandardFunctions_AggregateFunctions(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:          `$[*].min()`,
+			inputJSON:         `[3,1,2]`,
+			expectedJSON:      `[1]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].min()`,
+			inputJSON:         `[3,1.5,2]`,
+			expectedJSON:      `[1.5]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$.a.max()`,
+			inputJSON:         `{"a":[3,1,2]}`,
+			expectedJSON:      `[3]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].max()`,
+			inputJSON:         `[3,10,2]`,
+			expectedJSON:      `[10]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$.big[*].max()`,
+			inputJSON:         `{"big":[9007199254740993,1]}`,
+			expectedJSON:      `[9007199254740993]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$[*].max()`,
+			inputJSON:         `[9007199254740992,9007199254740993]`,
+			expectedJSON:      `[9007199254740993]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$[*].min()`,
+			inputJSON:         `[9007199254740993,9007199254740992]`,
+			expectedJSON:      `[9007199254740992]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$[*].min()`,
+			inputJSON:         `[2,1.0,1]`,
+			expectedJSON:      `[1.0]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$[*].sum()`,
+			inputJSON:         `[1,2,3.5]`,
+			expectedJSON:      `[6.5]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].sum()`,
+			inputJSON:         `[1,2,3.5]`,
+			expectedJSON:      `[6.5]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$.a.sum()`,
+			inputJSON:         `{"a":[]}`,
+			expectedJSON:      `[0]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].avg()`,
+			inputJSON:         `[1,2,3]`,
+			expectedJSON:      `[2]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$..price.avg()`,
+			inputJSON:         `{"a":{"price":1},"b":[{"price":3}]}`,
+			expectedJSON:      `[2]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].count()`,
+			inputJSON:         `[1,"a",null]`,
+			expectedJSON:      `[3]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].first()`,
+			inputJSON:         `["a","b"]`,
+			expectedJSON:      `["a"]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].last()`,
+			inputJSON:         `["a","b"]`,
+			expectedJSON:      `["b"]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].distinct()`,
+			inputJSON:         `[1,"1",1,{"a":1},{"a":1},null,null]`,
+			expectedJSON:      `[[1,"1",{"a":1},null]]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].distinct()`,
+			inputJSON:         `[1,1.0,2]`,
+			expectedJSON:      `[[1,2]]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$[*].sort()`,
+			inputJSON:         `[3,1,2]`,
+			expectedJSON:      `[[1,2,3]]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].sort()`,
+			inputJSON:         `[3,1,2.5]`,
+			expectedJSON:      `[[1,2.5,3]]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$[*].sort()`,
+			inputJSON:         `["b","c","a"]`,
+			expectedJSON:      `[["a","b","c"]]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.a.sort()`,
+			inputJSON:         `{"a":[]}`,
+			expectedJSON:      `[[]]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].reverse()`,
+			inputJSON:         `[1,"a",null]`,
+			expectedJSON:      `[[null,"a",1]]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].concat()`,
+			inputJSON:         `["a","b","c"]`,
+			expectedJSON:      `["abc"]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].concat()`,
+			inputJSON:         `[[1],[2,3],[]]`,
+			expectedJSON:      `[[1,2,3]]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].sort().last()`,
+			inputJSON:         `[3,1,2]`,
+			expectedJSON:      `[3]`,
+			standardFunctions: true,
+		},
+	}
+
+	runTestCases(t, "TestStandardFunctions_AggregateFunctions", testCases)
+}
+
+func TestStandardFunctions_FilterFunctions(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:          `$.keys()`,
+			inputJSON:         `{"b":1,"a":2}`,
+			expectedJSON:      `[["a","b"]]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.values()`,
+			inputJSON:         `{"b":1,"a":2}`,
+			expectedJSON:      `[[2,1]]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].length()`,
+			inputJSON:         `["abc","日本",[1,2],{"a":1}]`,
+			expectedJSON:      `[3,2,2,1]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].lower()`,
+			inputJSON:         `["ABC","Def"]`,
+			expectedJSON:      `["abc","def"]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].upper()`,
+			inputJSON:         `["abc","Def"]`,
+			expectedJSON:      `["ABC","DEF"]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].trim()`,
+			inputJSON:         `[" a ","b\t"]`,
+			expectedJSON:      `["a","b"]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].toNumber()`,
+			inputJSON:         `["1.5"," 2 ",3]`,
+			expectedJSON:      `[1.5,2,3]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].toNumber()`,
+			inputJSON:         `[1.5,"2"]`,
+			expectedJSON:      `[1.5,2]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$[*].toString()`,
+			inputJSON:         `["a",1.5,true,null]`,
+			expectedJSON:      `["a","1.5","true","null"]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].toString()`,
+			inputJSON:         `[100000000000000000000,1.50]`,
+			expectedJSON:      `["100000000000000000000","1.50"]`,
+			standardFunctions: true,
+			unmarshalFunc:     useJSONNumberDecoderFunction,
+		},
+		{
+			jsonpath:          `$[*].toNumber().sum()`,
+			inputJSON:         `["1","2"]`,
+			expectedJSON:      `[3]`,
+			standardFunctions: true,
+		},
+	}
+
+	runTestCases(t, "TestStandardFunctions_FilterFunctions", testCases)
+}
+
+func TestStandardFunctions_FilterExpressions(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:          `$[?(length(@.tags) > 1)].id`,
+			inputJSON:         `[{"id":1,"tags":["a","b"]},{"id":2,"tags":["a"]}]`,
+			expectedJSON:      `[1]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[?(lower(@.name) == 'bob')].id`,
+			inputJSON:         `[{"id":1,"name":"BOB"},{"id":2,"name":"alice"}]`,
+			expectedJSON:      `[1]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[?(sum(@.items[*].price) >= 10)].id`,
+			inputJSON:         `[{"id":1,"items":[{"price":4},{"price":6}]},{"id":2,"items":[{"price":1}]}]`,
+			expectedJSON:      `[1]`,
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[?(count(@.*) == 2)].id`,
+			inputJSON:         `[{"id":1,"a":1},{"id":2}]`,
+			expectedJSON:      `[1]`,
+			standardFunctions: true,
+		},
+	}
+
+	runTestCases(t, "TestStandardFunctions_FilterExpressions", testCases)
+}
+
+func TestStandardFunctions_ErrorCases(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:          `$[*].min()`,
+			inputJSON:         `[1,"a"]`,
+			expectedErr:       createErrorFunctionFailed(`.min()`, `type unmatched (expected=number, found=string)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.a.max()`,
+			inputJSON:         `{"a":[]}`,
+			expectedErr:       createErrorFunctionFailed(`.max()`, `empty input`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.a.min()`,
+			inputJSON:         `{"a":[]}`,
+			expectedErr:       createErrorFunctionFailed(`.min()`, `empty input`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].sum()`,
+			inputJSON:         `[1,null]`,
+			expectedErr:       createErrorFunctionFailed(`.sum()`, `type unmatched (expected=number, found=null)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].avg()`,
+			inputJSON:         `[{}]`,
+			expectedErr:       createErrorFunctionFailed(`.avg()`, `type unmatched (expected=number, found=map[string]interface {})`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.a.avg()`,
+			inputJSON:         `{"a":[]}`,
+			expectedErr:       createErrorFunctionFailed(`.avg()`, `empty input`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.a.first()`,
+			inputJSON:         `{"a":[]}`,
+			expectedErr:       createErrorFunctionFailed(`.first()`, `empty input`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.a.last()`,
+			inputJSON:         `{"a":[]}`,
+			expectedErr:       createErrorFunctionFailed(`.last()`, `empty input`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].sort()`,
+			inputJSON:         `["a",1]`,
+			expectedErr:       createErrorFunctionFailed(`.sort()`, `mixed types (expected=string)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].sort()`,
+			inputJSON:         `[1,"a"]`,
+			expectedErr:       createErrorFunctionFailed(`.sort()`, `mixed types (expected=number)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].sort()`,
+			inputJSON:         `[true]`,
+			expectedErr:       createErrorFunctionFailed(`.sort()`, `type unmatched (expected=number/string, found=bool)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].concat()`,
+			inputJSON:         `["a",[1]]`,
+			expectedErr:       createErrorFunctionFailed(`.concat()`, `mixed types (expected=string)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].concat()`,
+			inputJSON:         `[[1],"a"]`,
+			expectedErr:       createErrorFunctionFailed(`.concat()`, `mixed types (expected=array)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$[*].concat()`,
+			inputJSON:         `[1]`,
+			expectedErr:       createErrorFunctionFailed(`.concat()`, `type unmatched (expected=string/array, found=float64)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.a.concat()`,
+			inputJSON:         `{"a":[]}`,
+			expectedErr:       createErrorFunctionFailed(`.concat()`, `empty input`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.keys()`,
+			inputJSON:         `[]`,
+			expectedErr:       createErrorFunctionFailed(`.keys()`, `type unmatched (expected=object, found=[]interface {})`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.values()`,
+			inputJSON:         `"a"`,
+			expectedErr:       createErrorFunctionFailed(`.values()`, `type unmatched (expected=object, found=string)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.length()`,
+			inputJSON:         `1`,
+			expectedErr:       createErrorFunctionFailed(`.length()`, `type unmatched (expected=string/array/object, found=float64)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.lower()`,
+			inputJSON:         `1`,
+			expectedErr:       createErrorFunctionFailed(`.lower()`, `type unmatched (expected=string, found=float64)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.upper()`,
+			inputJSON:         `null`,
+			expectedErr:       createErrorFunctionFailed(`.upper()`, `type unmatched (expected=string, found=null)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.trim()`,
+			inputJSON:         `true`,
+			expectedErr:       createErrorFunctionFailed(`.trim()`, `type unmatched (expected=string, found=bool)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.toNumber()`,
+			inputJSON:         `"1a"`,
+			expectedErr:       createErrorFunctionFailed(`.toNumber()`, `invalid number (value="1a")`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.toNumber()`,
+			inputJSON:         `true`,
+			expectedErr:       createErrorFunctionFailed(`.toNumber()`, `type unmatched (expected=number/string, found=bool)`),
+			standardFunctions: true,
+		},
+		{
+			jsonpath:          `$.toString()`,
+			inputJSON:         `[]`,
+			expectedErr:       createErrorFunctionFailed(`.toString()`, `type unmatched (expected=string/number/boolean/null, found=[]interface {})`),
+			standardFunctions: true,
+		},
+	}
+
+	runTestCases(t, "TestStandardFunctions_ErrorCases", testCases)
+}
+
+func TestStandardFunctions_Replaced(t *testing.T) {
+	testCases := []TestCase{
+		{
+			jsonpath:          `$[*].lower('x')`,
+			inputJSON:         `["ABC","Def"]`,
+			expectedJSON:      `["x","x"]`,
+			standardFunctions: true,
+			functions: map[string]config.Function{
+				`lower`: {Kind: config.FilterKind, Arity: 1,
+					Filter: func(_ config.FunctionContext, _ any, arguments []any) (any, error) {
+						return arguments[0], nil
+					}},
+			},
+		},
+		{
+			jsonpath:          `$.a.sum()`,
+			inputJSON:         `{"a":[1,2]}`,
+			expectedJSON:      `["$"]`,
+			standardFunctions: true,
+			functions: map[string]config.Function{
+				`sum`: {Kind: config.AggregateKind, WithContext: true,
+					Aggregate: func(context config.FunctionContext, _ []any, _ []any) (any, error) {
+						return context.Path, nil
+					}},
+			},
+		},
+		{
+			jsonpath:          `$[?(length(@.a) == 'replaced')].id`,
+			inputJSON:         `[{"id":1,"a":"abc"}]`,
+			expectedJSON:      `[1]`,
+			standardFunctions: true,
+			functions: map[string]config.Function{
+				`length`: {
+					Kind:    config.FilterKind,
+					Filter:  func(config.FunctionContext, any, []any) (any, error) { return `replaced`, nil },
+					Inverse: func(value any) (any, error) { return value, nil },
+				},
+			},
+		},
+	}
+
+	runTestCases(t, "TestStandardFunctions_Replaced", testCases)
+}
@@ -1,10 +1,15 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"runtime"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/AsaiYusuke/jsonpath/v2"
@@ -15,15 +20,27 @@ import (
 type TestGroup map[string][]TestCase
 
 type TestCase struct {
-	jsonpath        string
-	inputJSON       string
-	expectedJSON    string
-	expectedErr     error
-	unmarshalFunc   func(string, *any) error
-	filters         map[string]func(any) (any, error)
-	aggregates      map[string]func([]any) (any, error)
-	accessorMode    bool
-	resultValidator func(any, []any) error
+	jsonpath            string
+	inputJSON           string
+	expectedJSON        string
+	expectedErr         error
+	unmarshalFunc       func(string, *any) error
+	filters             map[string]func(any) (any, error)
+	aggregates          map[string]func([]any) (any, error)
+	functions           map[string]config.Function
+	userData            any
+	ctx                 context.Context
+	standardFunctions   bool
+	accessorMode        bool
+	optimization        bool
+	observer            config.Observer
+	collectAllErrors    bool
+	continueOnFuncError bool
+	documentOrder       bool
+	unordered           bool
+	formatStyle         config.FormatStyle
+	resultValidator     func(any, []any) error
+	queryValidator      func(*jsonpath.Query, any, config.Config) error
 }
 
 func createErrorFunctionFailed(functionName string, errorString string) errors.ErrorFunctionFailed {
@@ -56,14 +73,15 @@ func createErrorFunctionNotFound(function string) errors.ErrorFunctionNotFound {
 	return errors.NewErrorFunctionNotFound(function)
 }
 
-func execTestRetrieve(t *testing.T, inputJSON any, testCase TestCase, fileLine string) ([]any, error) {
-	jsonPath := testCase.jsonpath
+// createTestConfig creates the config of the test case, and reports whether the test case sets any of it.
+func createTestConfig(testCase TestCase) (config.Config, bool) {
 	hasConfig := false
 	config := config.Config{}
-	expectedError := testCase.expectedErr
-	var actualObject []any
-	var err error
 
+	if testCase.standardFunctions {
+		hasConfig = true
+		config.SetStandardFunctions()
+	}
 	if len(testCase.filters) > 0 {
 		hasConfig = true
 		for id, function := range testCase.filters {
@@ -76,15 +94,119 @@ func execTestRetrieve(t *testing.T, inputJSON any, testCase TestCase, fileLine s
 			config.SetAggregateFunction(id, function)
 		}
 	}
+	if len(testCase.functions) > 0 {
+		hasConfig = true
+		for id, function := range testCase.functions {
+			config.SetFunction(id, function)
+		}
+	}
+	if testCase.userData != nil {
+		hasConfig = true
+		config.SetUserData(testCase.userData)
+	}
 	if testCase.accessorMode {
 		hasConfig = true
 		config.SetAccessorMode()
 	}
+	if testCase.optimization {
+		hasConfig = true
+		config.SetOptimization()
+	}
+	if testCase.observer != nil {
+		hasConfig = true
+		config.SetObserver(testCase.observer)
+	}
+	if testCase.collectAllErrors {
+		hasConfig = true
+		config.SetCollectAllErrors()
+	}
+	if testCase.continueOnFuncError {
+		hasConfig = true
+		config.SetContinueOnFunctionError()
+	}
+	if testCase.documentOrder {
+		hasConfig = true
+		config.SetDocumentOrder()
+	}
+	if testCase.unordered {
+		hasConfig = true
+		config.SetUnordered()
+	}
+	// The format style is used only by Format, so that it does not change the retrieval.
+	config.SetFormatStyle(testCase.formatStyle)
+	return config, hasConfig
+}
 
+// retrieveTestCase retrieves the JSONPath of the test case with the config of the test case.
+func retrieveTestCase(inputJSON any, testCase TestCase) ([]any, error) {
+	jsonPath := testCase.jsonpath
+	config, hasConfig := createTestConfig(testCase)
+
+	if testCase.ctx != nil {
+		return jsonpath.RetrieveContext(testCase.ctx, jsonPath, inputJSON, config)
+	}
 	if hasConfig {
-		actualObject, err = jsonpath.Retrieve(jsonPath, inputJSON, config)
-	} else {
-		actualObject, err = jsonpath.Retrieve(jsonPath, inputJSON)
+		return jsonpath.Retrieve(jsonPath, inputJSON, config)
+	}
+	return jsonpath.Retrieve(jsonPath, inputJSON)
+}
+
+// execTestCompile compiles the JSONPath of the test case with the config of the test case,
+// and validates the query if the test case has the query validator.
+// It reports whether the JSONPath is compiled.
+func execTestCompile(t *testing.T, inputJSON any, testCase TestCase, fileLine string) bool {
+	config, _ := createTestConfig(testCase)
+	query, err := jsonpath.Compile(testCase.jsonpath, config)
+	if err != nil {
+		if reflect.TypeOf(testCase.expectedErr) != reflect.TypeOf(err) ||
+			fmt.Sprintf(`%s`, testCase.expectedErr) != fmt.Sprintf(`%s`, err) {
+			t.Errorf("%s: expected error<%s> != actual error<%s>\n", fileLine, testCase.expectedErr, err)
+		}
+		return false
+	}
+
+	if testCase.queryValidator != nil {
+		if err := testCase.queryValidator(query, inputJSON, config); err != nil {
+			t.Errorf("%s: Error: %v", fileLine, err)
+		}
+	}
+	return true
+}
+
+func execTestRetrieve(t *testing.T, inputJSON any, testCase TestCase, fileLine string) ([]any, error) {
+	expectedError := testCase.expectedErr
+	actualObject, err := retrieveTestCase(inputJSON, testCase)
+
+	isCollecting := testCase.collectAllErrors || testCase.continueOnFuncError
+	if isCollecting && testCase.optimization {
+		// The optimized query must collect the same results and errors as the query without the optimization.
+		referenceTestCase := testCase
+		referenceTestCase.optimization = false
+		referenceObject, referenceErr := retrieveTestCase(inputJSON, referenceTestCase)
+		if fmt.Sprint(referenceObject, referenceErr) != fmt.Sprint(actualObject, err) {
+			t.Errorf("%s: expected<%v %v> != actual<%v %v>\n", fileLine, referenceObject, referenceErr, actualObject, err)
+			return nil, err
+		}
+	}
+
+	if multipleErr, ok := err.(errors.ErrorMultiple); ok && isCollecting {
+		// The error of the retrieval without the collection is one of the collected errors.
+		if expectedError == nil {
+			// The retrieval that succeeded without the collection only records the failed branches beside the results.
+			if len(actualObject) == 0 {
+				t.Errorf("%s: expected results != actual no results with error<%s>\n", fileLine, err)
+				return nil, err
+			}
+			return actualObject, nil
+		}
+		for _, branchErr := range multipleErr.Errors {
+			if reflect.TypeOf(expectedError) == reflect.TypeOf(branchErr.Err) &&
+				fmt.Sprintf(`%s`, expectedError) == fmt.Sprintf(`%s`, branchErr.Err) {
+				return nil, err
+			}
+		}
+		t.Errorf("%s: expected error<%s> is not in actual error<%s>\n", fileLine, expectedError, err)
+		return nil, err
 	}
 
 	if err != nil {
@@ -105,10 +227,26 @@ func execTestRetrieve(t *testing.T, inputJSON any, testCase TestCase, fileLine s
 }
 
 func runTestCase(t *testing.T, testCase TestCase, fileLine string) {
+	if observer, ok := testCase.observer.(validatingObserver); ok {
+		defer func() {
+			if err := observer.validate(); err != nil {
+				t.Errorf("%s: Error: %v", fileLine, err)
+			}
+		}()
+	}
+
 	srcJSON := testCase.inputJSON
 	var src any
 	var err error
 
+	// The test case without the input only compiles the JSONPath.
+	if srcJSON == `` {
+		if execTestCompile(t, src, testCase, fileLine) && testCase.expectedErr != nil {
+			t.Errorf("%s: expected error<%s> != actual error<none>\n", fileLine, testCase.expectedErr)
+		}
+		return
+	}
+
 	if testCase.unmarshalFunc != nil {
 		err = testCase.unmarshalFunc(srcJSON, &src)
 	} else {
@@ -119,6 +257,13 @@ func runTestCase(t *testing.T, testCase TestCase, fileLine string) {
 		return
 	}
 
+	if testCase.queryValidator != nil {
+		execTestCompile(t, src, testCase, fileLine)
+		if t.Failed() {
+			return
+		}
+	}
+
 	actualObject, err := execTestRetrieve(t, src, testCase, fileLine)
 	if t.Failed() {
 		return
@@ -141,6 +286,14 @@ func runTestCase(t *testing.T, testCase TestCase, fileLine string) {
 		return
 	}
 
+	if testCase.unordered {
+		if !isSameElements(testCase.expectedJSON, actualObject) {
+			t.Errorf("%s: expectedOutputJSON<%s> != actualOutputJSON<%s> in any order\n",
+				fileLine, testCase.expectedJSON, actualOutputJSON)
+		}
+		return
+	}
+
 	if string(actualOutputJSON) != testCase.expectedJSON {
 		t.Errorf("%s: expectedOutputJSON<%s> != actualOutputJSON<%s>\n",
 			fileLine, testCase.expectedJSON, actualOutputJSON)
@@ -148,6 +301,27 @@ func runTestCase(t *testing.T, testCase TestCase, fileLine string) {
 	}
 }
 
+// isSameElements reports whether the results have the same elements as the expected JSON array in any order.
+func isSameElements(expectedJSON string, actualObject []any) bool {
+	var expectedElements []json.RawMessage
+	if err := json.Unmarshal([]byte(expectedJSON), &expectedElements); err != nil {
+		return false
+	}
+	if len(expectedElements) != len(actualObject) {
+		return false
+	}
+	expectedStrings := make([]string, len(expectedElements))
+	actualStrings := make([]string, len(actualObject))
+	for index := range expectedElements {
+		expectedStrings[index] = string(expectedElements[index])
+		actualElementJSON, _ := json.Marshal(actualObject[index])
+		actualStrings[index] = string(actualElementJSON)
+	}
+	slices.Sort(expectedStrings)
+	slices.Sort(actualStrings)
+	return slices.Equal(expectedStrings, actualStrings)
+}
+
 func runTestCases(t *testing.T, testGroupName string, testCases []TestCase) {
 	for i, testCase := range testCases {
 		if _, file, line, ok := runtime.Caller(2); ok {
@@ -207,6 +381,257 @@ var quarterFunc = func(param any) (any, error) {
 	return nil, fmt.Errorf(`type error`)
 }
 
+var roundFunc = config.Function{
+	Kind:  config.FilterKind,
+	Arity: 1,
+	Filter: func(_ config.FunctionContext, param any, args []any) (any, error) {
+		input, ok := param.(float64)
+		if !ok {
+			return nil, fmt.Errorf(`type error`)
+		}
+		digits, ok := args[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf(`argument type error`)
+		}
+		scale := math.Pow(10, digits)
+		return math.Round(input*scale) / scale, nil
+	},
+}
+
+var defaultFunc = config.Function{
+	Kind:  config.FilterKind,
+	Arity: 1,
+	Filter: func(_ config.FunctionContext, param any, args []any) (any, error) {
+		if param == nil {
+			return args[0], nil
+		}
+		return param, nil
+	},
+}
+
+var echoArgsFunc = config.Function{
+	Kind:  config.FilterKind,
+	Arity: 3,
+	Filter: func(_ config.FunctionContext, _ any, args []any) (any, error) {
+		return args, nil
+	},
+}
+
+var joinFunc = config.Function{
+	Kind:  config.AggregateKind,
+	Arity: 1,
+	Aggregate: func(_ config.FunctionContext, params []any, args []any) (any, error) {
+		separator, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf(`argument type error`)
+		}
+		texts := make([]string, len(params))
+		for index, param := range params {
+			texts[index] = fmt.Sprint(param)
+		}
+		return strings.Join(texts, separator), nil
+	},
+}
+
+var lowerExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.ValueType},
+	ResultType: config.ValueType,
+	Expression: func(args []any) (any, error) {
+		if text, ok := args[0].(string); ok {
+			return strings.ToLower(text), nil
+		}
+		return nil, fmt.Errorf(`type error`)
+	},
+}
+
+// sizeExpression returns the number of the elements as json.Number.
+var sizeExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.NodesType},
+	ResultType: config.NumberType,
+	Expression: func(args []any) (any, error) {
+		return json.Number(fmt.Sprint(len(args[0].([]any)))), nil
+	},
+}
+
+var halfExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.NumberType},
+	ResultType: config.NumberType,
+	Expression: func(args []any) (any, error) {
+		if number, ok := args[0].(float64); ok {
+			return number / 2, nil
+		}
+		return nil, fmt.Errorf(`type error`)
+	},
+}
+
+var upperExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.StringType},
+	ResultType: config.StringType,
+	Expression: func(args []any) (any, error) {
+		if text, ok := args[0].(string); ok {
+			return strings.ToUpper(text), nil
+		}
+		return nil, fmt.Errorf(`type error`)
+	},
+}
+
+// numberBadExpression is declared to return the number, but returns the string.
+var numberBadExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{},
+	ResultType: config.NumberType,
+	Expression: func(args []any) (any, error) {
+		return `1`, nil
+	},
+}
+
+var isEmailExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.ValueType},
+	ResultType: config.LogicalType,
+	Expression: func(args []any) (any, error) {
+		text, ok := args[0].(string)
+		return ok && strings.Contains(text, `@`), nil
+	},
+}
+
+var countExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.NodesType},
+	ResultType: config.ValueType,
+	Expression: func(args []any) (any, error) {
+		return float64(len(args[0].([]any))), nil
+	},
+}
+
+var notExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.LogicalType},
+	ResultType: config.LogicalType,
+	Expression: func(args []any) (any, error) {
+		return !args[0].(bool), nil
+	},
+}
+
+var elementsExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.ValueType},
+	ResultType: config.NodesType,
+	Expression: func(args []any) (any, error) {
+		elements, _ := args[0].([]any)
+		return elements, nil
+	},
+}
+
+// nodesBadExpression is declared to return the nodes, but returns the string.
+var nodesBadExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{config.ValueType},
+	ResultType: config.NodesType,
+	Expression: func(args []any) (any, error) {
+		return `not nodes`, nil
+	},
+}
+
+var errExpression = config.Function{
+	Kind:       config.ExpressionKind,
+	ParamTypes: []config.FunctionType{},
+	ResultType: config.LogicalType,
+	Expression: func(args []any) (any, error) {
+		return nil, fmt.Errorf(`expression error`)
+	},
+}
+
+var pathFunc = config.Function{
+	Kind:        config.FilterKind,
+	WithContext: true,
+	Filter: func(ctx config.FunctionContext, _ any, _ []any) (any, error) {
+		return ctx.Path, nil
+	},
+}
+
+var pathsAggregateFunc = config.Function{
+	Kind:        config.AggregateKind,
+	WithContext: true,
+	Aggregate: func(ctx config.FunctionContext, params []any, _ []any) (any, error) {
+		return []any{ctx.Path, float64(len(params))}, nil
+	},
+}
+
+var refFunc = config.Function{
+	Kind:        config.FilterKind,
+	WithContext: true,
+	Filter: func(ctx config.FunctionContext, param any, _ []any) (any, error) {
+		name, ok := param.(string)
+		if !ok {
+			return nil, fmt.Errorf(`type error`)
+		}
+		definitions, _ := ctx.Root.(map[string]any)[`definitions`].(map[string]any)
+		definition, ok := definitions[name]
+		if !ok {
+			return nil, fmt.Errorf(`definition not found (name=%s)`, name)
+		}
+		return definition, nil
+	},
+}
+
+var userDataFunc = config.Function{
+	Kind:        config.FilterKind,
+	WithContext: true,
+	Arity:       1,
+	Filter: func(ctx config.FunctionContext, param any, args []any) (any, error) {
+		key, _ := args[0].(string)
+		return ctx.UserData.(map[string]any)[key], nil
+	},
+}
+
+var cancelAwareFunc = config.Function{
+	Kind:        config.FilterKind,
+	WithContext: true,
+	Filter: func(ctx config.FunctionContext, param any, _ []any) (any, error) {
+		if err := ctx.Context.Err(); err != nil {
+			return nil, err
+		}
+		return param, nil
+	},
+}
+
+var toCentsFunc = config.Function{
+	Kind: config.FilterKind,
+	Filter: func(_ config.FunctionContext, param any, _ []any) (any, error) {
+		if input, ok := param.(float64); ok {
+			return math.Round(input * 100), nil
+		}
+		return nil, fmt.Errorf(`type error`)
+	},
+	Inverse: func(param any) (any, error) {
+		if input, ok := param.(float64); ok {
+			return input / 100, nil
+		}
+		return nil, fmt.Errorf(`inverse type error`)
+	},
+}
+
+var negateFunc = config.Function{
+	Kind: config.FilterKind,
+	Filter: func(_ config.FunctionContext, param any, _ []any) (any, error) {
+		if input, ok := param.(float64); ok {
+			return -input, nil
+		}
+		return nil, fmt.Errorf(`type error`)
+	},
+	Inverse: func(param any) (any, error) {
+		if input, ok := param.(float64); ok {
+			return -input, nil
+		}
+		return nil, fmt.Errorf(`inverse type error`)
+	},
+}
+
 var errAggregateFunc = func(param []any) (any, error) {
 	return nil, fmt.Errorf("aggregate error")
 }
@@ -302,13 +727,91 @@ func createGetOnlyValidator(expected any) func(any, []any) error {
 		if !reflect.DeepEqual(got, expected) {
 			return fmt.Errorf("get-only: expected %#v, got %#v", expected, got)
 		}
-		if accessor.Set != nil {
-			return fmt.Errorf("get-only: accessor.Set expected nil, got non-nil")
+		if _, ok := accessor.TrySet(nil).(errors.ErrorReadOnly); !ok {
+			return fmt.Errorf("get-only: accessor.TrySet expected ErrorReadOnly")
+		}
+		if _, ok := catchPanic(func() { accessor.Set(nil) }).(errors.ErrorReadOnly); !ok {
+			return fmt.Errorf("get-only: accessor.Set expected to panic with ErrorReadOnly")
+		}
+		if !reflect.DeepEqual(accessor.Get(), expected) {
+			return fmt.Errorf("get-only: value changed %#v", accessor.Get())
 		}
 		return nil
 	}
 }
 
+func catchPanic(f func()) (err error) {
+	defer func() {
+		if exception := recover(); exception != nil {
+			err, _ = exception.(error)
+		}
+	}()
+	f()
+	return nil
+}
+
+func createReversibleValidator(
+	expectedGet, setValue any, srcGetter func(any) any, expectedSrc any) func(any, []any) error {
+	return func(src any, actualObject []any) error {
+		accessor := actualObject[0].(config.Accessor)
+
+		if getValue := accessor.Get(); getValue != expectedGet {
+			return fmt.Errorf(`get : expect<%v> != actual<%v>`, expectedGet, getValue)
+		}
+
+		if err := accessor.TrySet(setValue); err != nil {
+			return fmt.Errorf(`try set : %v`, err)
+		}
+
+		if srcValue := srcGetter(src); srcValue != expectedSrc {
+			return fmt.Errorf(`set -> src : expect<%v> != actual<%v>`, expectedSrc, srcValue)
+		}
+
+		if getValue := accessor.Get(); getValue != setValue {
+			return fmt.Errorf(`set -> get : expect<%v> != actual<%v>`, setValue, getValue)
+		}
+
+		return nil
+	}
+}
+
+func createTrySetErrorValidator(setValue any, expectedErr error) func(any, []any) error {
+	return func(src any, actualObject []any) error {
+		accessor := actualObject[0].(config.Accessor)
+
+		err := accessor.TrySet(setValue)
+		if reflect.TypeOf(err) != reflect.TypeOf(expectedErr) || fmt.Sprint(err) != fmt.Sprint(expectedErr) {
+			return fmt.Errorf(`try set : expect<%v> != actual<%v>`, expectedErr, err)
+		}
+
+		if panicErr := catchPanic(func() { accessor.Set(setValue) }); fmt.Sprint(panicErr) != fmt.Sprint(expectedErr) {
+			return fmt.Errorf(`set : expect panic<%v> != actual<%v>`, expectedErr, panicErr)
+		}
+
+		return nil
+	}
+}
+
+// createTryGetErrorValidator creates the validator that changes the source node to the value,
+// and checks that reading the accessor fails.
+func createTryGetErrorValidator(change func(any), expectedErr error) func(any, []any) error {
+	return func(src any, actualObject []any) error {
+		accessor := actualObject[0].(config.Accessor)
+		change(src)
+
+		value, err := accessor.TryGet()
+		if value != nil || reflect.TypeOf(err) != reflect.TypeOf(expectedErr) || fmt.Sprint(err) != fmt.Sprint(expectedErr) {
+			return fmt.Errorf(`try get : expect<<nil> %v> != actual<%v %v>`, expectedErr, value, err)
+		}
+
+		if panicErr := catchPanic(func() { accessor.Get() }); fmt.Sprint(panicErr) != fmt.Sprint(expectedErr) {
+			return fmt.Errorf(`get : expect panic<%v> != actual<%v>`, expectedErr, panicErr)
+		}
+
+		return nil
+	}
+}
+
 var sliceStructChangedResultValidator = func(src any, actualObject []any) error {
 	srcArray := src.([]any)
 	accessor := actualObject[0].(config.Accessor)
@@ -398,3 +901,42 @@ func twiceFilter(item any) (any, error) {
 func errorFilter(item any) (any, error) {
 	return nil, createErrorFunctionFailed("errFilter", "test error")
 }
+
+// validatingObserver is the observer that validates the events after the retrieval of the test case.
+type validatingObserver interface {
+	config.Observer
+	validate() error
+}
+
+// countingObserver counts the retrievals to check that each start has its end.
+type countingObserver struct {
+	mutex       sync.Mutex
+	queryStarts int
+	queryEnds   int
+}
+
+func (o *countingObserver) OnQueryStart(ctx context.Context, _ string) context.Context {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.queryStarts++
+	return ctx
+}
+
+func (o *countingObserver) OnNodeVisit(context.Context, string) {}
+
+func (o *countingObserver) OnFunctionCall(context.Context, string, error) {}
+
+func (o *countingObserver) OnQueryEnd(context.Context, string, int, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.queryEnds++
+}
+
+func (o *countingObserver) validate() error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.queryStarts != o.queryEnds {
+		return fmt.Errorf(`query start<%d> != query end<%d>`, o.queryStarts, o.queryEnds)
+	}
+	return nil
+}
@@ -2,19 +2,22 @@ package tests
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
 )
 
-func execParserFunc(jsonPath, srcJSON string, b *testing.B) {
+func execParserFunc(jsonPath, srcJSON string, b *testing.B, config ...config.Config) {
 	var src any
 	if err := json.Unmarshal([]byte(srcJSON), &src); err != nil {
 		b.Errorf(`%s`, err)
 		return
 	}
 
-	parserFunc, err := jsonpath.Parse(jsonPath)
+	parserFunc, err := jsonpath.Parse(jsonPath, config...)
 	if err != nil {
 		b.Errorf(`%s`, err)
 		return
@@ -203,3 +206,77 @@ func BenchmarkParserFunc_recursive_index(b *testing.B) {
 
 	execParserFunc(jsonPath, srcJSON, b)
 }
+
+func optimizationConfig() config.Config {
+	config := config.Config{}
+	config.SetOptimization()
+	return config
+}
+
+func BenchmarkParserFunc_optimized_recursive_name(b *testing.B) {
+	jsonPath := `$..price`
+	srcJSON := `{"store":{"book":[{"title":"a","price":8.95},{"title":"b","price":12.99},{"title":"c"}],"bicycle":{"color":"red","price":19.95}}}`
+	b.Run(`plain`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b) })
+	b.Run(`optimized`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, optimizationConfig()) })
+}
+
+func BenchmarkParserFunc_optimized_wildcard_chain(b *testing.B) {
+	jsonPath := `$[*][*]`
+	srcJSON := `[[1,2,3],[4,5,6],[7,8,9]]`
+	b.Run(`plain`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b) })
+	b.Run(`optimized`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, optimizationConfig()) })
+}
+
+func BenchmarkParserFunc_optimized_filter_root(b *testing.B) {
+	jsonPath := `$.items[*][?(@.price <= $.limit && 1 == 1)]`
+	srcJSON := `{"limit":10,"items":[[{"price":5},{"price":20}],[{"price":7},{"price":30}],[{"price":1}]]}`
+	b.Run(`plain`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b) })
+	b.Run(`optimized`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, optimizationConfig()) })
+}
+
+// wideObjectJSON returns the object that has the given number of members with the price.
+func wideObjectJSON(width int) string {
+	var builder strings.Builder
+	builder.WriteByte('{')
+	for index := range width {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+		fmt.Fprintf(&builder, `"item%d":{"price":%d}`, width-index, index)
+	}
+	builder.WriteByte('}')
+	return builder.String()
+}
+
+func sortedConfig() config.Config {
+	config := config.Config{}
+	config.SetStandardFunctions()
+	return config
+}
+
+func unorderedConfig() config.Config {
+	config := sortedConfig()
+	config.SetUnordered()
+	return config
+}
+
+func BenchmarkParserFunc_unordered_wildcard(b *testing.B) {
+	jsonPath := `$.*.price.sum()`
+	srcJSON := wideObjectJSON(1000)
+	b.Run(`sorted`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, sortedConfig()) })
+	b.Run(`unordered`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, unorderedConfig()) })
+}
+
+func BenchmarkParserFunc_unordered_filter(b *testing.B) {
+	jsonPath := `$[?(@.price >= 500)].price.sum()`
+	srcJSON := wideObjectJSON(1000)
+	b.Run(`sorted`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, sortedConfig()) })
+	b.Run(`unordered`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, unorderedConfig()) })
+}
+
+func BenchmarkParserFunc_unordered_recursive(b *testing.B) {
+	jsonPath := `$..price.sum()`
+	srcJSON := `{"store":` + wideObjectJSON(1000) + `}`
+	b.Run(`sorted`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, sortedConfig()) })
+	b.Run(`unordered`, func(b *testing.B) { execParserFunc(jsonPath, srcJSON, b, unorderedConfig()) })
+}
@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+func TestErrorInvalidSyntax_Diagnostic(t *testing.T) {
+	testCases := []struct {
+		jsonpath         string
+		expectedNear     string
+		expectedLine     int
+		expectedColumn   int
+		expectedExpected string
+		expectedHint     string
+	}{
+		{
+			jsonpath:         `$.a[?(@.b = 1)]`,
+			expectedNear:     `[?(@.b = 1)]`,
+			expectedLine:     1,
+			expectedColumn:   11,
+			expectedExpected: `')', '==', '!=', '<', '<=', '>', '>=', '=~', '&&', '||'`,
+			expectedHint:     `use '==' to compare the values`,
+		},
+		{
+			jsonpath:         `$.a[@.b==1]`,
+			expectedNear:     `[@.b==1]`,
+			expectedLine:     1,
+			expectedColumn:   5,
+			expectedExpected: `':', '*', number, string`,
+			expectedHint:     `write the filter as '[?(...)]'`,
+		},
+		{
+			jsonpath:       `$[?(@.a=='x)]`,
+			expectedNear:   `[?(@.a=='x)]`,
+			expectedLine:   1,
+			expectedColumn: 14,
+			expectedHint:   `the string literal that starts at column 10 is not closed`,
+		},
+		{
+			jsonpath:         `$.a..`,
+			expectedNear:     `..`,
+			expectedLine:     1,
+			expectedColumn:   6,
+			expectedExpected: `'[', '*', member name`,
+			expectedHint:     `'..' must be followed by a member name, '*' or a bracketed selection`,
+		},
+		{
+			jsonpath:         `$.あい.`,
+			expectedNear:     `.`,
+			expectedLine:     1,
+			expectedColumn:   6,
+			expectedExpected: `'*', member name`,
+		},
+		{
+			jsonpath:         `$['あい'].`,
+			expectedNear:     `.`,
+			expectedLine:     1,
+			expectedColumn:   8,
+			expectedExpected: `end of input, '.', '..', '['`,
+		},
+		{
+			jsonpath:         `$[?(@.a > 1 && )]`,
+			expectedNear:     `[?(@.a > 1 && )]`,
+			expectedLine:     1,
+			expectedColumn:   16,
+			expectedExpected: `'(', '$', '@', '!', number, string`,
+		},
+		{
+			jsonpath:         "$[?(@.a=~/it's/ && @['a\nb'] = 2)]",
+			expectedNear:     "[?(@.a=~/it's/ && @['a\nb'] = 2)]",
+			expectedLine:     2,
+			expectedColumn:   5,
+			expectedExpected: `')', '==', '!=', '<', '<=', '>', '>=', '=~', '&&', '||'`,
+			expectedHint:     `use '==' to compare the values`,
+		},
+		{
+			jsonpath:       `$[?(@.a==@.b)]`,
+			expectedNear:   `@.a==@.b)]`,
+			expectedLine:   1,
+			expectedColumn: 5,
+		},
+		{
+			jsonpath:         `$` + strings.Repeat(`.a`, 200) + `.`,
+			expectedNear:     `.`,
+			expectedLine:     1,
+			expectedColumn:   403,
+			expectedExpected: `'*', member name`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.jsonpath, func(t *testing.T) {
+			_, err := jsonpath.Compile(testCase.jsonpath)
+			syntaxErr, ok := err.(errors.ErrorInvalidSyntax)
+			if !ok {
+				t.Fatalf("expected error<errors.ErrorInvalidSyntax> != actual error<%v>\n", err)
+			}
+			// The error stays comparable, so that it can be the key of a map and compared with ==.
+			if _, again := jsonpath.Compile(testCase.jsonpath); again != err {
+				t.Errorf("expected error<%v> == actual error<%v>\n", err, again)
+			}
+			if syntaxErr.JSONPath != testCase.jsonpath {
+				t.Errorf("expected jsonpath<%s> != actual jsonpath<%s>\n", testCase.jsonpath, syntaxErr.JSONPath)
+			}
+			if syntaxErr.Near != testCase.expectedNear {
+				t.Errorf("expected near<%s> != actual near<%s>\n", testCase.expectedNear, syntaxErr.Near)
+			}
+			if syntaxErr.Line != testCase.expectedLine || syntaxErr.Column != testCase.expectedColumn {
+				t.Errorf("expected line:column<%d:%d> != actual line:column<%d:%d>\n",
+					testCase.expectedLine, testCase.expectedColumn, syntaxErr.Line, syntaxErr.Column)
+			}
+			if syntaxErr.Expected != testCase.expectedExpected {
+				t.Errorf("expected expected<%v> != actual expected<%v>\n", testCase.expectedExpected, syntaxErr.Expected)
+			}
+			if syntaxErr.Hint != testCase.expectedHint {
+				t.Errorf("expected hint<%s> != actual hint<%s>\n", testCase.expectedHint, syntaxErr.Hint)
+			}
+		})
+	}
+}
+
+func TestErrorInvalidSyntax_Pretty(t *testing.T) {
+	testCases := []struct {
+		err      errors.ErrorInvalidSyntax
+		expected string
+	}{
+		{
+			err: errors.ErrorInvalidSyntax{
+				Position: 1, Reason: `unrecognized input`, Near: "[?(@.a &&\n\t@.b = 1)]",
+				JSONPath: "$[?(@.a &&\n\t@.b = 1)]", Line: 2, Column: 6,
+				Expected: `'==', '!='`, Hint: `use '==' to compare the values`,
+			},
+			expected: "invalid syntax at line 2, column 6: unrecognized input\n" +
+				"  \t@.b = 1)]\n" +
+				"  \t    ^\n" +
+				"expected: '==', '!='\n" +
+				"hint: use '==' to compare the values",
+		},
+		{
+			err: errors.ErrorInvalidSyntax{
+				Position: 4, Reason: `comparison between two current nodes is prohibited`, Near: `@.a==@.b)]`,
+				JSONPath: `$[?(@.a==@.b)]`, Line: 1, Column: 5,
+			},
+			expected: "invalid syntax at line 1, column 5: comparison between two current nodes is prohibited\n" +
+				"  $[?(@.a==@.b)]\n" +
+				"      ^",
+		},
+		{
+			err:      errors.NewErrorInvalidSyntax(1, `unrecognized input`, `.`),
+			expected: `invalid syntax (position=1, reason=unrecognized input, near=.)`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expected, func(t *testing.T) {
+			if actual := testCase.err.Pretty(); actual != testCase.expected {
+				t.Errorf("expected<%s> != actual<%s>\n", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	goerrors "errors"
+	"strconv"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+func TestErrors_IsSentinel(t *testing.T) {
+	sentinels := []error{
+		errors.ErrSyntax, errors.ErrNotFound, errors.ErrTypeUnmatched, errors.ErrFunctionFailed, errors.ErrReadOnly,
+		errors.ErrTypeConversion,
+	}
+
+	testCases := []struct {
+		jsonpath         string
+		expectedSentinel error
+	}{
+		{jsonpath: `$.`, expectedSentinel: errors.ErrSyntax},
+		{jsonpath: `$[?(1.0.0>0)]`, expectedSentinel: errors.ErrSyntax},
+		{jsonpath: `$.unknown()`, expectedSentinel: errors.ErrSyntax},
+		{jsonpath: `$[(command)]`, expectedSentinel: errors.ErrSyntax},
+		{jsonpath: `$.a`, expectedSentinel: errors.ErrNotFound},
+		{jsonpath: `$[0]`, expectedSentinel: errors.ErrTypeUnmatched},
+		{jsonpath: `$.errFilter()`, expectedSentinel: errors.ErrFunctionFailed},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.jsonpath, func(t *testing.T) {
+			_, err := jsonpath.Retrieve(testCase.jsonpath, map[string]any{}, errFilterConfig())
+			for _, sentinel := range sentinels {
+				expected := sentinel == testCase.expectedSentinel
+				if actual := goerrors.Is(err, sentinel); actual != expected {
+					t.Errorf("expected errors.Is(%v, %v)<%t> != actual<%t>\n", err, sentinel, expected, actual)
+				}
+			}
+		})
+	}
+
+	err := errors.NewErrorReadOnly(&errors.ErrorBasicRuntime{})
+	if !goerrors.Is(err, errors.ErrReadOnly) || goerrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("expected errors.Is(%v, ErrReadOnly) only\n", err)
+	}
+}
+
+func errFilterConfig() config.Config {
+	config := config.Config{}
+	config.SetFilterFunction(`errFilter`, errFilterFunc)
+	return config
+}
+
+func TestErrors_Unwrap(t *testing.T) {
+	config := config.Config{}
+	config.SetFunction(`check`, cancelAwareFunc)
+
+	_, err := jsonpath.RetrieveContext(canceledContext, `$.a.check()`, map[string]any{`a`: 1.0}, config)
+	if !goerrors.Is(err, context.Canceled) {
+		t.Errorf("expected errors.Is(%v, context.Canceled)<true> != actual<false>\n", err)
+	}
+	if !goerrors.Is(err, errors.ErrFunctionFailed) {
+		t.Errorf("expected errors.Is(%v, ErrFunctionFailed)<true> != actual<false>\n", err)
+	}
+
+	_, err = jsonpath.Retrieve(`$[?(1.0.0>0)]`, nil)
+	var numError *strconv.NumError
+	if !goerrors.As(err, &numError) || numError.Num != `1.0.0` {
+		t.Errorf("expected errors.As(%v, *strconv.NumError)<true> != actual<false>\n", err)
+	}
+	if !goerrors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected errors.Is(%v, strconv.ErrSyntax)<true> != actual<false>\n", err)
+	}
+}
+
+func TestErrors_As(t *testing.T) {
+	_, err := jsonpath.Retrieve(`$.a.b`, map[string]any{`a`: map[string]any{}})
+
+	var valueTarget errors.ErrorMemberNotExist
+	if !goerrors.As(err, &valueTarget) || valueTarget.GetPath() != `.b` {
+		t.Errorf("expected errors.As(%v, *ErrorMemberNotExist)<true> != actual<false>\n", err)
+	}
+
+	var pointerTarget *errors.ErrorMemberNotExist
+	if !goerrors.As(err, &pointerTarget) || pointerTarget.GetPath() != `.b` {
+		t.Errorf("expected errors.As(%v, **ErrorMemberNotExist)<true> != actual<false>\n", err)
+	}
+
+	var otherTarget *errors.ErrorTypeUnmatched
+	if goerrors.As(err, &otherTarget) {
+		t.Errorf("expected errors.As(%v, **ErrorTypeUnmatched)<false> != actual<true>\n", err)
+	}
+
+	// The pointer to the error is also matched with the value target.
+	pointerErr := &valueTarget
+	var fromPointer errors.ErrorMemberNotExist
+	if !goerrors.As(pointerErr, &fromPointer) || fromPointer.GetPath() != `.b` {
+		t.Errorf("expected errors.As(%v, *ErrorMemberNotExist)<true> != actual<false>\n", pointerErr)
+	}
+
+	wrappedErr := goerrors.Join(goerrors.New(`other`), err)
+	var wrappedTarget *errors.ErrorMemberNotExist
+	if !goerrors.As(wrappedErr, &wrappedTarget) || !goerrors.Is(wrappedErr, errors.ErrNotFound) {
+		t.Errorf("expected errors.As(%v, **ErrorMemberNotExist)<true> != actual<false>\n", wrappedErr)
+	}
+}
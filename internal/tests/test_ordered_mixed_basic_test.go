@@ -0,0 +1,280 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/ordered"
+)
+
+var useOrderedDecoderFunction = func(srcJSON string, src *any) error {
+	return ordered.Unmarshal([]byte(srcJSON), src)
+}
+
+func TestOrdered_Retrieve(t *testing.T) {
+	testGroups := TestGroup{
+		`document-order`: []TestCase{
+			{
+				jsonpath:      `$.*`,
+				inputJSON:     `{"c":1,"a":2,"b":3}`,
+				expectedJSON:  `[1,2,3]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				documentOrder: true,
+			},
+			{
+				jsonpath:      `$[*]`,
+				inputJSON:     `{"c":1,"a":2,"b":3}`,
+				expectedJSON:  `[1,2,3]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				documentOrder: true,
+			},
+			{
+				jsonpath:      `$.*.*`,
+				inputJSON:     `{"y":{"d":1,"c":2},"x":[3,{"b":4,"a":5}]}`,
+				expectedJSON:  `[1,2,3,{"b":4,"a":5}]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				documentOrder: true,
+			},
+			{
+				jsonpath:      `$[?(@ > 1)]`,
+				inputJSON:     `{"c":3,"a":1,"b":2}`,
+				expectedJSON:  `[3,2]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				documentOrder: true,
+			},
+			{
+				jsonpath:      `$..a`,
+				inputJSON:     `{"z":{"a":1},"y":[{"a":2}],"a":3}`,
+				expectedJSON:  `[3,1,2]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				documentOrder: true,
+			},
+			{
+				jsonpath:      `$..*`,
+				inputJSON:     `{"b":{"d":1,"c":2},"a":3}`,
+				expectedJSON:  `[{"d":1,"c":2},3,1,2]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				documentOrder: true,
+			},
+			{
+				jsonpath:      `$..[?(@.v)].v`,
+				inputJSON:     `{"b":{"v":1},"a":[{"v":2}],"c":{"v":3}}`,
+				expectedJSON:  `[1,3,2]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				documentOrder: true,
+			},
+		},
+		`sorted-order`: []TestCase{
+			{
+				jsonpath:      `$.*`,
+				inputJSON:     `{"c":1,"a":2,"b":3}`,
+				expectedJSON:  `[2,3,1]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+			},
+			{
+				jsonpath:      `$[?(@ > 1)]`,
+				inputJSON:     `{"c":3,"a":1,"b":2}`,
+				expectedJSON:  `[2,3]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+			},
+			{
+				jsonpath:      `$..a`,
+				inputJSON:     `{"z":{"a":1},"y":[{"a":2}],"a":3}`,
+				expectedJSON:  `[3,2,1]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+			},
+			{
+				jsonpath:      `$.*`,
+				inputJSON:     `{"c":1,"a":2,"b":3}`,
+				expectedJSON:  `[2,3,1]`,
+				documentOrder: true,
+			},
+		},
+		`member`: []TestCase{
+			{
+				jsonpath:      `$.b.y`,
+				inputJSON:     `{"b":{"y":{"k":1,"j":2}}}`,
+				expectedJSON:  `[{"k":1,"j":2}]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+			},
+			{
+				jsonpath:      `$['c','a','z']`,
+				inputJSON:     `{"a":1,"b":2,"c":3}`,
+				expectedJSON:  `[3,1]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+			},
+			{
+				jsonpath:      `$.z`,
+				inputJSON:     `{"a":1}`,
+				expectedErr:   createErrorMemberNotExist(`.z`),
+				unmarshalFunc: useOrderedDecoderFunction,
+			},
+			{
+				jsonpath:      `$[0]`,
+				inputJSON:     `{"a":1}`,
+				expectedErr:   createErrorTypeUnmatched(`[0]`, `array`, `*ordered.Object`),
+				unmarshalFunc: useOrderedDecoderFunction,
+			},
+			{
+				jsonpath:      `$[?(@.a == $[1].a)].b`,
+				inputJSON:     `[{"a":{"x":1,"y":{"p":1,"q":2}},"b":1},{"a":{"y":{"q":2,"p":1},"x":1},"b":2}]`,
+				expectedJSON:  `[1,2]`,
+				unmarshalFunc: useOrderedDecoderFunction,
+			},
+		},
+		`function`: []TestCase{
+			{
+				jsonpath:          `$.length()`,
+				inputJSON:         `{"b":1,"a":2}`,
+				expectedJSON:      `[2]`,
+				unmarshalFunc:     useOrderedDecoderFunction,
+				standardFunctions: true,
+			},
+			{
+				jsonpath:          `$.keys()`,
+				inputJSON:         `{"b":1,"a":2}`,
+				expectedJSON:      `[["a","b"]]`,
+				unmarshalFunc:     useOrderedDecoderFunction,
+				standardFunctions: true,
+			},
+			{
+				jsonpath:          `$.*.distinct()`,
+				inputJSON:         `{"x":{"a":1,"b":2},"y":{"b":2,"a":1}}`,
+				expectedJSON:      `[[{"a":1,"b":2}]]`,
+				unmarshalFunc:     useOrderedDecoderFunction,
+				standardFunctions: true,
+				documentOrder:     true,
+			},
+		},
+		`accessor`: []TestCase{
+			{
+				jsonpath:      `$.*`,
+				inputJSON:     `{"b":1,"a":2}`,
+				unmarshalFunc: useOrderedDecoderFunction,
+				accessorMode:  true,
+				documentOrder: true,
+				resultValidator: func(src any, actualObject []any) error {
+					for index, accessor := range actualObject {
+						accessor.(config.Accessor).Set(float64(index + 10))
+					}
+					actual, _ := json.Marshal(src)
+					if expected := `{"b":10,"a":11}`; string(actual) != expected {
+						return fmt.Errorf(`expected<%s> != actual<%s>`, expected, actual)
+					}
+					return nil
+				},
+			},
+		},
+	}
+
+	runTestGroups(t, testGroups)
+}
+
+func TestOrdered_Unmarshal(t *testing.T) {
+	testCases := []struct {
+		inputJSON    string
+		expectedJSON string
+		expectedErr  string
+	}{
+		{inputJSON: `{"b":1,"a":{"d":[true,null,"x"],"c":1.5}}`, expectedJSON: `{"b":1,"a":{"d":[true,null,"x"],"c":1.5}}`},
+		{inputJSON: ` [ {} , [] ] `, expectedJSON: `[{},[]]`},
+		{inputJSON: `{"a":1,"b":2,"a":3}`, expectedJSON: `{"a":3,"b":2}`},
+		{inputJSON: `"x"`, expectedJSON: `"x"`},
+		{inputJSON: `{"a":1} {}`, expectedErr: `ordered: invalid data after top-level value`},
+		{inputJSON: `{"a":1} x`, expectedErr: `invalid character 'x' looking for beginning of value`},
+		{inputJSON: `{"a":}`, expectedErr: `missing value after object key`},
+		{inputJSON: `{"a":1`, expectedErr: `unexpected end of JSON input`},
+		{inputJSON: ``, expectedErr: `EOF`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.inputJSON, func(t *testing.T) {
+			var src any
+			err := ordered.Unmarshal([]byte(testCase.inputJSON), &src)
+			if fmt.Sprint(err) != testCase.expectedErr && !(err == nil && testCase.expectedErr == ``) {
+				t.Errorf("expected error<%s> != actual error<%v>\n", testCase.expectedErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if actual, _ := json.Marshal(src); string(actual) != testCase.expectedJSON {
+				t.Errorf("expected JSON<%s> != actual JSON<%s>\n", testCase.expectedJSON, actual)
+			}
+		})
+	}
+}
+
+func TestOrdered_Object(t *testing.T) {
+	var object ordered.Object
+	if err := json.Unmarshal([]byte(`{"b":1,"a":2,"c":3}`), &object); err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	object.Set(`a`, 4)
+	object.Set(`d`, 5)
+	object.Delete(`b`)
+	object.Delete(`z`)
+
+	if expected := []string{`a`, `c`, `d`}; !slices.Equal(object.Keys(), expected) {
+		t.Errorf("expected keys<%v> != actual keys<%v>\n", expected, object.Keys())
+	}
+	if value, ok := object.Get(`a`); !ok || value != 4 {
+		t.Errorf("expected value<4> != actual value<%v>\n", value)
+	}
+	if object.Len() != 3 {
+		t.Errorf("expected length<3> != actual length<%d>\n", object.Len())
+	}
+	var keys []string
+	for key := range object.All() {
+		keys = append(keys, key)
+		break
+	}
+	if len(keys) != 1 || keys[0] != `a` {
+		t.Errorf("expected keys<[a]> != actual keys<%v>\n", keys)
+	}
+	if actual, _ := json.Marshal(&object); string(actual) != `{"a":4,"c":3,"d":5}` {
+		t.Errorf("expected JSON<{\"a\":4,\"c\":3,\"d\":5}> != actual JSON<%s>\n", actual)
+	}
+
+	var emptyObject ordered.Object
+	emptyObject.Set(`x`, nil)
+	if actual, _ := json.Marshal(&emptyObject); string(actual) != `{"x":null}` {
+		t.Errorf("expected JSON<{\"x\":null}> != actual JSON<%s>\n", actual)
+	}
+
+	if err := json.Unmarshal([]byte(`[1]`), &object); fmt.Sprint(err) != `ordered: cannot unmarshal array into Object` {
+		t.Errorf("expected error<ordered: cannot unmarshal array into Object> != actual error<%v>\n", err)
+	}
+}
+
+func TestOrdered_Equal(t *testing.T) {
+	var left, right any
+	ordered.Unmarshal([]byte(`{"a":[1,{"x":1,"y":2}],"b":null}`), &left)
+	ordered.Unmarshal([]byte(`{"b":null,"a":[1,{"y":2,"x":1}]}`), &right)
+	var plain any
+	json.Unmarshal([]byte(`{"b":null,"a":[1,{"y":2,"x":1}]}`), &plain)
+
+	testCases := []struct {
+		left     any
+		right    any
+		expected bool
+	}{
+		{left: left, right: right, expected: true},
+		{left: left, right: plain, expected: true},
+		{left: left, right: ordered.NewObject(), expected: false},
+		{left: left, right: []any{}, expected: false},
+		{left: []any{1.0}, right: []any{1.0, 2.0}, expected: false},
+		{left: []any{1.0}, right: 1.0, expected: false},
+		{left: `x`, right: `x`, expected: true},
+	}
+	for index, testCase := range testCases {
+		if actual := ordered.Equal(testCase.left, testCase.right); actual != testCase.expected {
+			t.Errorf("case %d: expected<%v> != actual<%v>\n", index, testCase.expected, actual)
+		}
+	}
+}
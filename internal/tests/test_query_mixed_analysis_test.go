@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+// expectAnalysis validates that the query is singular, refers to the root and has the member names as expected.
+func expectAnalysis(singular bool, rootRef bool, memberNames []string) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, _ any, _ config.Config) error {
+		if actual := query.IsSingular(); actual != singular {
+			return fmt.Errorf(`expected singular<%t> != actual singular<%t>`, singular, actual)
+		}
+		if actual := query.HasRootReference(); actual != rootRef {
+			return fmt.Errorf(`expected root reference<%t> != actual root reference<%t>`, rootRef, actual)
+		}
+		if actual := query.MemberNames(); !reflect.DeepEqual(actual, memberNames) {
+			return fmt.Errorf(`expected member names<%v> != actual member names<%v>`, memberNames, actual)
+		}
+		return nil
+	}
+}
+
+func TestQuery_IsSingular(t *testing.T) {
+	runTestCases(t, `TestQuery_IsSingular`, []TestCase{
+		{jsonpath: `$`, queryValidator: expectAnalysis(true, false, nil)},
+		{jsonpath: `$.a[0]['b'][-1]`, queryValidator: expectAnalysis(true, false, []string{`a`, `b`})},
+		{
+			jsonpath: `$.a.twice()`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFilter,
+			},
+			queryValidator: expectAnalysis(true, false, []string{`a`}),
+		},
+		{
+			jsonpath: `$[*].max()`,
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			queryValidator: expectAnalysis(true, false, nil),
+		},
+		{
+			jsonpath: `$[*].max().twice()`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFilter,
+			},
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			queryValidator: expectAnalysis(true, false, nil),
+		},
+		{jsonpath: `$.*`, queryValidator: expectAnalysis(false, false, nil)},
+		{
+			jsonpath: `$[*].twice()`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFilter,
+			},
+			queryValidator: expectAnalysis(false, false, nil),
+		},
+		{jsonpath: `$..a`, queryValidator: expectAnalysis(false, false, []string{`a`})},
+		{jsonpath: `$['a','b']`, queryValidator: expectAnalysis(false, false, []string{`a`, `b`})},
+		{jsonpath: `$[0,1]`, queryValidator: expectAnalysis(false, false, nil)},
+		{jsonpath: `$[0:1]`, queryValidator: expectAnalysis(false, false, nil)},
+		{jsonpath: `$.a[0][*]`, queryValidator: expectAnalysis(false, false, []string{`a`})},
+		{jsonpath: `$[?(@.a)]`, queryValidator: expectAnalysis(false, false, []string{`a`})},
+	})
+}
+
+func TestQuery_HasRootReference(t *testing.T) {
+	runTestCases(t, `TestQuery_HasRootReference`, []TestCase{
+		{jsonpath: `$[?(@.a==$.b)]`, queryValidator: expectAnalysis(false, true, []string{`a`, `b`})},
+		{jsonpath: `$[?($.b==@.a)]`, queryValidator: expectAnalysis(false, true, []string{`a`, `b`})},
+		{jsonpath: `$[?(@.a==1 || $.b)]`, queryValidator: expectAnalysis(false, true, []string{`a`, `b`})},
+		{jsonpath: `$[?(length($.b)>1)]`, standardFunctions: true, queryValidator: expectAnalysis(false, true, []string{`b`})},
+		{jsonpath: `$[?(@.a==1)]`, queryValidator: expectAnalysis(false, false, []string{`a`})},
+		{jsonpath: `$[?(length(@.a)>1)].b`, standardFunctions: true, queryValidator: expectAnalysis(false, false, []string{`a`, `b`})},
+	})
+}
+
+func TestQuery_MemberNames(t *testing.T) {
+	runTestCases(t, `TestQuery_MemberNames`, []TestCase{
+		{jsonpath: `$.users[?(@.role=='admin')].name`, queryValidator: expectAnalysis(false, false, []string{`users`, `role`, `name`})},
+		{jsonpath: `$['a','b'].a..b.c`, queryValidator: expectAnalysis(false, false, []string{`a`, `b`, `c`})},
+		{jsonpath: `$["a.b"]['']`, queryValidator: expectAnalysis(true, false, []string{`a.b`, ``})},
+	})
+}
@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+func getValue[T any](query *jsonpath.Query, src any) (any, error) {
+	return jsonpath.Get[T](query, src)
+}
+
+func getAllValues[T any](query *jsonpath.Query, src any) (any, error) {
+	return jsonpath.GetAll[T](query, src)
+}
+
+func TestQuery_Get(t *testing.T) {
+	testCases := []struct {
+		jsonpath      string
+		inputJSON     string
+		useNumber     bool
+		accessorMode  bool
+		get           func(*jsonpath.Query, any) (any, error)
+		expectedValue string
+		expectedErr   string
+	}{
+		{jsonpath: `$.a`, inputJSON: `{"a":"x"}`, get: getValue[string], expectedValue: `string:x`},
+		{jsonpath: `$.a`, inputJSON: `{"a":true}`, get: getValue[bool], expectedValue: `bool:true`},
+		{jsonpath: `$.a`, inputJSON: `{"a":[1,"x"]}`, get: getValue[[]any], expectedValue: `[]interface {}:[1 x]`},
+		{jsonpath: `$.a`, inputJSON: `{"a":{"b":1}}`, get: getValue[map[string]any], expectedValue: `map[string]interface {}:map[b:1]`},
+		{jsonpath: `$.a`, inputJSON: `{"a":null}`, get: getValue[any], expectedValue: `<nil>:<nil>`},
+		{jsonpath: `$.a`, inputJSON: `{"a":null}`, get: getValue[[]any], expectedValue: `[]interface {}:[]`},
+		{jsonpath: `$.a`, inputJSON: `{"a":1.5}`, get: getValue[float64], expectedValue: `float64:1.5`},
+		{jsonpath: `$.a`, inputJSON: `{"a":[1,2]}`, get: getValue[[]int], expectedValue: `[]int:[1 2]`},
+		{jsonpath: `$.a`, inputJSON: `{"a":[[1],[]]}`, get: getValue[[][]uint], expectedValue: `[][]uint:[[1] []]`},
+		{jsonpath: `$[*]`, inputJSON: `[1,2]`, get: getValue[int], expectedValue: `int:1`},
+
+		{jsonpath: `$.a`, inputJSON: `{"a":-3}`, get: getValue[int], expectedValue: `int:-3`},
+		{jsonpath: `$.a`, inputJSON: `{"a":127}`, get: getValue[int8], expectedValue: `int8:127`},
+		{jsonpath: `$.a`, inputJSON: `{"a":65535}`, get: getValue[uint16], expectedValue: `uint16:65535`},
+		{jsonpath: `$.a`, inputJSON: `{"a":1e3}`, get: getValue[int64], expectedValue: `int64:1000`},
+		{jsonpath: `$.a`, inputJSON: `{"a":1.5}`, get: getValue[float32], expectedValue: `float32:1.5`},
+		{jsonpath: `$.a`, inputJSON: `{"a":9007199254740993}`, useNumber: true, get: getValue[int64], expectedValue: `int64:9007199254740993`},
+		{jsonpath: `$.a`, inputJSON: `{"a":18446744073709551615}`, useNumber: true, get: getValue[uint64], expectedValue: `uint64:18446744073709551615`},
+		{jsonpath: `$.a`, inputJSON: `{"a":2.0}`, useNumber: true, get: getValue[int], expectedValue: `int:2`},
+		{jsonpath: `$.a`, inputJSON: `{"a":2.5}`, useNumber: true, get: getValue[float64], expectedValue: `float64:2.5`},
+		{jsonpath: `$.a`, inputJSON: `{"a":2.5}`, useNumber: true, get: getValue[json.Number], expectedValue: `json.Number:2.5`},
+		{jsonpath: `$.a`, inputJSON: `{"a":3}`, accessorMode: true, get: getValue[int], expectedValue: `int:3`},
+		{jsonpath: `$.a`, inputJSON: `{"a":3}`, accessorMode: true, get: getValue[config.Accessor], expectedValue: `config.Accessor:3`},
+
+		{jsonpath: `$.a`, inputJSON: `{"a":128}`, get: getValue[int8],
+			expectedErr: `type conversion failed (location=$['a'], expected=int8, found=float64, error=128 overflows int8)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":-1}`, get: getValue[uint],
+			expectedErr: `type conversion failed (location=$['a'], expected=uint, found=float64, error=-1 overflows uint)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":1e19}`, get: getValue[int64],
+			expectedErr: `type conversion failed (location=$['a'], expected=int64, found=float64, error=1e+19 overflows int64)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":1.5}`, get: getValue[int],
+			expectedErr: `type conversion failed (location=$['a'], expected=int, found=float64, error=1.5 is not an integer)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":1e39}`, get: getValue[float32],
+			expectedErr: `type conversion failed (location=$['a'], expected=float32, found=float64, error=1e+39 overflows float32)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":9223372036854775808}`, useNumber: true, get: getValue[int64],
+			expectedErr: `type conversion failed (location=$['a'], expected=int64, found=json.Number, error=9223372036854775808 overflows int64)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":1e400}`, useNumber: true, get: getValue[float64],
+			expectedErr: `type conversion failed (location=$['a'], expected=float64, found=json.Number, error=strconv.ParseFloat: parsing "1e400": value out of range)`},
+		{jsonpath: `$.a[1]`, inputJSON: `{"a":[1,"x"]}`, get: getValue[int],
+			expectedErr: `type conversion failed (location=$['a'][1], expected=int, found=string)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":[1,[2.5]]}`, get: getValue[[][]int],
+			expectedErr: `type conversion failed (location=$['a'][0], expected=[]int, found=float64)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":[[1],[2.5]]}`, get: getValue[[][]int],
+			expectedErr: `type conversion failed (location=$['a'][1][0], expected=int, found=float64, error=2.5 is not an integer)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":null}`, get: getValue[string],
+			expectedErr: `type conversion failed (location=$['a'], expected=string, found=null)`},
+		{jsonpath: `$.a`, inputJSON: `{"a":1}`, get: getValue[json.Number],
+			expectedErr: `type conversion failed (location=$['a'], expected=json.Number, found=float64)`},
+		{jsonpath: `$.a`, inputJSON: `{"b":1}`, get: getValue[int],
+			expectedErr: `member did not exist (path=.a)`},
+
+		{jsonpath: `$[*].a`, inputJSON: `[{"a":1},{"a":2}]`, get: getAllValues[int], expectedValue: `[]int:[1 2]`},
+		{jsonpath: `$..a`, inputJSON: `{"a":"x","b":{"a":"y"}}`, get: getAllValues[string], expectedValue: `[]string:[x y]`},
+		{jsonpath: `$[*].length()`, inputJSON: `[[1,2],"abc"]`, get: getAllValues[uint8], expectedValue: `[]uint8:[2 3]`},
+		{jsonpath: `$..a`, inputJSON: `{"a":1,"b":{"a":"x"}}`, get: getAllValues[int],
+			expectedErr: `type conversion failed (location=$['b']['a'], expected=int, found=string)`},
+		{jsonpath: `$.*.a`, inputJSON: `{"b":{}}`, get: getAllValues[int],
+			expectedErr: `member did not exist (path=.a)`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(fmt.Sprintf(`%s_%s`, testCase.jsonpath, testCase.inputJSON), func(t *testing.T) {
+			cfg := config.Config{}
+			cfg.SetStandardFunctions()
+			if testCase.accessorMode {
+				cfg.SetAccessorMode()
+			}
+			query, err := jsonpath.Compile(testCase.jsonpath, cfg)
+			if err != nil {
+				t.Errorf("expected error<nil> != actual error<%s>\n", err)
+				return
+			}
+
+			var src any
+			decoder := json.NewDecoder(bytes.NewBufferString(testCase.inputJSON))
+			if testCase.useNumber {
+				decoder.UseNumber()
+			}
+			if err := decoder.Decode(&src); err != nil {
+				t.Errorf("%s", err)
+				return
+			}
+
+			value, err := testCase.get(query, src)
+			if fmt.Sprint(err) != testCase.expectedErr && !(err == nil && testCase.expectedErr == ``) {
+				t.Errorf("expected error<%s> != actual error<%v>\n", testCase.expectedErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if accessor, ok := value.(config.Accessor); ok {
+				value = fmt.Sprintf(`%v`, accessor.Get())
+				if actual := fmt.Sprintf(`config.Accessor:%v`, value); actual != testCase.expectedValue {
+					t.Errorf("expected value<%s> != actual value<%s>\n", testCase.expectedValue, actual)
+				}
+				return
+			}
+			if actual := fmt.Sprintf(`%T:%v`, value, value); actual != testCase.expectedValue {
+				t.Errorf("expected value<%s> != actual value<%s>\n", testCase.expectedValue, actual)
+			}
+		})
+	}
+}
+
+func TestQuery_GetError(t *testing.T) {
+	query, _ := jsonpath.Compile(`$.a`)
+	_, err := jsonpath.Get[int8](query, map[string]any{`a`: 300.0})
+
+	var conversionErr errors.ErrorTypeConversion
+	if !goerrors.As(err, &conversionErr) || conversionErr.Location != `$['a']` ||
+		conversionErr.Expected != `int8` || conversionErr.Found != `float64` {
+		t.Errorf("expected errors.As(%v, *ErrorTypeConversion)<true> != actual<false>\n", err)
+	}
+	if !goerrors.Is(err, errors.ErrTypeConversion) || goerrors.Is(err, errors.ErrTypeUnmatched) {
+		t.Errorf("expected errors.Is(%v, ErrTypeConversion) only\n", err)
+	}
+	if goerrors.Unwrap(err) == nil {
+		t.Errorf("expected errors.Unwrap(%v) != nil\n", err)
+	}
+
+	_, err = jsonpath.Get[int](query, map[string]any{`a`: `x`})
+	if !goerrors.As(err, &conversionErr) || goerrors.Unwrap(err) != nil {
+		t.Errorf("expected errors.Unwrap(%v) == nil\n", err)
+	}
+}
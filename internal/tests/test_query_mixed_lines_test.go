@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+// formatLineResults returns the results in the form of `line:values` or `line:error`, one per line.
+func formatLineResults(results []jsonpath.LineResult) string {
+	var builder strings.Builder
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(&builder, "%d:%v\n", result.Line, result.Err)
+			continue
+		}
+		values, _ := json.Marshal(result.Values)
+		fmt.Fprintf(&builder, "%d:%s\n", result.Line, values)
+	}
+	return builder.String()
+}
+
+func TestQuery_RetrieveLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		jsonpath string
+		input    string
+		expected string
+	}{
+		{
+			name:     `lines`,
+			jsonpath: `$.a`,
+			input:    "{\"a\":1}\n{\"a\":[2]}\n{\"a\":\"3\"}\n",
+			expected: "1:[1]\n2:[[2]]\n3:[\"3\"]\n",
+		},
+		{
+			name:     `no trailing line feed`,
+			jsonpath: `$.a`,
+			input:    "{\"a\":1}\n{\"a\":2}",
+			expected: "1:[1]\n2:[2]\n",
+		},
+		{
+			name:     `empty lines and carriage returns`,
+			jsonpath: `$.a`,
+			input:    "\n{\"a\":1}\r\n  \r\n{\"a\":2}\r\n\n",
+			expected: "2:[1]\n4:[2]\n",
+		},
+		{
+			name:     `runtime errors`,
+			jsonpath: `$.a`,
+			input:    "{\"a\":1}\n{\"b\":2}\n[]\n{\"a\":4}\n",
+			expected: "1:[1]\n2:member did not exist (path=.a)\n3:type unmatched (path=.a, expected=object, found=[]interface {})\n4:[4]\n",
+		},
+		{
+			name:     `invalid JSON`,
+			jsonpath: `$.a`,
+			input:    "{\"a\":1}\n{\"a\":\n{\"a\":3}\n",
+			expected: "1:[1]\n2:unexpected end of JSON input\n3:[3]\n",
+		},
+		{
+			name:     `filter`,
+			jsonpath: `$[?(@.level == 'error')].message`,
+			input:    "[{\"level\":\"info\",\"message\":\"a\"},{\"level\":\"error\",\"message\":\"b\"}]\n[{\"level\":\"error\",\"message\":\"c\"}]\n",
+			expected: "1:[\"b\"]\n2:[\"c\"]\n",
+		},
+		{
+			name:     `empty input`,
+			jsonpath: `$.a`,
+			input:    ``,
+			expected: ``,
+		},
+	}
+
+	for _, testCase := range testCases {
+		for _, workers := range []int{0, 1, 4} {
+			t.Run(fmt.Sprintf(`%s (workers=%d)`, testCase.name, workers), func(t *testing.T) {
+				query, err := jsonpath.Compile(testCase.jsonpath)
+				if err != nil {
+					t.Errorf("expected error<nil> != actual error<%s>\n", err)
+					return
+				}
+				var results []jsonpath.LineResult
+				for result := range query.RetrieveLines(strings.NewReader(testCase.input), workers) {
+					results = append(results, result)
+				}
+				if actual := formatLineResults(results); actual != testCase.expected {
+					t.Errorf("expected results<\n%s> != actual results<\n%s>\n", testCase.expected, actual)
+				}
+			})
+		}
+	}
+}
+
+func TestQuery_RetrieveLines_Order(t *testing.T) {
+	// The earlier lines take longer, so that the later lines finish first in parallel.
+	cfg := config.Config{}
+	cfg.SetFilterFunction(`sleep`, func(param any) (any, error) {
+		time.Sleep(time.Duration(param.(float64)) * time.Millisecond)
+		return param, nil
+	})
+	query, err := jsonpath.Compile(`$.wait.sleep()`, cfg)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	var input, expected strings.Builder
+	for line := 1; line <= 40; line++ {
+		wait := (40 - line) % 8
+		fmt.Fprintf(&input, "{\"wait\":%d}\n", wait)
+		fmt.Fprintf(&expected, "%d:[%d]\n", line, wait)
+	}
+
+	var results []jsonpath.LineResult
+	for result := range query.RetrieveLines(strings.NewReader(input.String()), 8) {
+		results = append(results, result)
+	}
+	if actual := formatLineResults(results); actual != expected.String() {
+		t.Errorf("expected results<\n%s> != actual results<\n%s>\n", expected.String(), actual)
+	}
+}
+
+func TestQuery_RetrieveLines_Break(t *testing.T) {
+	query, _ := jsonpath.Compile(`$.a`)
+	input := strings.Repeat("{\"a\":1}\n", 100)
+	for _, workers := range []int{1, 4} {
+		count := 0
+		for range query.RetrieveLines(strings.NewReader(input), workers) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Errorf("workers=%d: expected count<3> != actual count<%d>\n", workers, count)
+		}
+	}
+}
+
+func TestQuery_RetrieveLines_LongLine(t *testing.T) {
+	query, _ := jsonpath.Compile(`$.a.length()`, func() config.Config {
+		cfg := config.Config{}
+		cfg.SetFilterFunction(`length`, func(param any) (any, error) {
+			return len(param.(string)), nil
+		})
+		return cfg
+	}())
+	input := fmt.Sprintf("{\"a\":\"%s\"}\n", strings.Repeat(`x`, 1<<20))
+	var results []jsonpath.LineResult
+	for result := range query.RetrieveLines(strings.NewReader(input), 1) {
+		results = append(results, result)
+	}
+	if actual, expected := formatLineResults(results), "1:[1048576]\n"; actual != expected {
+		t.Errorf("expected results<%s> != actual results<%s>\n", expected, actual)
+	}
+}
+
+func TestQuery_RetrieveLines_ReadError(t *testing.T) {
+	query, _ := jsonpath.Compile(`$.a`)
+	for _, workers := range []int{1, 4} {
+		reader := io.MultiReader(strings.NewReader("{\"a\":1}\n{\"a\":2}\n"), iotest.ErrReader(fmt.Errorf(`read failed`)))
+		var results []jsonpath.LineResult
+		for result := range query.RetrieveLines(reader, workers) {
+			results = append(results, result)
+		}
+		if actual, expected := formatLineResults(results), "1:[1]\n2:[2]\n3:read failed\n"; actual != expected {
+			t.Errorf("workers=%d: expected results<\n%s> != actual results<\n%s>\n", workers, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+// expectPaths validates that the query retrieves the same results with the paths as without them,
+// and that the paths of the results are as expected.
+func expectPaths(expected string) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, src any, config config.Config) error {
+		expectedOutput, expectedErr := query.Retrieve(src)
+		if config.AccessorMode {
+			expectedOutput = getAccessorValues(expectedOutput)
+		}
+
+		// The second retrieval checks that the paths do not leak into the next one.
+		for range 2 {
+			output, paths, err := query.RetrievePaths(src)
+			if fmt.Sprint(err) != fmt.Sprint(expectedErr) {
+				return fmt.Errorf(`expected error<%v> != actual error<%v>`, expectedErr, err)
+			}
+			if len(output) != len(paths) {
+				return fmt.Errorf(`output<%d> and paths<%d> are not the same length`, len(output), len(paths))
+			}
+			if config.AccessorMode {
+				output = getAccessorValues(output)
+			}
+			if !reflect.DeepEqual(output, expectedOutput) {
+				return fmt.Errorf(`expected output<%v> != actual output<%v>`, expectedOutput, output)
+			}
+			if actual := strings.Join(paths, ` `); actual != expected {
+				return fmt.Errorf(`expected paths<%s> != actual paths<%s>`, expected, actual)
+			}
+		}
+		return nil
+	}
+}
+
+// expectAccessorJSON validates that the accessors of the results get the values of the expected JSON.
+func expectAccessorJSON(expectedJSON string) func(any, []any) error {
+	return func(_ any, actualObject []any) error {
+		actualJSON, _ := json.Marshal(getAccessorValues(actualObject))
+		if string(actualJSON) != expectedJSON {
+			return fmt.Errorf(`expected output<%s> != actual output<%s>`, expectedJSON, actualJSON)
+		}
+		return nil
+	}
+}
+
+func getAccessorValues(output []any) []any {
+	values := make([]any, len(output))
+	for index := range output {
+		values[index] = output[index].(config.Accessor).Get()
+	}
+	return values
+}
+
+func TestQuery_RetrievePaths(t *testing.T) {
+	runTestCases(t, `TestQuery_RetrievePaths`, []TestCase{
+		{
+			jsonpath:       `$`,
+			inputJSON:      `{"a":1}`,
+			expectedJSON:   `[{"a":1}]`,
+			queryValidator: expectPaths(`$`),
+		},
+		{
+			jsonpath:       `$.a.b`,
+			inputJSON:      `{"a":{"b":1}}`,
+			expectedJSON:   `[1]`,
+			queryValidator: expectPaths(`$['a']['b']`),
+		},
+		{
+			jsonpath:       `$[*]`,
+			inputJSON:      `{"b":2,"a":1}`,
+			expectedJSON:   `[1,2]`,
+			queryValidator: expectPaths(`$['a'] $['b']`),
+		},
+		{
+			jsonpath:       `$[*][*]`,
+			inputJSON:      `[[1,2],[3]]`,
+			expectedJSON:   `[1,2,3]`,
+			queryValidator: expectPaths(`$[0][0] $[0][1] $[1][0]`),
+		},
+		{
+			jsonpath:       `$[*][*]`,
+			inputJSON:      `[[1,2],[3]]`,
+			optimization:   true,
+			expectedJSON:   `[1,2,3]`,
+			queryValidator: expectPaths(`$[0][0] $[0][1] $[1][0]`),
+		},
+		{
+			jsonpath:       `$[2,0,-1]`,
+			inputJSON:      `["a","b","c"]`,
+			expectedJSON:   `["c","a","c"]`,
+			queryValidator: expectPaths(`$[2] $[0] $[2]`),
+		},
+		{
+			jsonpath:       `$[1:]`,
+			inputJSON:      `["a","b","c"]`,
+			expectedJSON:   `["b","c"]`,
+			queryValidator: expectPaths(`$[1] $[2]`),
+		},
+		{
+			jsonpath:       `$[::-2]`,
+			inputJSON:      `["a","b","c"]`,
+			expectedJSON:   `["c","a"]`,
+			queryValidator: expectPaths(`$[2] $[0]`),
+		},
+		{
+			jsonpath:       `$['a','b c']`,
+			inputJSON:      `{"a":1,"b c":2}`,
+			expectedJSON:   `[1,2]`,
+			queryValidator: expectPaths(`$['a'] $['b c']`),
+		},
+		{
+			jsonpath:       `$..a`,
+			inputJSON:      `{"a":1,"b":[{"a":2}]}`,
+			expectedJSON:   `[1,2]`,
+			queryValidator: expectPaths(`$['a'] $['b'][0]['a']`),
+		},
+		{
+			jsonpath:       `$..*`,
+			inputJSON:      `{"a":[1]}`,
+			expectedJSON:   `[[1],1]`,
+			queryValidator: expectPaths(`$['a'] $['a'][0]`),
+		},
+		{
+			jsonpath:       `$..[0]`,
+			inputJSON:      `[[1]]`,
+			optimization:   true,
+			expectedJSON:   `[[1],1]`,
+			queryValidator: expectPaths(`$[0] $[0][0]`),
+		},
+		{
+			jsonpath:       `$.items[?(@.price > $.limit)].name`,
+			inputJSON:      `{"limit":10,"items":[{"name":"a","price":8},{"name":"b","price":12}]}`,
+			expectedJSON:   `["b"]`,
+			queryValidator: expectPaths(`$['items'][1]['name']`),
+		},
+		{
+			jsonpath:       `$.items[?(@.price > $.limit)].name`,
+			inputJSON:      `{"limit":10,"items":[{"name":"a","price":8},{"name":"b","price":12}]}`,
+			optimization:   true,
+			expectedJSON:   `["b"]`,
+			queryValidator: expectPaths(`$['items'][1]['name']`),
+		},
+		{
+			jsonpath:          `$[?(length(@.a) == 2)]`,
+			inputJSON:         `{"x":{"a":"xx"},"y":{"a":"y"}}`,
+			standardFunctions: true,
+			expectedJSON:      `[{"a":"xx"}]`,
+			queryValidator:    expectPaths(`$['x']`),
+		},
+		{
+			jsonpath:       `$['it\'s']`,
+			inputJSON:      `{"it's":1}`,
+			expectedJSON:   `[1]`,
+			queryValidator: expectPaths(`$['it\'s']`),
+		},
+		{
+			jsonpath:  `$[*].twice()`,
+			inputJSON: `[1,2]`,
+			filters: map[string]func(any) (any, error){
+				`twice`: twiceFilter,
+			},
+			expectedJSON:   `[2,4]`,
+			queryValidator: expectPaths(`$[0] $[1]`),
+		},
+		{
+			jsonpath:  `$.a[*].max()`,
+			inputJSON: `{"a":[1,3,2]}`,
+			aggregates: map[string]func([]any) (any, error){
+				`max`: maxAggregate,
+			},
+			expectedJSON:   `[3]`,
+			queryValidator: expectPaths(`$`),
+		},
+		{
+			jsonpath:        `$.a[1,0]`,
+			inputJSON:       `{"a":[1,2]}`,
+			accessorMode:    true,
+			resultValidator: expectAccessorJSON(`[2,1]`),
+			queryValidator:  expectPaths(`$['a'][1] $['a'][0]`),
+		},
+		{
+			jsonpath:       `$[*].a`,
+			inputJSON:      `[{"a":1},{"b":2},{"a":3}]`,
+			expectedJSON:   `[1,3]`,
+			queryValidator: expectPaths(`$[0]['a'] $[2]['a']`),
+		},
+		{
+			jsonpath:         `$[*].a`,
+			inputJSON:        `[{"a":1},{"b":2}]`,
+			collectAllErrors: true,
+			expectedErr:      createErrorMemberNotExist(`.a`),
+			queryValidator:   expectPaths(`$[0]['a']`),
+		},
+		{
+			jsonpath:       `$.a`,
+			inputJSON:      `{}`,
+			expectedErr:    createErrorMemberNotExist(`.a`),
+			queryValidator: expectPaths(``),
+		},
+	})
+}
+
+func TestQuery_RetrievePaths_Retrieve(t *testing.T) {
+	query, err := jsonpath.Compile(`$..[?(@.a)].a`)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	var src any
+	json.Unmarshal([]byte(`{"x":{"a":1},"y":[{"a":{"a":2}}]}`), &src)
+	output, paths, err := query.RetrievePaths(src)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	// Each path selects the value returned at the same position.
+	for index, path := range paths {
+		values, err := jsonpath.Retrieve(path, src)
+		if err != nil || len(values) != 1 || !reflect.DeepEqual(values[0], output[index]) {
+			t.Errorf("path<%s> selects<%v, %v> != value<%v>\n", path, values, err, output[index])
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+// expectPlan validates that the query has the plan, and that the query compiled with the optimization has the optimized plan.
+func expectPlan(plan string, optimizedPlan string) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, _ any, config config.Config) error {
+		if actual := query.Plan(); actual != plan {
+			return fmt.Errorf("expected plan<\n%s> != actual plan<\n%s>", plan, actual)
+		}
+
+		config.SetOptimization()
+		optimizedQuery, err := jsonpath.Compile(query.String(), config)
+		if err != nil {
+			return err
+		}
+		if actual := optimizedQuery.Plan(); actual != optimizedPlan {
+			return fmt.Errorf("expected optimized plan<\n%s> != actual optimized plan<\n%s>", optimizedPlan, actual)
+		}
+		return nil
+	}
+}
+
+func TestQuery_Plan(t *testing.T) {
+	runTestCases(t, `TestQuery_Plan`, []TestCase{
+		{
+			jsonpath: `$[?(1==1 && @.a)]`,
+			queryValidator: expectPlan(
+				`filter
+  and
+    compare ==
+      literal 1
+      literal 1
+    current @
+      child ['a']
+`,
+				`filter
+  current @
+    child ['a']
+`,
+			),
+		},
+		{
+			jsonpath: `$[?(1!=2 || @.a=~/b/)]`,
+			queryValidator: expectPlan(
+				`filter
+  or
+    not
+      compare ==
+        literal 2
+        literal 1
+    compare =~ /b/
+      current @
+        child ['a']
+`,
+				`filter
+  constant true
+`,
+			),
+		},
+		{
+			jsonpath: `$.items[?(@.price <= $.limit)]`,
+			queryValidator: expectPlan(
+				`child ['items']
+filter
+  compare <=
+    current @
+      child ['price']
+    root $
+      child ['limit']
+`,
+				`child ['items']
+filter
+  compare <=
+    current @
+      child ['price']
+    hoisted #0
+      root $
+        child ['limit']
+`,
+			),
+		},
+		{
+			jsonpath: `$[?(1 < $.x)]`,
+			queryValidator: expectPlan(
+				`filter
+  compare <
+    literal 1
+    root $
+      child ['x']
+`,
+				`filter
+  hoisted #0
+    compare > (number)
+      root $
+        child ['x']
+      literal 1
+`,
+			),
+		},
+		{
+			jsonpath: `$[*][*].*..price`,
+			queryValidator: expectPlan(
+				`wildcard *
+wildcard *
+wildcard *
+descendant ..
+child ['price']
+`,
+				`wildcard * depth=3
+descendant ..['price'] (key lookup)
+`,
+			),
+		},
+		{
+			jsonpath: `$[?(@.a =~ /b/ && @.c == 1)]`,
+			queryValidator: expectPlan(
+				`filter
+  and
+    compare =~ /b/
+      current @
+        child ['a']
+    compare ==
+      current @
+        child ['c']
+      literal 1
+`,
+				`filter
+  and
+    compare ==
+      current @
+        child ['c']
+      literal 1
+    compare =~ /b/
+      current @
+        child ['a']
+`,
+			),
+		},
+		{
+			jsonpath:          `$[0,1:3]..[*][*].length()`,
+			standardFunctions: true,
+			queryValidator: expectPlan(
+				`union [0,1:3:1]
+descendant ..
+wildcard *
+wildcard *
+function length()
+`,
+				`union [0,1:3:1]
+descendant ..
+wildcard * depth=2
+function length()
+`,
+			),
+		},
+	})
+}
+
+func TestQuery_PlanHoistedEachRetrieval(t *testing.T) {
+	config := config.Config{}
+	config.SetOptimization()
+	query, err := jsonpath.Compile(`$.v[?($.x == 1 || @ > $.y)]`, config)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	testCases := []struct {
+		inputJSON    string
+		expectedJSON string
+	}{
+		{inputJSON: `{"x":2,"y":2,"v":[1,3]}`, expectedJSON: `[3]`},
+		{inputJSON: `{"x":1,"y":2,"v":[1,3]}`, expectedJSON: `[1,3]`},
+		{inputJSON: `{"x":2,"y":0,"v":[1,3]}`, expectedJSON: `[1,3]`},
+	}
+	for _, testCase := range testCases {
+		var src any
+		if err := json.Unmarshal([]byte(testCase.inputJSON), &src); err != nil {
+			t.Errorf("%s", err)
+			return
+		}
+		output, err := query.Retrieve(src)
+		if err != nil {
+			t.Errorf("expected error<nil> != actual error<%s>\n", err)
+			continue
+		}
+		outputJSON, _ := json.Marshal(output)
+		if string(outputJSON) != testCase.expectedJSON {
+			t.Errorf("expected output<%s> != actual output<%s>\n", testCase.expectedJSON, outputJSON)
+		}
+	}
+}
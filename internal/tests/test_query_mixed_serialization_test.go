@@ -0,0 +1,335 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+type querySettings struct {
+	Name  string
+	Query jsonpath.Query
+}
+
+func TestQuery_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		inputJSON    string
+		expectedPath string
+		expectedErr  error
+	}{
+		{
+			inputJSON:    `{"Name":"a","Query":"$.a[0]"}`,
+			expectedPath: `$.a[0]`,
+		},
+		{
+			inputJSON:    `{"Name":"a","Query":""}`,
+			expectedPath: ``,
+		},
+		{
+			inputJSON:    `{"Name":"a","Query":null}`,
+			expectedPath: ``,
+		},
+		{
+			inputJSON: `{"Name":"a","Query":"$.a."}`,
+			expectedErr: errors.ErrorInvalidSyntax{
+				Position: 3,
+				Reason:   `unrecognized input`,
+				Near:     `.`,
+				JSONPath: `$.a.`,
+				Line:     1,
+				Column:   5,
+				Expected: `'*', member name`,
+			},
+		},
+		{
+			inputJSON:   `{"Name":"a","Query":"$.unknown()"}`,
+			expectedErr: createErrorFunctionNotFound(`.unknown()`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.inputJSON, func(t *testing.T) {
+			var settings querySettings
+			err := json.Unmarshal([]byte(testCase.inputJSON), &settings)
+			if !reflect.DeepEqual(err, testCase.expectedErr) {
+				t.Errorf("expected error<%v> != actual error<%v>\n", testCase.expectedErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if actual := settings.Query.String(); actual != testCase.expectedPath {
+				t.Errorf("expected path<%s> != actual path<%s>\n", testCase.expectedPath, actual)
+			}
+		})
+	}
+}
+
+func TestQuery_Zero(t *testing.T) {
+	var settings querySettings
+	if err := json.Unmarshal([]byte(`{"Name":"a","Query":""}`), &settings); err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	query := &settings.Query
+	src := map[string]any{`a`: 1.0}
+	expectedErr := `invalid syntax (position=0, reason=unrecognized input, near=)`
+	retrievals := map[string]func() error{
+		`Retrieve`: func() error {
+			_, err := query.Retrieve(src)
+			return err
+		},
+		`RetrieveContext`: func() error {
+			_, err := query.RetrieveContext(context.Background(), src)
+			return err
+		},
+		`RetrievePaths`: func() error {
+			_, _, err := query.RetrievePaths(src)
+			return err
+		},
+		`RetrieveTrace`: func() error {
+			_, trace, err := query.RetrieveTrace(src)
+			if trace == nil {
+				return fmt.Errorf(`nil trace`)
+			}
+			return err
+		},
+		`Get`: func() error {
+			_, err := jsonpath.Get[float64](query, src)
+			return err
+		},
+		`RetrieveLines`: func() error {
+			for result := range query.RetrieveLines(strings.NewReader(`{"a":1}`), 1) {
+				return result.Err
+			}
+			return nil
+		},
+	}
+	for name, retrieve := range retrievals {
+		err := retrieve()
+		if fmt.Sprint(err) != expectedErr || !goerrors.Is(err, errors.ErrSyntax) {
+			t.Errorf("%s: expected error<%s> != actual error<%v>\n", name, expectedErr, err)
+		}
+	}
+
+	if query.AST() != nil || query.IsSingular() || query.HasRootReference() ||
+		query.MemberNames() != nil || query.Plan() != `` || query.String() != `` {
+		t.Errorf("expected the zero values from the zero Query\n")
+	}
+}
+
+func TestQuery_MarshalJSON(t *testing.T) {
+	query, err := jsonpath.Compile(`$[?(@.a=="b")]`)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	expected := `{"Name":"a","Query":"$[?(@.a==\"b\")]"}`
+	for _, input := range []any{querySettings{Name: `a`, Query: *query}, &querySettings{Name: `a`, Query: *query}} {
+		actual, err := json.Marshal(input)
+		if err != nil {
+			t.Errorf("expected error<nil> != actual error<%s>\n", err)
+			return
+		}
+		if string(actual) != expected {
+			t.Errorf("expected<%s> != actual<%s>\n", expected, actual)
+		}
+	}
+
+	actual, err := json.Marshal(querySettings{})
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	if string(actual) != `{"Name":"","Query":""}` {
+		t.Errorf("expected<%s> != actual<%s>\n", `{"Name":"","Query":""}`, actual)
+	}
+}
+
+func TestQuery_Gob(t *testing.T) {
+	query, err := jsonpath.Compile(`$..price`)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(querySettings{Name: `a`, Query: *query}); err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	var decoded querySettings
+	if err := gob.NewDecoder(&buffer).Decode(&decoded); err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	if decoded.Name != `a` || decoded.Query.String() != `$..price` {
+		t.Errorf("expected<a $..price> != actual<%s %s>\n", decoded.Name, decoded.Query.String())
+		return
+	}
+
+	output, err := decoded.Query.Retrieve(map[string]any{`a`: map[string]any{`price`: 1.5}})
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	if !reflect.DeepEqual(output, []any{1.5}) {
+		t.Errorf("expected<[1.5]> != actual<%v>\n", output)
+	}
+}
+
+type queryRules struct {
+	Name  string
+	Rules struct {
+		Target jsonpath.Query
+		Next   *jsonpath.Query
+	}
+}
+
+func newQueryRulesConfig() config.Config {
+	config := config.Config{}
+	config.SetFunction(`round`, roundFunc)
+	return config
+}
+
+func TestQuery_RoundTripFunction(t *testing.T) {
+	const jsonPath = `$[*].round(1)`
+	src := []any{1.24, 2.25}
+	expectedOutput := []any{1.2, 2.3}
+
+	query, err := jsonpath.Compile(jsonPath, newQueryRulesConfig())
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	source := queryRules{Name: `a`}
+	source.Rules.Target = *query
+	source.Rules.Next = query
+
+	roundTrips := map[string]func(*queryRules) error{
+		`text`: func(decoded *queryRules) error {
+			text, err := source.Rules.Target.MarshalText()
+			if err != nil {
+				return err
+			}
+			if err := decoded.Rules.Target.UnmarshalText(text); err != nil {
+				return err
+			}
+			return decoded.Rules.Next.UnmarshalText(text)
+		},
+		`JSON`: func(decoded *queryRules) error {
+			data, err := json.Marshal(source)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(data, decoded)
+		},
+		`gob`: func(decoded *queryRules) error {
+			var buffer bytes.Buffer
+			if err := gob.NewEncoder(&buffer).Encode(source); err != nil {
+				return err
+			}
+			return gob.NewDecoder(&buffer).Decode(decoded)
+		},
+	}
+
+	for name, roundTrip := range roundTrips {
+		t.Run(name, func(t *testing.T) {
+			decoded := queryRules{}
+			decoded.Rules.Next = &jsonpath.Query{}
+			if err := jsonpath.PrepareQueries(&decoded, newQueryRulesConfig()); err != nil {
+				t.Errorf("expected error<nil> != actual error<%s>\n", err)
+				return
+			}
+			if err := roundTrip(&decoded); err != nil {
+				t.Errorf("expected error<nil> != actual error<%s>\n", err)
+				return
+			}
+			for _, decodedQuery := range []*jsonpath.Query{&decoded.Rules.Target, decoded.Rules.Next} {
+				if decodedQuery.String() != jsonPath {
+					t.Errorf("expected path<%s> != actual path<%s>\n", jsonPath, decodedQuery.String())
+					return
+				}
+				output, err := decodedQuery.Retrieve(src)
+				if err != nil {
+					t.Errorf("expected error<nil> != actual error<%s>\n", err)
+					return
+				}
+				if !reflect.DeepEqual(output, expectedOutput) {
+					t.Errorf("expected<%v> != actual<%v>\n", expectedOutput, output)
+				}
+			}
+		})
+	}
+}
+
+func TestQuery_UnmarshalJSONStandardFunctions(t *testing.T) {
+	var settings querySettings
+	if err := json.Unmarshal([]byte(`{"Name":"a","Query":"$[*].length()"}`), &settings); err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	output, err := settings.Query.Retrieve([]any{`ab`, []any{1.0}})
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	if !reflect.DeepEqual(output, []any{2.0, 1.0}) {
+		t.Errorf("expected<[2 1]> != actual<%v>\n", output)
+	}
+}
+
+func TestQuery_PrepareQueriesFieldName(t *testing.T) {
+	testCases := []struct {
+		inputJSON     string
+		expectedField string
+	}{
+		{
+			inputJSON:     `{"Rules":{"Target":"$.a."}}`,
+			expectedField: `Rules.Target`,
+		},
+		{
+			inputJSON:     `{"Rules":{"Next":"$.a."}}`,
+			expectedField: `Rules.Next`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.inputJSON, func(t *testing.T) {
+			decoded := queryRules{}
+			decoded.Rules.Next = &jsonpath.Query{}
+			if err := jsonpath.PrepareQueries(&decoded); err != nil {
+				t.Errorf("expected error<nil> != actual error<%s>\n", err)
+				return
+			}
+			err := json.Unmarshal([]byte(testCase.inputJSON), &decoded)
+			var syntaxErr errors.ErrorInvalidSyntax
+			if !goerrors.As(err, &syntaxErr) || syntaxErr.Field != testCase.expectedField {
+				t.Errorf("expected field<%s> != actual error<%v>\n", testCase.expectedField, err)
+				return
+			}
+			expectedErr := `invalid syntax (field=` + testCase.expectedField + `, position=3, reason=unrecognized input, near=.)`
+			if err.Error() != expectedErr {
+				t.Errorf("expected error<%s> != actual error<%s>\n", expectedErr, err)
+			}
+		})
+	}
+
+	if err := jsonpath.PrepareQueries(queryRules{}); err == nil ||
+		err.Error() != `jsonpath: PrepareQueries requires a non-nil pointer to a struct, not tests.queryRules` {
+		t.Errorf("expected error<the pointer is required> != actual error<%v>\n", err)
+	}
+}
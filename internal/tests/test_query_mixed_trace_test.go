@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+)
+
+// expectTrace validates that the query retrieves the same results with the trace as without it,
+// and that the trace of the filters is rendered as expected.
+func expectTrace(expected string) func(*jsonpath.Query, any, config.Config) error {
+	return func(query *jsonpath.Query, src any, _ config.Config) error {
+		expectedOutput, expectedErr := query.Retrieve(src)
+
+		// The second retrieval checks that the trace does not leak into the next one.
+		for range 2 {
+			output, trace, err := query.RetrieveTrace(src)
+			if !reflect.DeepEqual(err, expectedErr) {
+				return fmt.Errorf(`expected error<%v> != actual error<%v>`, expectedErr, err)
+			}
+			if !reflect.DeepEqual(output, expectedOutput) {
+				return fmt.Errorf(`expected output<%v> != actual output<%v>`, expectedOutput, output)
+			}
+			if actual := trace.String(); actual != expected {
+				return fmt.Errorf("expected trace<\n%s> != actual trace<\n%s>", expected, actual)
+			}
+		}
+		return nil
+	}
+}
+
+func TestQuery_RetrieveTrace(t *testing.T) {
+	runTestCases(t, `TestQuery_RetrieveTrace`, []TestCase{
+		{
+			jsonpath:     `$.items[?(@.price <= $.limit && @.tag != 'x')].name`,
+			inputJSON:    `{"limit":10,"items":[{"name":"a","price":8,"tag":"y"},{"name":"b","price":12},{"name":"c","price":1,"tag":"x"}]}`,
+			expectedJSON: `["a"]`,
+			optimization: true,
+			queryValidator: expectTrace(`$['items'][?(@['price']<=$['limit']&&@['tag']!='x')]
+  $['items'][0]: matched
+    @['price']<=$['limit']: 8, 10 -> matched
+    @['tag']!='x': "y", "x" -> matched
+    @['price']<=$['limit']&&@['tag']!='x': matched
+  $['items'][1]: not matched
+    @['price']<=$['limit']: 12, 10 -> not matched
+    @['tag']!='x': <missing>, "x" -> matched
+    @['price']<=$['limit']&&@['tag']!='x': not matched
+  $['items'][2]: not matched
+    @['price']<=$['limit']: 1, 10 -> matched
+    @['tag']!='x': "x", "x" -> not matched
+    @['price']<=$['limit']&&@['tag']!='x': not matched
+`),
+		},
+		{
+			jsonpath:     `$[?(@.a =~ /b/ || !@.c)]`,
+			inputJSON:    `{"p":{"a":"abc"},"q":{"c":1}}`,
+			expectedJSON: `[{"a":"abc"}]`,
+			optimization: true,
+			queryValidator: expectTrace(`$[?(@['a']=~/b/||!@['c'])]
+  $['p']: matched
+    @['a']=~/b/: "abc" -> matched
+    @['c']: not matched
+    !@['c']: matched
+    @['a']=~/b/||!@['c']: matched
+  $['q']: not matched
+    @['a']=~/b/: <missing> -> not matched
+    @['c']: matched
+    !@['c']: not matched
+    @['a']=~/b/||!@['c']: not matched
+`),
+		},
+		{
+			jsonpath:     `$[?(@.a[?(@.b==1)])]`,
+			inputJSON:    `[{"a":[{"b":1},{"b":2}]},{"a":[]}]`,
+			expectedJSON: `[{"a":[{"b":1},{"b":2}]}]`,
+			optimization: true,
+			queryValidator: expectTrace(`$[?(@['a'][?(@['b']==1)])]
+  $[0]: matched
+    @['a'][?(@['b']==1)]: matched
+  $[1]: not matched
+    @['a'][?(@['b']==1)]: not matched
+$[0]['a'][?(@['b']==1)]
+  $[0]['a'][0]: matched
+    @['b']==1: 1, 1 -> matched
+  $[0]['a'][1]: not matched
+    @['b']==1: 2, 1 -> not matched
+`),
+		},
+		{
+			jsonpath:     `$[?(@.x > 1)]`,
+			inputJSON:    `[{"x":1},{"y":2}]`,
+			expectedErr:  createErrorMemberNotExist(`[?(@.x > 1)]`),
+			optimization: true,
+			queryValidator: expectTrace(`$[?(@['x']>1)]
+  $[0]: not matched
+    @['x']>1: 1, 1 -> not matched
+  $[1]: not matched
+    @['x']>1: <missing>, 1 -> not matched
+`),
+		},
+		{
+			jsonpath:          `$[?(1 < length(@.x) || $.all)]`,
+			standardFunctions: true,
+			inputJSON:         `[{"x":"ab"},{"x":"a"}]`,
+			expectedJSON:      `[{"x":"ab"}]`,
+			optimization:      true,
+			queryValidator: expectTrace(`$[?(length(@['x'])>1||$['all'])]
+  $[0]: matched
+    length(@['x'])>1: 2, 1 -> matched
+    $['all']: not matched
+    length(@['x'])>1||$['all']: matched
+  $[1]: not matched
+    length(@['x'])>1: 1, 1 -> not matched
+    $['all']: not matched
+    length(@['x'])>1||$['all']: not matched
+`),
+		},
+		{
+			jsonpath:     `$[?(1 == 1)]`,
+			inputJSON:    `[0]`,
+			expectedJSON: `[0]`,
+			optimization: true,
+			queryValidator: expectTrace(`$[?(1==1)]
+  $[0]: matched
+    1==1: 1, 1 -> matched
+`),
+		},
+		{
+			jsonpath:       `$.a`,
+			inputJSON:      `{"a":1}`,
+			expectedJSON:   `[1]`,
+			optimization:   true,
+			queryValidator: expectTrace(``),
+		},
+	})
+}
+
+func TestQuery_RetrieveTraceOperands(t *testing.T) {
+	query, err := jsonpath.Compile(`$[?(@.a == 'x')]`)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	_, trace, err := query.RetrieveTrace([]any{map[string]any{`a`: `x`}, map[string]any{}})
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+
+	expected := &jsonpath.Trace{
+		Filters: []jsonpath.FilterTrace{
+			{
+				Path:       `$`,
+				Expression: `@['a']=='x'`,
+				Candidates: []jsonpath.CandidateTrace{
+					{
+						Path:    `$[0]`,
+						Value:   map[string]any{`a`: `x`},
+						Matched: true,
+						Steps: []jsonpath.StepTrace{
+							{
+								Expression: `@['a']=='x'`,
+								Left:       &jsonpath.OperandTrace{Value: `x`, Exists: true},
+								Right:      &jsonpath.OperandTrace{Value: `x`, Exists: true},
+								Matched:    true,
+							},
+						},
+					},
+					{
+						Path:  `$[1]`,
+						Value: map[string]any{},
+						Steps: []jsonpath.StepTrace{
+							{
+								Expression: `@['a']=='x'`,
+								Left:       &jsonpath.OperandTrace{},
+								Right:      &jsonpath.OperandTrace{Value: `x`, Exists: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(trace, expected) {
+		t.Errorf("expected trace<%#v> != actual trace<%#v>\n", expected, trace)
+	}
+}
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+type unmarshalSummary struct {
+	Name    string    `jsonpath:"$.user.name"`
+	Age     int       `jsonpath:"$.user.age"`
+	Total   float64   `jsonpath:"$.items[*].price.sum()"`
+	Prices  []float32 `jsonpath:"$.items[*].price"`
+	Tags    []string  `jsonpath:"$.tags"`
+	Nick    string    `jsonpath:"$.user.nick,optional"`
+	First   string    `jsonpath:"$.items[*].name"`
+	Ignored string    `jsonpath:"-"`
+	Plain   string
+	private string `jsonpath:"$.user.name"`
+}
+
+type unmarshalFailure struct {
+	Name    string `jsonpath:"$.user.name"`
+	Age     int8   `jsonpath:"$.user.age"`
+	Missing string `jsonpath:"$.user.missing"`
+	Invalid string `jsonpath:"$.user["`
+	Counts  []int  `jsonpath:"$.items[*].price"`
+	Deep    string `jsonpath:"$.user.name.first,optional"`
+}
+
+func unmarshalSource(t *testing.T, srcJSON string) any {
+	var src any
+	if err := json.Unmarshal([]byte(srcJSON), &src); err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func TestUnmarshal(t *testing.T) {
+	src := unmarshalSource(t, `{"user":{"name":"alice","age":30},"items":[{"name":"a","price":1.5},{"name":"b","price":2}],"tags":["x","y"]}`)
+
+	summary := unmarshalSummary{Nick: `keep`, Ignored: `keep`, Plain: `keep`}
+	if err := jsonpath.Unmarshal(src, &summary); err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	expected := `{Name:alice Age:30 Total:3.5 Prices:[1.5 2] Tags:[x y] Nick:keep First:a Ignored:keep Plain:keep private:}`
+	if actual := fmt.Sprintf(`%+v`, summary); actual != expected {
+		t.Errorf("expected<%s> != actual<%s>\n", expected, actual)
+	}
+
+	src = unmarshalSource(t, `{"user":{"name":"bob","nick":"b"},"items":[],"tags":[]}`)
+	summary = unmarshalSummary{}
+	err := jsonpath.Unmarshal(src, &summary)
+	expectedErr := "field failed (field=Age, jsonpath=$.user.age, error=member did not exist (path=.age))\n" +
+		"field failed (field=Total, jsonpath=$.items[*].price.sum(), error=member did not exist (path=[*]))\n" +
+		"field failed (field=Prices, jsonpath=$.items[*].price, error=member did not exist (path=[*]))\n" +
+		"field failed (field=First, jsonpath=$.items[*].name, error=member did not exist (path=[*]))"
+	if fmt.Sprint(err) != expectedErr {
+		t.Errorf("expected error<%s> != actual error<%v>\n", expectedErr, err)
+	}
+	if summary.Name != `bob` || summary.Nick != `b` || len(summary.Tags) != 0 {
+		t.Errorf("expected the succeeded fields to be set<%+v>\n", summary)
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	src := unmarshalSource(t, `{"user":{"name":"alice","age":300},"items":[{"price":1},{"price":2.5}]}`)
+
+	failure := unmarshalFailure{Counts: []int{7}}
+	err := jsonpath.Unmarshal(src, &failure)
+
+	var unmarshalErr errors.ErrorUnmarshal
+	if !goerrors.As(err, &unmarshalErr) {
+		t.Errorf("expected errors.As(%v, *ErrorUnmarshal)<true> != actual<false>\n", err)
+		return
+	}
+	expectedFields := []string{`Age`, `Missing`, `Invalid`, `Counts`}
+	if len(unmarshalErr.Errors) != len(expectedFields) {
+		t.Errorf("expected errors<%v> != actual errors<%v>\n", expectedFields, unmarshalErr.Errors)
+		return
+	}
+	for index, field := range expectedFields {
+		if unmarshalErr.Errors[index].Field != field {
+			t.Errorf("expected field<%s> != actual field<%s>\n", field, unmarshalErr.Errors[index].Field)
+		}
+	}
+
+	var conversionErr errors.ErrorTypeConversion
+	if !goerrors.As(err, &conversionErr) || conversionErr.Location != `$['user']['age']` {
+		t.Errorf("expected errors.As(%v, *ErrorTypeConversion)<true> != actual<false>\n", err)
+	}
+	if !goerrors.Is(err, errors.ErrNotFound) || !goerrors.Is(err, errors.ErrSyntax) || !goerrors.Is(err, errors.ErrTypeConversion) {
+		t.Errorf("expected errors.Is(%v, ErrNotFound, ErrSyntax, ErrTypeConversion)<true> != actual<false>\n", err)
+	}
+	if expected := `type conversion failed (location=$['items'][1]['price'], expected=int, found=float64, error=2.5 is not an integer)`; fmt.Sprint(unmarshalErr.Errors[3].Err) != expected {
+		t.Errorf("expected error<%s> != actual error<%v>\n", expected, unmarshalErr.Errors[3].Err)
+	}
+	if failure.Name != `alice` || failure.Age != 0 || len(failure.Counts) != 1 || failure.Counts[0] != 7 {
+		t.Errorf("expected the failed fields to be unchanged<%+v>\n", failure)
+	}
+}
+
+func TestUnmarshal_InvalidTarget(t *testing.T) {
+	var summary *unmarshalSummary
+	number := 1
+	testCases := []struct {
+		target      any
+		expectedErr string
+	}{
+		{target: unmarshalSummary{}, expectedErr: `jsonpath: Unmarshal requires a non-nil pointer to a struct, not tests.unmarshalSummary`},
+		{target: summary, expectedErr: `jsonpath: Unmarshal requires a non-nil pointer to a struct, not *tests.unmarshalSummary`},
+		{target: &number, expectedErr: `jsonpath: Unmarshal requires a non-nil pointer to a struct, not *int`},
+		{target: nil, expectedErr: `jsonpath: Unmarshal requires a non-nil pointer to a struct, not null`},
+	}
+	for _, testCase := range testCases {
+		if err := jsonpath.Unmarshal(map[string]any{}, testCase.target); fmt.Sprint(err) != testCase.expectedErr {
+			t.Errorf("expected error<%s> != actual error<%v>\n", testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestExtractor_Config(t *testing.T) {
+	type doubled struct {
+		Values []float64 `jsonpath:"$[*].twice()"`
+		Count  int       `jsonpath:"$.length()"`
+	}
+
+	cfg := config.Config{}
+	cfg.SetFilterFunction(`twice`, twiceFilter)
+	extractor := jsonpath.NewExtractor(cfg)
+	src := unmarshalSource(t, `[1,2]`)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			var value doubled
+			err := extractor.Unmarshal(src, &value)
+			if fmt.Sprint(value.Values) != `[2 4]` {
+				t.Errorf("expected values<[2 4]> != actual values<%v>\n", value.Values)
+			}
+			var syntaxErr errors.ErrorFunctionNotFound
+			if !goerrors.As(err, &syntaxErr) {
+				t.Errorf("expected errors.As(%v, *ErrorFunctionNotFound)<true> != actual<false>\n", err)
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -1,6 +1,8 @@
 package jsonpath
 
 import (
+	"context"
+
 	"github.com/AsaiYusuke/jsonpath/v2/config"
 	"github.com/AsaiYusuke/jsonpath/v2/internal/syntax"
 )
@@ -12,3 +14,11 @@ func Retrieve(jsonPath string, src any, config ...config.Config) ([]any, error)
 func Parse(jsonPath string, config ...config.Config) (func(src any, dst ...*[]any) ([]any, error), error) {
 	return syntax.Parse(jsonPath, config...)
 }
+
+func RetrieveContext(ctx context.Context, jsonPath string, src any, config ...config.Config) ([]any, error) {
+	return syntax.RetrieveContext(ctx, jsonPath, src, config...)
+}
+
+func ParseContext(jsonPath string, config ...config.Config) (func(ctx context.Context, src any, dst ...*[]any) ([]any, error), error) {
+	return syntax.ParseContext(jsonPath, config...)
+}
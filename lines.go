@@ -0,0 +1,130 @@
+package jsonpath
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+)
+
+// LineResult represents the result of the query for a line of the NDJSON (JSON Lines).
+// Line is the line number starting from 1.
+// Err is the error of the retrieval, the error of decoding the line, or the error of reading the input.
+type LineResult struct {
+	Line   int
+	Values []any
+	Err    error
+}
+
+// RetrieveLines returns the iterator of the results of the query for each line of the NDJSON read from the reader.
+// The lines are decoded by json.Unmarshal, and the empty lines are skipped.
+// The results are yielded in order of the lines, even when they are retrieved in parallel by the workers.
+// The lines are retrieved one by one when workers is 1 or less.
+// The error of reading the input is yielded as the last result with the line number where it occurred.
+// When the iteration stops early, the reading stops after the line being read.
+func (q *Query) RetrieveLines(reader io.Reader, workers int) iter.Seq[LineResult] {
+	return q.RetrieveLinesContext(context.Background(), reader, workers)
+}
+
+// RetrieveLinesContext returns the iterator of the results of the query for each line of the NDJSON.
+func (q *Query) RetrieveLinesContext(ctx context.Context, reader io.Reader, workers int) iter.Seq[LineResult] {
+	return func(yield func(LineResult) bool) {
+		if workers <= 1 {
+			q.retrieveLines(ctx, reader, yield)
+			return
+		}
+		q.retrieveLinesParallel(ctx, reader, workers, yield)
+	}
+}
+
+// lineJob is a line read from the input, with the channel that receives its result.
+type lineJob struct {
+	line   int
+	data   []byte
+	err    error
+	result chan LineResult
+}
+
+func (q *Query) retrieveLines(ctx context.Context, reader io.Reader, yield func(LineResult) bool) {
+	readLines(reader, func(job lineJob) bool {
+		return yield(q.retrieveLine(ctx, job))
+	})
+}
+
+// retrieveLinesParallel retrieves the lines by the workers.
+// The jobs are queued in order of the lines, so that the results are yielded in the same order.
+// The number of the lines waiting for being yielded is limited by the number of the workers.
+func (q *Query) retrieveLinesParallel(ctx context.Context, reader io.Reader, workers int, yield func(LineResult) bool) {
+	jobs := make(chan lineJob)
+	ordered := make(chan lineJob, workers)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		defer close(jobs)
+		defer close(ordered)
+		readLines(reader, func(job lineJob) bool {
+			job.result = make(chan LineResult, 1)
+			select {
+			case ordered <- job:
+			case <-done:
+				return false
+			}
+			select {
+			case jobs <- job:
+				return true
+			case <-done:
+				return false
+			}
+		})
+	}()
+
+	for range workers {
+		go func() {
+			for job := range jobs {
+				job.result <- q.retrieveLine(ctx, job)
+			}
+		}()
+	}
+
+	for job := range ordered {
+		if !yield(<-job.result) {
+			return
+		}
+	}
+}
+
+func (q *Query) retrieveLine(ctx context.Context, job lineJob) LineResult {
+	if job.err != nil {
+		return LineResult{Line: job.line, Err: job.err}
+	}
+	var src any
+	if err := json.Unmarshal(job.data, &src); err != nil {
+		return LineResult{Line: job.line, Err: err}
+	}
+	values, err := q.RetrieveContext(ctx, src)
+	return LineResult{Line: job.line, Values: values, Err: err}
+}
+
+// readLines passes each line that is not empty to the handler until it returns false.
+// The line of any length is read, unlike bufio.Scanner.
+func readLines(reader io.Reader, handle func(lineJob) bool) {
+	bufferedReader := bufio.NewReader(reader)
+	for line := 1; ; line++ {
+		data, err := bufferedReader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			handle(lineJob{line: line, err: err})
+			return
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			if !handle(lineJob{line: line, data: data}) {
+				return
+			}
+		}
+		if err == io.EOF {
+			return
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Package ordered provides the JSON object that keeps the order of its members,
+// so that JSONPath retrieves the members in order of the document.
+//
+// The objects are decoded into *Object, the arrays into []any,
+// and the other values the same as encoding/json decodes into any.
+package ordered
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// Object represents the JSON object that keeps the order of its members.
+// The members of Object are retrieved in order of the document by config.Config.SetDocumentOrder,
+// or in sorted order of their keys, the same as map[string]any, by default.
+type Object struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewObject returns the empty object.
+func NewObject() *Object {
+	return &Object{values: map[string]any{}}
+}
+
+// Unmarshal decodes the JSON in data into v, with the objects decoded into *Object.
+func Unmarshal(data []byte, v *any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	value, err := decodeValue(decoder)
+	if err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		if err != nil {
+			return err
+		}
+		return errors.New(`ordered: invalid data after top-level value`)
+	}
+	*v = value
+	return nil
+}
+
+// Len returns the number of the members.
+func (o *Object) Len() int {
+	return len(o.keys)
+}
+
+// Keys returns the keys of the members in order.
+// The returned slice must not be modified.
+func (o *Object) Keys() []string {
+	return o.keys
+}
+
+// Get returns the value of the member, and reports whether the member exists.
+func (o *Object) Get(key string) (any, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+// Set sets the value of the member.
+// The new member is added to the end.
+func (o *Object) Set(key string, value any) {
+	if o.values == nil {
+		o.values = map[string]any{}
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Delete deletes the member.
+func (o *Object) Delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	o.keys = slices.DeleteFunc(o.keys, func(existingKey string) bool { return existingKey == key })
+}
+
+// All returns the iterator of the members in order.
+func (o *Object) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for _, key := range o.keys {
+			if !yield(key, o.values[key]) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns the values of the members by the key.
+// The values changed through the map are reflected in the object,
+// but the members must be added and deleted by Set and Delete to keep their order.
+func (o *Object) Map() map[string]any {
+	return o.values
+}
+
+// MarshalJSON returns the JSON of the object with the members in order.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for index, key := range o.keys {
+		if index > 0 {
+			buffer.WriteByte(',')
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueJSON, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(keyJSON)
+		buffer.WriteByte(':')
+		buffer.Write(valueJSON)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON decodes the JSON object keeping the order of its members.
+func (o *Object) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := Unmarshal(data, &value); err != nil {
+		return err
+	}
+	object, ok := value.(*Object)
+	if !ok {
+		return fmt.Errorf(`ordered: cannot unmarshal %s into Object`, describe(value))
+	}
+	*o = *object
+	return nil
+}
+
+// Equal reports whether the JSON values are equal, regardless of the order of the members of the objects.
+// The object of *Object and that of map[string]any are equal if they have the same members.
+func Equal(left, right any) bool {
+	leftMap, isLeftObject := toMap(left)
+	rightMap, isRightObject := toMap(right)
+	if isLeftObject || isRightObject {
+		if !isLeftObject || !isRightObject || len(leftMap) != len(rightMap) {
+			return false
+		}
+		for key, leftValue := range leftMap {
+			rightValue, ok := rightMap[key]
+			if !ok || !Equal(leftValue, rightValue) {
+				return false
+			}
+		}
+		return true
+	}
+
+	leftList, isLeftList := left.([]any)
+	rightList, isRightList := right.([]any)
+	if isLeftList || isRightList {
+		return isLeftList && isRightList && slices.EqualFunc(leftList, rightList, Equal)
+	}
+
+	return reflect.DeepEqual(left, right)
+}
+
+func toMap(value any) (map[string]any, bool) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		return typedValue, true
+	case *Object:
+		return typedValue.values, true
+	}
+	return nil, false
+}
+
+func decodeValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := NewObject()
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.Set(keyToken.(string), value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return object, nil
+
+	case json.Delim('['):
+		list := []any{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+	return token, nil
+}
+
+func describe(value any) string {
+	switch value.(type) {
+	case []any:
+		return `array`
+	case nil:
+		return `null`
+	}
+	return reflect.TypeOf(value).String()
+}
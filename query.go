@@ -0,0 +1,246 @@
+package jsonpath
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+	"github.com/AsaiYusuke/jsonpath/v2/internal/syntax"
+)
+
+// Query represents the compiled JSONPath.
+// The zero Query, such as the one decoded from the empty string, behaves as the empty JSONPath:
+// the retrievals return the same error as Compile of the empty JSONPath, and the other methods return the zero values.
+type Query struct {
+	jsonPath string
+	query    *syntax.Query
+
+	// config and field are set by PrepareQueries for decoding.
+	config []config.Config
+	field  string
+}
+
+// Compile returns the compiled JSONPath.
+func Compile(jsonPath string, config ...config.Config) (*Query, error) {
+	query, err := syntax.Compile(jsonPath, config...)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{jsonPath: jsonPath, query: query}, nil
+}
+
+// standardConfig has the standard functions, with which the JSONPaths are compiled by default when they are decoded.
+var standardConfig = newStandardConfig()
+
+func newStandardConfig() config.Config {
+	config := config.Config{}
+	config.SetStandardFunctions()
+	return config
+}
+
+// errEmptyQuery returns the error of compiling the empty JSONPath, which the zero Query returns.
+var errEmptyQuery = sync.OnceValue(func() error {
+	_, err := syntax.Compile(``)
+	return err
+})
+
+// Retrieve returns the retrieved JSON.
+func (q *Query) Retrieve(src any, dst ...*[]any) ([]any, error) {
+	if q.query == nil {
+		return nil, errEmptyQuery()
+	}
+	return q.query.Execute(context.Background(), src, dst...)
+}
+
+// RetrieveContext returns the retrieved JSON.
+func (q *Query) RetrieveContext(ctx context.Context, src any, dst ...*[]any) ([]any, error) {
+	if q.query == nil {
+		return nil, errEmptyQuery()
+	}
+	return q.query.Execute(ctx, src, dst...)
+}
+
+// RetrievePaths returns the retrieved JSON together with the normalized paths of the values, such as $['a'][0].
+// The paths are in the same order as the values.
+// The value returned by a function has the path of the node where the function is called,
+// the same as config.FunctionContext.Path.
+func (q *Query) RetrievePaths(src any) ([]any, []string, error) {
+	return q.RetrievePathsContext(context.Background(), src)
+}
+
+// RetrievePathsContext returns the retrieved JSON together with the normalized paths of the values.
+func (q *Query) RetrievePathsContext(ctx context.Context, src any) ([]any, []string, error) {
+	if q.query == nil {
+		return nil, nil, errEmptyQuery()
+	}
+	return q.query.ExecutePaths(ctx, src)
+}
+
+// RetrieveTrace returns the retrieved JSON together with the trace of the filters.
+// The trace records, for each element tested by each filter, the computed values of the comparisons
+// and the result of each sub-expression. It is returned even if the retrieval fails,
+// so that it shows which condition eliminated the elements on ErrorMemberNotExist.
+// The query is compiled again without Config.SetOptimization on the first call.
+func (q *Query) RetrieveTrace(src any) ([]any, *Trace, error) {
+	return q.RetrieveTraceContext(context.Background(), src)
+}
+
+// RetrieveTraceContext returns the retrieved JSON together with the trace of the filters.
+func (q *Query) RetrieveTraceContext(ctx context.Context, src any) ([]any, *Trace, error) {
+	if q.query == nil {
+		return nil, newTrace(nil), errEmptyQuery()
+	}
+	output, filters, err := q.query.ExecuteTrace(ctx, src)
+	return output, newTrace(filters), err
+}
+
+// AST returns the syntax tree of the JSONPath.
+// Each call returns a new tree, so modifying it does not affect the query.
+func (q *Query) AST() *ast.Query {
+	if q.query == nil {
+		return nil
+	}
+	return q.query.AST()
+}
+
+// IsSingular reports whether the query returns at most one value.
+// The query is singular when it consists only of the member names and the indexes,
+// or when it ends with an aggregate function that has no following value group.
+func (q *Query) IsSingular() bool {
+	if q.query == nil {
+		return false
+	}
+	return q.query.IsSingular()
+}
+
+// HasRootReference reports whether the filters or the function arguments refer to the root `$`.
+// Such a query depends on the whole JSON, not only on the current node.
+func (q *Query) HasRootReference() bool {
+	if q.query == nil {
+		return false
+	}
+	return q.query.HasRootReference()
+}
+
+// MemberNames returns the literal member names in the query, including those in the filters.
+// Each name appears once, in order of appearance. The wildcards are not included.
+func (q *Query) MemberNames() []string {
+	if q.query == nil {
+		return nil
+	}
+	return q.query.MemberNames()
+}
+
+// Plan returns the text representation of the syntax tree that the query executes.
+// Comparing the plans compiled with and without Config.SetOptimization shows the optimization.
+func (q *Query) Plan() string {
+	if q.query == nil {
+		return ``
+	}
+	return q.query.Plan()
+}
+
+// String returns the JSONPath used to compile the query.
+func (q *Query) String() string {
+	return q.jsonPath
+}
+
+// MarshalText returns the JSONPath used to compile the query.
+func (q Query) MarshalText() ([]byte, error) {
+	return []byte(q.jsonPath), nil
+}
+
+// UnmarshalText compiles the JSONPath.
+// It returns the same errors as Compile, such as ErrorInvalidSyntax.
+// The JSONPath is compiled with the config set by PrepareQueries, or with the standard functions without it.
+// ErrorInvalidSyntax has the field name set by PrepareQueries.
+// The empty text results in the zero Query.
+func (q *Query) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		q.jsonPath, q.query = ``, nil
+		return nil
+	}
+
+	query, err := Compile(string(text), q.getDecodingConfig()...)
+	if err != nil {
+		if syntaxErr, ok := err.(errors.ErrorInvalidSyntax); ok {
+			syntaxErr.Field = q.field
+			return syntaxErr
+		}
+		return err
+	}
+	q.jsonPath, q.query = query.jsonPath, query.query
+	return nil
+}
+
+func (q *Query) getDecodingConfig() []config.Config {
+	if q.config == nil {
+		return []config.Config{standardConfig}
+	}
+	return q.config
+}
+
+// MarshalJSON returns the JSONPath used to compile the query as the JSON string.
+func (q Query) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.jsonPath)
+}
+
+// GobEncode returns the JSONPath used to compile the query.
+func (q Query) GobEncode() ([]byte, error) {
+	return q.MarshalText()
+}
+
+// GobDecode compiles the JSONPath in the same way as UnmarshalText.
+func (q *Query) GobDecode(data []byte) error {
+	return q.UnmarshalText(data)
+}
+
+// PrepareQueries sets the config and the field names to the Query fields of the struct pointed to by v,
+// so that the decoders, such as encoding/json and encoding/gob, compile the JSONPaths in them with the config
+// and ErrorInvalidSyntax has the field name, such as Rules.Target.
+// The fields of the nested structs and the non-nil pointers are prepared, and the other Query values,
+// such as the elements of the slices, are compiled with the standard functions.
+func PrepareQueries(v any, config ...config.Config) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf(`jsonpath: PrepareQueries requires a non-nil pointer to a struct, not %s`, typeName(v))
+	}
+	prepareQueries(target.Elem(), ``, config)
+	return nil
+}
+
+var queryType = reflect.TypeFor[Query]()
+
+func prepareQueries(target reflect.Value, name string, config []config.Config) {
+	for index := range target.NumField() {
+		structField := target.Type().Field(index)
+		if !structField.IsExported() {
+			continue
+		}
+
+		field := target.Field(index)
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+
+		fieldName := structField.Name
+		if len(name) > 0 {
+			fieldName = name + `.` + fieldName
+		}
+		switch {
+		case field.Type() == queryType:
+			query := field.Addr().Interface().(*Query)
+			query.config, query.field = config, fieldName
+		case field.Kind() == reflect.Struct:
+			prepareQueries(field, fieldName, config)
+		}
+	}
+}
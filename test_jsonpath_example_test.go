@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/ast"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
 )
 
 func Example() {
@@ -100,3 +103,319 @@ func ExampleParse_reuseBuffer() {
 	// ["value2"]
 	// ["value2"]
 }
+
+func ExampleCompile() {
+	jsonPath, srcJSON := `$.key`, `{"key":"value"}`
+	query, err := jsonpath.Compile(jsonPath)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, err := query.Retrieve(src)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// ["value"]
+}
+
+func ExampleQuery_AST() {
+	jsonPath := `$.users[?(@.role=='admin')].name`
+	query, err := jsonpath.Compile(jsonPath)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	var names []string
+	ast.Inspect(query.AST(), func(node ast.Node) bool {
+		if selector, ok := node.(*ast.NameSelector); ok {
+			names = append(names, selector.Name)
+		}
+		return true
+	})
+	fmt.Println(names)
+	// Output:
+	// [users role name]
+}
+
+func ExampleBuilder() {
+	builder := jsonpath.Root().Child(`a.b`).Index(-1).Filter(jsonpath.Cur().Child(`x`).Gt(3))
+	fmt.Println(builder)
+	query, err := builder.Compile()
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	var src any
+	json.Unmarshal([]byte(`{"a.b":[[{"x":1},{"x":5}]]}`), &src)
+	output, _ := query.Retrieve(src)
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	// Output:
+	// $['a.b'][-1][?(@['x']>3)]
+	// [{"x":5}]
+}
+
+func ExampleFormat() {
+	for _, jsonPath := range []string{`$.a['b']`, `$['a'].b`, `$[ 'a' ][ 'b' ]`} {
+		output, err := jsonpath.Format(jsonPath)
+		if err != nil {
+			fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+			return
+		}
+		fmt.Println(output)
+	}
+	cfg := config.Config{}
+	cfg.SetFormatStyle(config.FormatStyle{Notation: config.DotNotation, Quote: config.DoubleQuote, Spacing: true})
+	output, _ := jsonpath.Format(`$['a'][?(@['b']=='c')]`, cfg)
+	fmt.Println(output)
+	// Output:
+	// $['a']['b']
+	// $['a']['b']
+	// $['a']['b']
+	// $.a[?(@.b == "c")]
+}
+
+func ExampleQuery_UnmarshalText() {
+	var settings struct {
+		Target jsonpath.Query
+	}
+	if err := json.Unmarshal([]byte(`{"Target":"$.key"}`), &settings); err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	var src any
+	json.Unmarshal([]byte(`{"key":"value"}`), &src)
+	output, _ := settings.Target.Retrieve(src)
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+
+	err := json.Unmarshal([]byte(`{"Target":"$.key."}`), &settings)
+	fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+	// Output:
+	// ["value"]
+	// type: errors.ErrorInvalidSyntax, value: invalid syntax (position=5, reason=unrecognized input, near=.)
+}
+
+func ExamplePrepareQueries() {
+	var settings struct {
+		Rules struct {
+			Target jsonpath.Query
+		}
+	}
+	config := config.Config{}
+	config.SetFilterFunction(`twice`, func(param any) (any, error) {
+		return param.(float64) * 2, nil
+	})
+	if err := jsonpath.PrepareQueries(&settings, config); err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	if err := json.Unmarshal([]byte(`{"Rules":{"Target":"$.key.twice()"}}`), &settings); err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	var src any
+	json.Unmarshal([]byte(`{"key":1}`), &src)
+	output, _ := settings.Rules.Target.Retrieve(src)
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+
+	err := json.Unmarshal([]byte(`{"Rules":{"Target":"$.key."}}`), &settings)
+	fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+	// Output:
+	// [2]
+	// type: errors.ErrorInvalidSyntax, value: invalid syntax (field=Rules.Target, position=5, reason=unrecognized input, near=.)
+}
+
+func ExampleQuery_IsSingular() {
+	for _, jsonPath := range []string{`$.users[0].name`, `$.users[?(@.age>$.limit)].name`} {
+		query, err := jsonpath.Compile(jsonPath)
+		if err != nil {
+			fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+			return
+		}
+		fmt.Println(query.IsSingular(), query.HasRootReference(), query.MemberNames())
+	}
+	// Output:
+	// true false [users name]
+	// false true [users age limit name]
+}
+
+func ExampleQuery_Plan() {
+	jsonPath := `$.items[*][*][?(@.price <= $.limit)]`
+	query, err := jsonpath.Compile(jsonPath)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	fmt.Print(query.Plan())
+	fmt.Println(`--`)
+
+	cfg := config.Config{}
+	cfg.SetOptimization()
+	optimizedQuery, err := jsonpath.Compile(jsonPath, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	fmt.Print(optimizedQuery.Plan())
+	// Output:
+	// child ['items']
+	// wildcard *
+	// wildcard *
+	// filter
+	//   compare <=
+	//     current @
+	//       child ['price']
+	//     root $
+	//       child ['limit']
+	// --
+	// child ['items']
+	// wildcard * depth=2
+	// filter
+	//   compare <=
+	//     current @
+	//       child ['price']
+	//     hoisted #0
+	//       root $
+	//         child ['limit']
+}
+
+func ExampleQuery_RetrieveTrace() {
+	query, err := jsonpath.Compile(`$.items[?(@.price <= $.limit && @.tag != 'x')].name`)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	srcJSON := `{"limit":10,"items":[{"name":"a","price":8,"tag":"y"},{"name":"b","price":12}]}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, trace, err := query.RetrieveTrace(src)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	outputJSON, _ := json.Marshal(output)
+	fmt.Println(string(outputJSON))
+	fmt.Print(trace)
+	// Output:
+	// ["a"]
+	// $['items'][?(@['price']<=$['limit']&&@['tag']!='x')]
+	//   $['items'][0]: matched
+	//     @['price']<=$['limit']: 8, 10 -> matched
+	//     @['tag']!='x': "y", "x" -> matched
+	//     @['price']<=$['limit']&&@['tag']!='x': matched
+	//   $['items'][1]: not matched
+	//     @['price']<=$['limit']: 12, 10 -> not matched
+	//     @['tag']!='x': <missing>, "x" -> matched
+	//     @['price']<=$['limit']&&@['tag']!='x': not matched
+}
+
+func ExampleQuery_RetrievePaths() {
+	query, err := jsonpath.Compile(`$..price`)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	srcJSON := `{"store":{"book":[{"price":8.95},{"price":12.99}]}}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	output, paths, err := query.RetrievePaths(src)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	for index := range output {
+		fmt.Println(paths[index], output[index])
+	}
+	// Output:
+	// $['store']['book'][0]['price'] 8.95
+	// $['store']['book'][1]['price'] 12.99
+}
+
+func ExampleGet() {
+	query, err := jsonpath.Compile(`$.user.age`)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	srcJSON := `{"user":{"name":"alice","age":30}}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	age, err := jsonpath.Get[int](query, src)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	fmt.Println(age + 1)
+	// Output:
+	// 31
+}
+
+func ExampleGetAll() {
+	query, err := jsonpath.Compile(`$.users[*].name`)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	srcJSON := `{"users":[{"name":"alice"},{"name":"bob"}]}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	names, err := jsonpath.GetAll[string](query, src)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	fmt.Println(strings.Join(names, `, `))
+	// Output:
+	// alice, bob
+}
+
+func ExampleUnmarshal() {
+	type Summary struct {
+		Name  string   `jsonpath:"$.user.name"`
+		Total float64  `jsonpath:"$.items[*].price.sum()"`
+		Tags  []string `jsonpath:"$.items[*].tag"`
+		Nick  string   `jsonpath:"$.user.nick,optional"`
+	}
+	srcJSON := `{"user":{"name":"alice"},"items":[{"price":1.5,"tag":"a"},{"price":2,"tag":"b"}]}`
+	var src any
+	json.Unmarshal([]byte(srcJSON), &src)
+	var summary Summary
+	if err := jsonpath.Unmarshal(src, &summary); err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	fmt.Printf(`%+v`, summary)
+	// Output:
+	// {Name:alice Total:3.5 Tags:[a b] Nick:}
+}
+
+func ExampleQuery_RetrieveLines() {
+	query, err := jsonpath.Compile(`$[?(@.level == 'error')].message`)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	ndjson := `[{"level":"info","message":"started"}]
+[{"level":"error","message":"disk full"},{"level":"error","message":"retry"}]
+[{"level":"info","message":"stopped"}]
+`
+	for result := range query.RetrieveLines(strings.NewReader(ndjson), 4) {
+		if result.Err != nil {
+			fmt.Printf("%d: %v\n", result.Line, result.Err)
+			continue
+		}
+		fmt.Printf("%d: %v\n", result.Line, result.Values)
+	}
+	// Output:
+	// 1: member did not exist (path=[?(@.level == 'error')])
+	// 2: [disk full retry]
+	// 3: member did not exist (path=[?(@.level == 'error')])
+}
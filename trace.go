@@ -0,0 +1,141 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/AsaiYusuke/jsonpath/v2/internal/syntax"
+)
+
+// Trace represents the records of the filters computed by Query.RetrieveTrace,
+// in order of computation.
+type Trace struct {
+	Filters []FilterTrace
+}
+
+// FilterTrace represents a filter applied to the elements of an object or an array.
+// Path is the normalized path of the object or the array,
+// and Expression is the filter expression such as `@.price<=$.limit`.
+type FilterTrace struct {
+	Path       string
+	Expression string
+	Candidates []CandidateTrace
+}
+
+// CandidateTrace represents an element tested by the filter.
+// Steps are the sub-expressions of the filter in order of computation.
+// The sub-expressions skipped because the result is already decided are not included.
+type CandidateTrace struct {
+	Path    string
+	Value   any
+	Matched bool
+	Steps   []StepTrace
+}
+
+// StepTrace represents a sub-expression of the filter computed for the candidate.
+// Left and Right are the operands of the comparison and nil for the other sub-expressions.
+// Right is also nil for the regular expression match.
+type StepTrace struct {
+	Expression string
+	Left       *OperandTrace
+	Right      *OperandTrace
+	Matched    bool
+}
+
+// OperandTrace represents the computed value of an operand of the comparison.
+// Exists is false when the query of the operand selects nothing.
+type OperandTrace struct {
+	Value  any
+	Exists bool
+}
+
+func newTrace(filters []syntax.TraceFilter) *Trace {
+	trace := &Trace{Filters: make([]FilterTrace, len(filters))}
+	for filterIndex, filter := range filters {
+		filterTrace := FilterTrace{
+			Path:       filter.Path,
+			Expression: formatExpression(filter.Expression, defaultFormatStyle),
+			Candidates: make([]CandidateTrace, len(filter.Candidates)),
+		}
+		for candidateIndex, candidate := range filter.Candidates {
+			candidateTrace := CandidateTrace{
+				Path:    candidate.Path,
+				Value:   candidate.Value,
+				Matched: candidate.Matched,
+				Steps:   make([]StepTrace, len(candidate.Steps)),
+			}
+			for stepIndex, step := range candidate.Steps {
+				candidateTrace.Steps[stepIndex] = StepTrace{
+					Expression: formatExpression(step.Expression, defaultFormatStyle),
+					Left:       newOperandTrace(step.Left),
+					Right:      newOperandTrace(step.Right),
+					Matched:    step.Matched,
+				}
+			}
+			filterTrace.Candidates[candidateIndex] = candidateTrace
+		}
+		trace.Filters[filterIndex] = filterTrace
+	}
+	return trace
+}
+
+func newOperandTrace(operand *syntax.TraceOperand) *OperandTrace {
+	if operand == nil {
+		return nil
+	}
+	return &OperandTrace{Value: operand.Value, Exists: operand.Exists}
+}
+
+// String returns the text representation of the trace, one filter, candidate and step per line.
+// The missing operands are shown as `<missing>`.
+func (t *Trace) String() string {
+	var builder strings.Builder
+	for _, filter := range t.Filters {
+		builder.WriteString(filter.Path)
+		builder.WriteString(`[?(`)
+		builder.WriteString(filter.Expression)
+		builder.WriteString(")]\n")
+		for _, candidate := range filter.Candidates {
+			builder.WriteString(`  `)
+			builder.WriteString(candidate.Path)
+			builder.WriteString(`: `)
+			builder.WriteString(traceResult(candidate.Matched))
+			builder.WriteString("\n")
+			for _, step := range candidate.Steps {
+				builder.WriteString(`    `)
+				builder.WriteString(step.Expression)
+				builder.WriteString(`: `)
+				if step.Left != nil {
+					builder.WriteString(step.Left.String())
+					if step.Right != nil {
+						builder.WriteString(`, `)
+						builder.WriteString(step.Right.String())
+					}
+					builder.WriteString(` -> `)
+				}
+				builder.WriteString(traceResult(step.Matched))
+				builder.WriteString("\n")
+			}
+		}
+	}
+	return builder.String()
+}
+
+// String returns the value in JSON, or `<missing>` if the operand does not exist.
+func (o *OperandTrace) String() string {
+	if !o.Exists {
+		return `<missing>`
+	}
+	text, err := json.Marshal(o.Value)
+	if err != nil {
+		return `?`
+	}
+	return string(text)
+}
+
+func traceResult(isMatched bool) string {
+	if isMatched {
+		return `matched`
+	}
+	return `not matched`
+}
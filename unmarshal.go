@@ -0,0 +1,130 @@
+package jsonpath
+
+import (
+	goerrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/errors"
+)
+
+var defaultExtractor = NewExtractor(standardConfig)
+
+// Unmarshal sets the fields of the struct pointed to by v to the values retrieved from src
+// by the JSONPaths in their `jsonpath` tags, such as `jsonpath:"$.user.name"`.
+// The standard functions are available in the JSONPaths.
+// See Extractor.Unmarshal for the details.
+func Unmarshal(src any, v any) error {
+	return defaultExtractor.Unmarshal(src, v)
+}
+
+// Extractor sets the fields of the structs by the JSONPaths in their tags.
+// The JSONPaths are compiled once for each type of the structs and reused.
+// Extractor is safe for concurrent use.
+type Extractor struct {
+	config []config.Config
+	fields sync.Map
+}
+
+// NewExtractor returns the extractor that compiles the JSONPaths with the config.
+func NewExtractor(config ...config.Config) *Extractor {
+	return &Extractor{config: config}
+}
+
+type extractorField struct {
+	index    int
+	name     string
+	jsonPath string
+	query    *Query
+	err      error
+	optional bool
+	nodeList bool
+}
+
+// Unmarshal sets the fields of the struct pointed to by v to the values retrieved from src
+// by the JSONPaths in their `jsonpath` tags.
+// The values are converted in the same way as Get.
+// The slice field takes all the retrieved values if the JSONPath is not singular, and the first value otherwise.
+// The field whose tag ends with `,optional` is left unchanged if the JSONPath retrieves nothing,
+// and the other fields fail. The fields without the tag, with the tag `-` and not exported are ignored.
+// The failures of all the fields are returned together by errors.ErrorUnmarshal.
+func (e *Extractor) Unmarshal(src any, v any) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf(`jsonpath: Unmarshal requires a non-nil pointer to a struct, not %s`, typeName(v))
+	}
+	target = target.Elem()
+
+	var errs []errors.ErrorFieldFailed
+	for _, field := range e.getFields(target.Type()) {
+		if err := field.extract(src, target.Field(field.index)); err != nil {
+			errs = append(errs, errors.NewErrorFieldFailed(field.name, field.jsonPath, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewErrorUnmarshal(errs)
+	}
+	return nil
+}
+
+func (e *Extractor) getFields(structType reflect.Type) []extractorField {
+	if fields, ok := e.fields.Load(structType); ok {
+		return fields.([]extractorField)
+	}
+	fields, _ := e.fields.LoadOrStore(structType, e.compileFields(structType))
+	return fields.([]extractorField)
+}
+
+func (e *Extractor) compileFields(structType reflect.Type) []extractorField {
+	var fields []extractorField
+	for index := range structType.NumField() {
+		structField := structType.Field(index)
+		tag, ok := structField.Tag.Lookup(`jsonpath`)
+		if !ok || tag == `-` || !structField.IsExported() {
+			continue
+		}
+
+		jsonPath, optional := strings.CutSuffix(tag, `,optional`)
+		query, err := Compile(jsonPath, e.config...)
+		fields = append(fields, extractorField{
+			index:    index,
+			name:     structField.Name,
+			jsonPath: jsonPath,
+			query:    query,
+			err:      err,
+			optional: optional,
+			nodeList: err == nil && structField.Type.Kind() == reflect.Slice && !query.IsSingular(),
+		})
+	}
+	return fields
+}
+
+func (f *extractorField) extract(src any, target reflect.Value) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	output, paths, err := f.query.RetrievePaths(src)
+	if err != nil {
+		if f.optional && (goerrors.Is(err, errors.ErrNotFound) || goerrors.Is(err, errors.ErrTypeUnmatched)) {
+			return nil
+		}
+		return err
+	}
+
+	if !f.nodeList {
+		return setValue(target, output[0], paths[0])
+	}
+
+	list := reflect.MakeSlice(target.Type(), len(output), len(output))
+	for index := range output {
+		if err := setValue(list.Index(index), output[index], paths[index]); err != nil {
+			return err
+		}
+	}
+	target.Set(list)
+	return nil
+}
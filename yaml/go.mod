@@ -0,0 +1,10 @@
+module github.com/AsaiYusuke/jsonpath/v2/yaml
+
+go 1.25
+
+require (
+	github.com/AsaiYusuke/jsonpath/v2 v2.0.0
+	go.yaml.in/yaml/v3 v3.0.5
+)
+
+replace github.com/AsaiYusuke/jsonpath/v2 => ../
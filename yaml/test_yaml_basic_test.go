@@ -0,0 +1,313 @@
+package yaml_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/yaml"
+)
+
+func TestYAML_Retrieve(t *testing.T) {
+	testCases := []struct {
+		jsonpath     string
+		inputYAML    string
+		expectedJSON string
+		expectedErr  string
+	}{
+		{
+			jsonpath:     `$.spec.containers[*].image`,
+			inputYAML:    "spec:\n  containers:\n    - name: web\n      image: nginx\n    - name: sidecar\n      image: envoy\n",
+			expectedJSON: `["nginx","envoy"]`,
+		},
+		{
+			jsonpath:     `$.spec.containers[?(@.port > 8000)].name`,
+			inputYAML:    "spec:\n  containers:\n    - {name: web, port: 80}\n    - {name: api, port: 8080}\n",
+			expectedJSON: `["api"]`,
+		},
+		{
+			jsonpath:     `$[?(@ == 1.5)]`,
+			inputYAML:    "- 1.5\n- 15e-1\n- 2\n",
+			expectedJSON: `[1.5,1.5]`,
+		},
+		{
+			jsonpath:     `$['1','true','null']`,
+			inputYAML:    "1: a\ntrue: b\n~: c\n",
+			expectedJSON: `["a","b","c"]`,
+		},
+		{
+			jsonpath:     `$.child.*`,
+			inputYAML:    "base: &base\n  a: 1\n  b: 2\nchild:\n  <<: *base\n  b: 3\n",
+			expectedJSON: `[1,3]`,
+		},
+		{
+			jsonpath:    `$.none`,
+			inputYAML:   "a: 1\n",
+			expectedErr: `member did not exist (path=.none)`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.jsonpath, func(t *testing.T) {
+			var src any
+			if err := yaml.Unmarshal([]byte(testCase.inputYAML), &src); err != nil {
+				t.Errorf("expected error<nil> != actual error<%s>\n", err)
+				return
+			}
+			output, err := jsonpath.Retrieve(testCase.jsonpath, src)
+			if fmt.Sprint(err) != testCase.expectedErr && !(err == nil && testCase.expectedErr == ``) {
+				t.Errorf("expected error<%s> != actual error<%v>\n", testCase.expectedErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if actual, _ := json.Marshal(output); string(actual) != testCase.expectedJSON {
+				t.Errorf("expected JSON<%s> != actual JSON<%s>\n", testCase.expectedJSON, actual)
+			}
+		})
+	}
+}
+
+// billionLaughsYAML returns the YAML whose each level refers to the previous one 9 times.
+func billionLaughsYAML(levels int) string {
+	var builder strings.Builder
+	builder.WriteString("a0: &a0 [lol]\n")
+	for level := 1; level <= levels; level++ {
+		fmt.Fprintf(&builder, "a%d: &a%d [", level, level)
+		for index := range 9 {
+			if index > 0 {
+				builder.WriteString(`, `)
+			}
+			fmt.Fprintf(&builder, "*a%d", level-1)
+		}
+		builder.WriteString("]\n")
+	}
+	return builder.String()
+}
+
+func TestYAML_Unmarshal(t *testing.T) {
+	testCases := []struct {
+		name         string
+		inputYAML    string
+		expectedJSON string
+		expectedErr  string
+	}{
+		{
+			name:         `scalars`,
+			inputYAML:    "int: 1\nhex: 0x1F\nfloat: 1.5\nexp: 1e3\nbool: yes\ntrue: true\nnull: ~\nstring: '1'\ndate: 2024-01-02\n",
+			expectedJSON: `{"bool":"yes","date":"2024-01-02","exp":1000,"float":1.5,"hex":31,"int":1,"null":null,"string":"1","true":true}`,
+		},
+		{
+			name:         `anchors and merges`,
+			inputYAML:    "a: &a {x: 1, y: 2}\nb: *a\nc:\n  <<: [*a, {z: 3}]\n  y: 4\n",
+			expectedJSON: `{"a":{"x":1,"y":2},"b":{"x":1,"y":2},"c":{"x":1,"y":4,"z":3}}`,
+		},
+		{
+			name:         `multiple documents`,
+			inputYAML:    "a: 1\n---\nb: 2\n",
+			expectedJSON: `{"a":1}`,
+		},
+		{
+			name:         `empty`,
+			inputYAML:    ``,
+			expectedJSON: `null`,
+		},
+		{
+			name:        `key of mapping`,
+			inputYAML:   "? {a: 1}\n: b\n",
+			expectedErr: `yaml: key that is not a scalar at line 1`,
+		},
+		{
+			name:        `merge of scalar`,
+			inputYAML:   "a:\n  <<: 1\n",
+			expectedErr: `yaml: merge of a value that is not a mapping at line 2`,
+		},
+		{
+			name:        `recursive alias`,
+			inputYAML:   "a: &x [*x]\n",
+			expectedErr: `yaml: anchor 'x' value contains itself at line 1`,
+		},
+		{
+			name:        `recursive alias in merge`,
+			inputYAML:   "a: &x\n  b:\n    <<: *x\n",
+			expectedErr: `yaml: anchor 'x' value contains itself at line 3`,
+		},
+		{
+			name:         `repeated alias`,
+			inputYAML:    "a: &x [1]\nb: [*x, *x]\nc: [*x, {d: *x}]\n",
+			expectedJSON: `{"a":[1],"b":[[1],[1]],"c":[[1],{"d":[1]}]}`,
+		},
+		{
+			name:        `excessive aliasing`,
+			inputYAML:   billionLaughsYAML(9),
+			expectedErr: `yaml: document contains excessive aliasing`,
+		},
+		{
+			name:        `invalid`,
+			inputYAML:   "a: [1\n",
+			expectedErr: `yaml: line 1: did not find expected ',' or ']'`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var src any
+			err := yaml.Unmarshal([]byte(testCase.inputYAML), &src)
+			if fmt.Sprint(err) != fmt.Sprint(testCase.expectedErr) && !(err == nil && testCase.expectedErr == ``) {
+				t.Errorf("expected error<%s> != actual error<%v>\n", testCase.expectedErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if actual, _ := json.Marshal(src); string(actual) != testCase.expectedJSON {
+				t.Errorf("expected JSON<%s> != actual JSON<%s>\n", testCase.expectedJSON, actual)
+			}
+		})
+	}
+}
+
+func TestYAML_Marshal(t *testing.T) {
+	type change struct {
+		jsonpath string
+		value    any
+	}
+	testCases := []struct {
+		name         string
+		inputYAML    string
+		changes      []change
+		edit         func(documents []*yaml.Document)
+		expectedYAML string
+	}{
+		{
+			name:         `unchanged`,
+			inputYAML:    "# head\na: 'x' # line\nb: [1, 2]\nc: &c\n  d: 1\ne: *c\n",
+			expectedYAML: "# head\na: 'x' # line\nb: [1, 2]\nc: &c\n  d: 1\ne: *c\n",
+		},
+		{
+			name:      `scalars`,
+			inputYAML: "# head\nname: \"web\" # the name\nreplicas: 2\nports: [80, 443]\n",
+			changes: []change{
+				{jsonpath: `$.name`, value: `api`},
+				{jsonpath: `$.replicas`, value: 3},
+				{jsonpath: `$.ports[1]`, value: 8443},
+			},
+			expectedYAML: "# head\nname: \"api\" # the name\nreplicas: 3\nports: [80, 8443]\n",
+		},
+		{
+			name:      `filter`,
+			inputYAML: "containers:\n  # web server\n  - name: web\n    image: nginx:1.25 # pinned\n  - name: sidecar\n    image: envoy:1.0\n",
+			changes: []change{
+				{jsonpath: `$.containers[?(@.name == 'web')].image`, value: `nginx:1.27`},
+			},
+			expectedYAML: "containers:\n  # web server\n  - name: web\n    image: nginx:1.27 # pinned\n  - name: sidecar\n    image: envoy:1.0\n",
+		},
+		{
+			name:      `type changed`,
+			inputYAML: "a: 1 # comment\nb: x\n",
+			changes: []change{
+				{jsonpath: `$.a`, value: map[string]any{`c`: []any{1.0, `d`}}},
+			},
+			expectedYAML: "a: # comment\n  c:\n    - 1\n    - d\nb: x\n",
+		},
+		{
+			name:      `anchor changed`,
+			inputYAML: "a: &a\n  x: 1\nb: *a\nc:\n  <<: *a\n  z: 2\n",
+			changes: []change{
+				{jsonpath: `$.a.x`, value: 5},
+			},
+			expectedYAML: "a: &a\n  x: 5\nb:\n  x: 1\nc:\n  x: 1\n  z: 2\n",
+		},
+		{
+			name:      `alias changed`,
+			inputYAML: "a: &a\n  x: 1\nb: *a\n",
+			changes: []change{
+				{jsonpath: `$.b.x`, value: 5},
+			},
+			expectedYAML: "a: &a\n  x: 1\nb:\n  x: 5\n",
+		},
+		{
+			name:      `members added and deleted`,
+			inputYAML: "b: 1 # keep\na: 2\nlist:\n  - 1\n  - 2\n  - 3\n",
+			edit: func(documents []*yaml.Document) {
+				object := documents[0].Value.(map[string]any)
+				delete(object, `a`)
+				object[`d`] = `new`
+				object[`c`] = true
+				object[`list`] = object[`list`].([]any)[:2]
+			},
+			expectedYAML: "b: 1 # keep\nlist:\n  - 1\n  - 2\nc: true\nd: new\n",
+		},
+		{
+			name:      `documents`,
+			inputYAML: "kind: Deployment\n---\n# service\nkind: Service\n",
+			changes: []change{
+				{jsonpath: `$.kind`, value: `StatefulSet`},
+			},
+			edit: func(documents []*yaml.Document) {
+				documents[1].Value = map[string]any{`kind`: `Service`, `name`: `svc`}
+			},
+			expectedYAML: "kind: StatefulSet\n---\n# service\nkind: Service\nname: svc\n",
+		},
+		{
+			name:         `empty`,
+			inputYAML:    ``,
+			expectedYAML: ``,
+		},
+	}
+
+	cfg := config.Config{}
+	cfg.SetAccessorMode()
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			documents, err := yaml.ParseDocuments([]byte(testCase.inputYAML))
+			if err != nil {
+				t.Errorf("expected error<nil> != actual error<%s>\n", err)
+				return
+			}
+			for _, change := range testCase.changes {
+				output, err := jsonpath.Retrieve(change.jsonpath, documents[0].Value, cfg)
+				if err != nil {
+					t.Errorf("expected error<nil> != actual error<%s>\n", err)
+					return
+				}
+				for _, accessor := range output {
+					accessor.(config.Accessor).Set(change.value)
+				}
+			}
+			if testCase.edit != nil {
+				testCase.edit(documents)
+			}
+
+			actual, err := yaml.MarshalDocuments(documents)
+			if err != nil {
+				t.Errorf("expected error<nil> != actual error<%s>\n", err)
+				return
+			}
+			if string(actual) != testCase.expectedYAML {
+				t.Errorf("expected YAML<\n%s> != actual YAML<\n%s>\n", testCase.expectedYAML, actual)
+			}
+		})
+	}
+}
+
+func TestYAML_MarshalNewDocument(t *testing.T) {
+	document, err := yaml.ParseDocument(nil)
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	document.Value = map[string]any{`b`: []any{1.0}, `a`: `x`}
+	actual, err := document.Marshal()
+	if err != nil {
+		t.Errorf("expected error<nil> != actual error<%s>\n", err)
+		return
+	}
+	if expected := "a: x\nb:\n  - 1\n"; string(actual) != expected {
+		t.Errorf("expected YAML<\n%s> != actual YAML<\n%s>\n", expected, actual)
+	}
+}
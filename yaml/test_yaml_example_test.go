@@ -0,0 +1,69 @@
+package yaml_test
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/AsaiYusuke/jsonpath/v2"
+	"github.com/AsaiYusuke/jsonpath/v2/config"
+	"github.com/AsaiYusuke/jsonpath/v2/yaml"
+)
+
+func ExampleUnmarshal() {
+	jsonPath, srcYAML := `$.spec.containers[?(@.port > 8000)].name`, `
+spec:
+  containers:
+    - {name: web, port: 80}
+    - {name: api, port: 8080}
+`
+	var src any
+	if err := yaml.Unmarshal([]byte(srcYAML), &src); err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	output, err := jsonpath.Retrieve(jsonPath, src)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	fmt.Println(output)
+	// Output:
+	// [api]
+}
+
+func ExampleDocument_Marshal() {
+	cfg := config.Config{}
+	cfg.SetAccessorMode()
+	jsonPath, srcYAML := `$.containers[?(@.name == 'web')].image`, `# deployment
+containers:
+  - name: web
+    image: nginx:1.25 # pinned
+  - name: sidecar
+    image: envoy:1.0
+`
+	document, err := yaml.ParseDocument([]byte(srcYAML))
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	output, err := jsonpath.Retrieve(jsonPath, document.Value, cfg)
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	output[0].(config.Accessor).Set(`nginx:1.27`)
+
+	outputYAML, err := document.Marshal()
+	if err != nil {
+		fmt.Printf(`type: %v, value: %v`, reflect.TypeOf(err), err)
+		return
+	}
+	fmt.Print(string(outputYAML))
+	// Output:
+	// # deployment
+	// containers:
+	//   - name: web
+	//     image: nginx:1.27 # pinned
+	//   - name: sidecar
+	//     image: envoy:1.0
+}
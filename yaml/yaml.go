@@ -0,0 +1,448 @@
+// Package yaml decodes YAML into the values that JSONPath retrieves from,
+// and writes the values changed by the accessors back into the YAML preserving its comments and order.
+//
+// The mappings are decoded into map[string]any, the sequences into []any,
+// and the integers and the floats into float64, the same as encoding/json decodes into any.
+// The keys that are not strings, such as 1 or true, are converted to the strings as written.
+// The timestamps are decoded into the strings as written, the anchors are resolved,
+// and the merge keys (<<) are expanded.
+package yaml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"time"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+const (
+	tagNull      = `!!null`
+	tagString    = `!!str`
+	tagTimestamp = `!!timestamp`
+	tagMerge     = `!!merge`
+)
+
+// Document represents a YAML document.
+// Value is the decoded value that JSONPath retrieves from, and that the accessors change.
+// The node tree of the document is kept, so that Marshal writes the changes back preserving the comments,
+// the order of the keys and the styles of the values left unchanged.
+type Document struct {
+	Value any
+	node  *yamlv3.Node
+}
+
+// Unmarshal decodes the first YAML document in data into v.
+// An empty data is decoded into nil.
+func Unmarshal(data []byte, v *any) error {
+	document, err := ParseDocument(data)
+	if err != nil {
+		return err
+	}
+	*v = document.Value
+	return nil
+}
+
+// ParseDocument parses the first YAML document in data.
+func ParseDocument(data []byte) (*Document, error) {
+	documents, err := parseDocuments(data, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) == 0 {
+		return &Document{}, nil
+	}
+	return documents[0], nil
+}
+
+// ParseDocuments parses all the YAML documents separated by `---` in data, such as the Kubernetes manifests.
+func ParseDocuments(data []byte) ([]*Document, error) {
+	return parseDocuments(data, -1)
+}
+
+func parseDocuments(data []byte, limit int) ([]*Document, error) {
+	var documents []*Document
+	decoder := yamlv3.NewDecoder(bytes.NewReader(data))
+	for limit < 0 || len(documents) < limit {
+		node := &yamlv3.Node{}
+		if err := decoder.Decode(node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		value, err := decodeNode(node)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, &Document{Value: value, node: node})
+	}
+	return documents, nil
+}
+
+// Marshal returns the YAML of the document with the changes of Value.
+// The nodes whose values are unchanged are written as they were parsed.
+// The changed values are written in the default style, keeping the comments attached to them
+// and the quotes of the strings replaced by the strings.
+// The mapping with the merge key is written with the merged keys expanded if any of its values is changed.
+// The alias is written as the value if the value differs from the anchored one.
+func (d *Document) Marshal() ([]byte, error) {
+	return MarshalDocuments([]*Document{d})
+}
+
+// MarshalDocuments returns the YAML of the documents separated by `---`.
+func MarshalDocuments(documents []*Document) ([]byte, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+	var buffer bytes.Buffer
+	encoder := yamlv3.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	for _, document := range documents {
+		node, err := document.syncNode()
+		if err != nil {
+			return nil, err
+		}
+		if err := encoder.Encode(node); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// syncNode updates the node tree with the changes of Value, and returns the node to encode.
+func (d *Document) syncNode() (*yamlv3.Node, error) {
+	if d.node == nil || len(d.node.Content) == 0 {
+		node, err := newNode(d.Value)
+		if err != nil {
+			return nil, err
+		}
+		d.node = &yamlv3.Node{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{node}}
+		return d.node, nil
+	}
+	node, err := syncNode(d.node.Content[0], d.Value)
+	if err != nil {
+		return nil, err
+	}
+	d.node.Content[0] = node
+	return d.node, nil
+}
+
+// decodeNode returns the value of the node in the shapes that JSONPath retrieves from.
+func decodeNode(node *yamlv3.Node) (any, error) {
+	return (&decoder{}).decode(node)
+}
+
+// The ratio of the values decoded through the aliases is limited in the same way as yaml.v3 does,
+// so that the document with excessive aliasing, such as the billion laughs, is not expanded.
+const (
+	aliasRatioRangeLow  = 400000
+	aliasRatioRangeHigh = 4000000
+)
+
+func allowedAliasRatio(decodeCount int) float64 {
+	switch {
+	case decodeCount <= aliasRatioRangeLow:
+		return 0.99
+	case decodeCount >= aliasRatioRangeHigh:
+		return 0.10
+	}
+	return 0.99 - 0.89*(float64(decodeCount-aliasRatioRangeLow)/float64(aliasRatioRangeHigh-aliasRatioRangeLow))
+}
+
+// decoder keeps the state of expanding the aliases while decoding the node tree.
+type decoder struct {
+	expanding   map[*yamlv3.Node]bool
+	aliasDepth  int
+	decodeCount int
+	aliasCount  int
+}
+
+func (d *decoder) decode(node *yamlv3.Node) (any, error) {
+	d.decodeCount++
+	if d.aliasDepth > 0 {
+		d.aliasCount++
+	}
+	if d.aliasCount > 100 && d.decodeCount > 1000 &&
+		float64(d.aliasCount)/float64(d.decodeCount) > allowedAliasRatio(d.decodeCount) {
+		return nil, errors.New(`yaml: document contains excessive aliasing`)
+	}
+
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return d.decode(node.Content[0])
+
+	case yamlv3.AliasNode:
+		return d.decodeAlias(node)
+
+	case yamlv3.SequenceNode:
+		list := make([]any, len(node.Content))
+		for index, child := range node.Content {
+			value, err := d.decode(child)
+			if err != nil {
+				return nil, err
+			}
+			list[index] = value
+		}
+		return list, nil
+
+	case yamlv3.MappingNode:
+		return d.decodeMapping(node)
+
+	case yamlv3.ScalarNode:
+		return decodeScalar(node)
+	}
+	return nil, fmt.Errorf(`yaml: unsupported node at line %d`, node.Line)
+}
+
+// decodeAlias returns the value of the anchored node, failing if the node contains the alias itself.
+func (d *decoder) decodeAlias(node *yamlv3.Node) (any, error) {
+	if d.expanding[node.Alias] {
+		return nil, fmt.Errorf(`yaml: anchor '%s' value contains itself at line %d`, node.Value, node.Line)
+	}
+	if d.expanding == nil {
+		d.expanding = map[*yamlv3.Node]bool{}
+	}
+	d.expanding[node.Alias] = true
+	d.aliasDepth++
+	value, err := d.decode(node.Alias)
+	d.aliasDepth--
+	delete(d.expanding, node.Alias)
+	return value, err
+}
+
+// decodeMapping returns the map of the mapping node.
+// The explicit keys take priority over the merged keys, and the earlier merged mappings over the later ones.
+func (d *decoder) decodeMapping(node *yamlv3.Node) (map[string]any, error) {
+	object := make(map[string]any, len(node.Content)/2)
+	var merged []map[string]any
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		keyNode, valueNode := node.Content[index], node.Content[index+1]
+		if keyNode.Tag == tagMerge {
+			mergedObjects, err := d.decodeMerge(valueNode)
+			if err != nil {
+				return nil, err
+			}
+			merged = append(merged, mergedObjects...)
+			continue
+		}
+		key, err := decodeKey(keyNode)
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.decode(valueNode)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = value
+	}
+	for _, mergedObject := range merged {
+		for key, value := range mergedObject {
+			if _, ok := object[key]; !ok {
+				object[key] = value
+			}
+		}
+	}
+	return object, nil
+}
+
+// decodeMerge returns the mappings merged by the merge key, either a mapping or a sequence of the mappings.
+func (d *decoder) decodeMerge(node *yamlv3.Node) ([]map[string]any, error) {
+	if node.Kind == yamlv3.SequenceNode {
+		var objects []map[string]any
+		for _, child := range node.Content {
+			childObjects, err := d.decodeMerge(child)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, childObjects...)
+		}
+		return objects, nil
+	}
+	value, err := d.decode(node)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf(`yaml: merge of a value that is not a mapping at line %d`, node.Line)
+	}
+	return []map[string]any{object}, nil
+}
+
+func decodeKey(node *yamlv3.Node) (string, error) {
+	if node.Kind == yamlv3.AliasNode {
+		return decodeKey(node.Alias)
+	}
+	if node.Kind != yamlv3.ScalarNode {
+		return ``, fmt.Errorf(`yaml: key that is not a scalar at line %d`, node.Line)
+	}
+	if node.ShortTag() == tagNull {
+		return `null`, nil
+	}
+	return node.Value, nil
+}
+
+func decodeScalar(node *yamlv3.Node) (any, error) {
+	switch node.ShortTag() {
+	case tagNull:
+		return nil, nil
+	case tagString, tagTimestamp:
+		return node.Value, nil
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	switch typedValue := value.(type) {
+	case int:
+		return float64(typedValue), nil
+	case int64:
+		return float64(typedValue), nil
+	case uint64:
+		return float64(typedValue), nil
+	case time.Time:
+		return node.Value, nil
+	}
+	return value, nil
+}
+
+// syncNode returns the node updated with the value.
+// The node is returned as it is if its value is unchanged, so that its style, its anchor and its comments remain.
+func syncNode(node *yamlv3.Node, value any) (*yamlv3.Node, error) {
+	current, err := decodeNode(node)
+	if err != nil {
+		return nil, err
+	}
+	if isSameValue(current, value) {
+		return node, nil
+	}
+
+	switch typedValue := value.(type) {
+	case map[string]any:
+		if node.Kind == yamlv3.MappingNode && !hasMergeKey(node) {
+			return syncMapping(node, typedValue)
+		}
+	case []any:
+		if node.Kind == yamlv3.SequenceNode {
+			return syncSequence(node, typedValue)
+		}
+	}
+
+	newValueNode, err := newNode(value)
+	if err != nil {
+		return nil, err
+	}
+	if node.Kind == yamlv3.ScalarNode && node.ShortTag() == tagString && newValueNode.ShortTag() == tagString {
+		newValueNode.Style = node.Style
+	}
+	newValueNode.HeadComment = node.HeadComment
+	newValueNode.FootComment = node.FootComment
+	if newValueNode.Kind == yamlv3.ScalarNode || newValueNode.Style == yamlv3.FlowStyle {
+		newValueNode.LineComment = node.LineComment
+	}
+	return newValueNode, nil
+}
+
+// syncMapping updates the values of the existing keys in place, removes the deleted keys,
+// and appends the added keys in order of the names.
+func syncMapping(node *yamlv3.Node, object map[string]any) (*yamlv3.Node, error) {
+	var content []*yamlv3.Node
+	existingKeys := map[string]bool{}
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		keyNode := node.Content[index]
+		key, err := decodeKey(keyNode)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := object[key]
+		if !ok {
+			continue
+		}
+		valueNode, err := syncNode(node.Content[index+1], value)
+		if err != nil {
+			return nil, err
+		}
+		// The line comment of the value replaced by a block is written after the key.
+		if lineComment := node.Content[index+1].LineComment; valueNode.LineComment != lineComment && keyNode.LineComment == `` {
+			keyNode.LineComment = lineComment
+		}
+		existingKeys[key] = true
+		content = append(content, keyNode, valueNode)
+	}
+
+	var addedKeys []string
+	for key := range object {
+		if !existingKeys[key] {
+			addedKeys = append(addedKeys, key)
+		}
+	}
+	slices.Sort(addedKeys)
+	for _, key := range addedKeys {
+		valueNode, err := newNode(object[key])
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: tagString, Value: key}, valueNode)
+	}
+
+	node.Content = content
+	return node, nil
+}
+
+// syncSequence updates the existing elements in place, and removes or appends the elements at the end.
+func syncSequence(node *yamlv3.Node, list []any) (*yamlv3.Node, error) {
+	content := make([]*yamlv3.Node, len(list))
+	for index, value := range list {
+		var err error
+		if index < len(node.Content) {
+			content[index], err = syncNode(node.Content[index], value)
+		} else {
+			content[index], err = newNode(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	node.Content = content
+	return node, nil
+}
+
+func newNode(value any) (*yamlv3.Node, error) {
+	node := &yamlv3.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func hasMergeKey(node *yamlv3.Node) bool {
+	for index := 0; index < len(node.Content); index += 2 {
+		if node.Content[index].Tag == tagMerge {
+			return true
+		}
+	}
+	return false
+}
+
+// isSameValue reports whether the values are equal, treating NaN as equal to NaN.
+func isSameValue(left, right any) bool {
+	if leftNumber, ok := left.(float64); ok {
+		if rightNumber, ok := right.(float64); ok && math.IsNaN(leftNumber) && math.IsNaN(rightNumber) {
+			return true
+		}
+	}
+	return reflect.DeepEqual(left, right)
+}